@@ -0,0 +1,103 @@
+package cronmath
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewAnchored starts a fluent chain whose shifts are computed on actual
+// instants: the expression is taken to fire on anchor's date on the wall
+// clock of loc, and Add, Sub, AddClock and SubClock move that instant.
+// Crossing a daylight saving change is then accounted for, so
+// "30 2 * * *" in America/New_York anchored on 8 March 2025 plus 24 hours
+// becomes "30 3 * * *", and crossing midnight moves the day fields by the
+// days of the real calendar. The anchor follows each shift. Only
+// expressions with a single fixed time can be shifted this way.
+func NewAnchored(expr string, loc *time.Location, anchor time.Time, opts ...Option) *CronMath {
+	return New(expr, append(opts, withWallClock(loc, anchor))...)
+}
+
+// withWallClock makes shifts move the instant the expression fires at on
+// anchor's date in loc
+func withWallClock(loc *time.Location, anchor time.Time) Option {
+	return func(cfg *config) {
+		cfg.wallLocation = loc
+		y, m, d := anchor.In(loc).Date()
+		cfg.wallTime = time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+}
+
+// Anchor returns the instant the expression fires at on its anchor date,
+// for expressions created with NewAnchored
+func (c *CronTime) Anchor() (time.Time, bool) {
+	if c.cfg.wallLocation == nil {
+		return time.Time{}, false
+	}
+	t, err := c.wallInstant()
+	return t, err == nil
+}
+
+// wallInstant returns the instant the expression fires at on its anchor
+// date. The instant a shift landed on is kept, so a time repeated by a
+// daylight saving change stays the one shifted to. A time skipped by one
+// is read on the clock in force before it, so 2:30 on a spring-forward
+// date is 3:30.
+func (c *CronTime) wallInstant() (time.Time, error) {
+	m, sec, err := c.clock()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("wall-clock shifts need a single fixed time: %w", err)
+	}
+	at := c.cfg.wallTime
+	if at.Hour()*60+at.Minute() == m && at.Second() == sec {
+		return at, nil
+	}
+
+	y, mon, d := at.Date()
+	t := time.Date(y, mon, d, m/60, m%60, sec, 0, c.cfg.wallLocation)
+	want := time.Date(y, mon, d, m/60, m%60, sec, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	if gap := want.Sub(got); gap > 0 {
+		t = t.Add(gap)
+	}
+	return t, nil
+}
+
+// adjustWallClock shifts the instant the expression fires at on its
+// anchor date by totalMinutes, then moves the day fields by the calendar
+// days between the two instants
+func (c *CronTime) adjustWallClock(totalMinutes int64) error {
+	if totalMinutes > maxShiftMinutes || totalMinutes < -maxShiftMinutes {
+		return &OverflowError{Value: totalMinutes, Unit: "minutes"}
+	}
+	start, err := c.wallInstant()
+	if err != nil {
+		return err
+	}
+	end := start.Add(time.Duration(totalMinutes) * time.Minute)
+
+	days := calendarDays(start, end)
+	if days != 0 && c.cfg.noWrap {
+		wrapped := end.Hour()*60 + end.Minute()
+		return &DayBoundaryError{From: start.Hour()*60 + start.Minute(), To: wrapped + days*minutesPerDay, Wrapped: wrapped}
+	}
+	if days != 0 {
+		if err := c.moveDays(days); err != nil {
+			return err
+		}
+	}
+	c.setClock(end.Minute(), end.Hour())
+	c.cfg.wallTime = end
+	return nil
+}
+
+// calendarDays returns the number of calendar days from a's date to b's,
+// each read in its own location
+func calendarDays(a, b time.Time) int {
+	return dayNumber(b) - dayNumber(a)
+}
+
+// dayNumber counts the days from the Unix epoch to t's date
+func dayNumber(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
@@ -0,0 +1,109 @@
+package cronmath
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewAnchored(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone data:", err)
+	}
+
+	tests := []struct {
+		name       string
+		expr       string
+		anchor     time.Time
+		shift      func(*CronMath) *CronMath
+		want       string
+		wantAnchor time.Time
+	}{
+		{
+			name:       "a day across spring forward",
+			expr:       "30 2 * * *",
+			anchor:     time.Date(2025, 3, 8, 0, 0, 0, 0, ny),
+			shift:      func(cm *CronMath) *CronMath { return cm.Add(Hours(24)) },
+			want:       "30 3 * * *",
+			wantAnchor: time.Date(2025, 3, 9, 3, 30, 0, 0, ny),
+		},
+		{
+			name:       "from a skipped time",
+			expr:       "30 2 * * *",
+			anchor:     time.Date(2025, 3, 9, 0, 0, 0, 0, ny),
+			shift:      func(cm *CronMath) *CronMath { return cm.Add(Hours(1)) },
+			want:       "30 4 * * *",
+			wantAnchor: time.Date(2025, 3, 9, 4, 30, 0, 0, ny),
+		},
+		{
+			name:       "into a repeated hour",
+			expr:       "30 1 * * *",
+			anchor:     time.Date(2025, 11, 2, 0, 0, 0, 0, ny),
+			shift:      func(cm *CronMath) *CronMath { return cm.Add(Hours(1)).Add(Hours(1)) },
+			want:       "30 2 * * *",
+			wantAnchor: time.Date(2025, 11, 2, 2, 30, 0, 0, ny),
+		},
+		{
+			name:       "day of month from the real calendar",
+			expr:       "0 1 1 * *",
+			anchor:     time.Date(2025, 3, 1, 0, 0, 0, 0, ny),
+			shift:      func(cm *CronMath) *CronMath { return cm.Sub(Hours(2)) },
+			want:       "0 23 28 * *",
+			wantAnchor: time.Date(2025, 2, 28, 23, 0, 0, 0, ny),
+		},
+		{
+			name:       "into the next month",
+			expr:       "30 23 31 1 *",
+			anchor:     time.Date(2025, 1, 31, 0, 0, 0, 0, ny),
+			shift:      func(cm *CronMath) *CronMath { return cm.AddClock(2, 0) },
+			want:       "30 1 1 2 *",
+			wantAnchor: time.Date(2025, 2, 1, 1, 30, 0, 0, ny),
+		},
+		{
+			name:       "day of week",
+			expr:       "30 23 * * FRI",
+			anchor:     time.Date(2025, 1, 31, 0, 0, 0, 0, ny),
+			shift:      func(cm *CronMath) *CronMath { return cm.Add(Hours(2)) },
+			want:       "30 1 * * 6",
+			wantAnchor: time.Date(2025, 2, 1, 1, 30, 0, 0, ny),
+		},
+		{
+			name:       "shift days",
+			expr:       "0 9 * * *",
+			anchor:     time.Date(2025, 3, 8, 0, 0, 0, 0, ny),
+			shift:      func(cm *CronMath) *CronMath { return cm.ShiftDays(1).Add(Hours(1)) },
+			want:       "0 10 * * *",
+			wantAnchor: time.Date(2025, 3, 9, 10, 0, 0, 0, ny),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := tt.shift(NewAnchored(tt.expr, ny, tt.anchor))
+			if err := cm.Error(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := cm.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			got, ok := cm.cron.Anchor()
+			if !ok || !got.Equal(tt.wantAnchor) {
+				t.Errorf("Anchor() = %v, %v, want %v", got, ok, tt.wantAnchor)
+			}
+		})
+	}
+}
+
+func TestNewAnchoredNeedsFixedTime(t *testing.T) {
+	cm := NewAnchored("*/5 9 * * *", time.UTC, time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)).Add(Hours(1))
+	if cm.Error() == nil {
+		t.Errorf("expected an error for a stepped minute, got %q", cm.String())
+	}
+}
+
+func TestAnchorUnanchored(t *testing.T) {
+	c, _ := ParseCron("0 9 * * *")
+	if _, ok := c.Anchor(); ok {
+		t.Error("Anchor() reported an anchor for an expression without one")
+	}
+}
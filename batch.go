@@ -0,0 +1,101 @@
+package cronmath
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ShiftAll parses each expression, adds d to it and writes it back,
+// returning the results and errors by position. An expression that cannot
+// be parsed or shifted keeps its original string in the results and gets
+// a *BatchError in errs, without stopping the others. Entries parsed as
+// "@reboot" are passed through unchanged. errs has an entry for every
+// expression, nil for those that were shifted.
+func ShiftAll(exprs []string, d Duration, opts ...Option) (results []string, errs []error) {
+	results = make([]string, len(exprs))
+	errs = make([]error, len(exprs))
+	for i, expr := range exprs {
+		results[i] = expr
+		c, err := ParseCronWith(expr, opts...)
+		if err == nil && !c.IsReboot() {
+			err = c.Add(d)
+		}
+		if err != nil {
+			errs[i] = &BatchError{Index: i, Expr: expr, Err: err}
+			continue
+		}
+		results[i] = c.String()
+	}
+	return results, errs
+}
+
+// BatchError is the error of one expression of a batch given to ShiftAll
+type BatchError struct {
+	// Index is the position of the expression in the batch
+	Index int
+	Expr  string
+	Err   error
+}
+
+// Error renders the error as `entry 3 ("*/5 9 * * *"): cause`
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("entry %d (%q): %v", e.Index, e.Expr, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// ShiftMap returns a copy of m with d added to the values keyed by the
+// keys keyFilter selects, or every key when it is nil, as found in Helm
+// values and ConfigMaps holding schedules among other settings. Values
+// that parse as expressions are shifted, and the rest are copied, as
+// are values the filter passes over. A value that parses but cannot be
+// shifted, or has five fields and does not parse, is copied unchanged
+// and reported in errs as a *MapError, in the order of the keys. m is
+// left untouched.
+func ShiftMap(m map[string]string, d Duration, keyFilter func(string) bool) (map[string]string, []error) {
+	out := make(map[string]string, len(m))
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		value := m[key]
+		out[key] = value
+		if keyFilter != nil && !keyFilter(key) {
+			continue
+		}
+
+		c, err := ParseCron(value)
+		switch {
+		case err != nil && len(strings.Fields(value)) != 5:
+			continue
+		case err == nil && !c.IsReboot():
+			err = c.Add(d)
+		}
+		if err != nil {
+			errs = append(errs, &MapError{Key: key, Expr: value, Err: err})
+			continue
+		}
+		out[key] = c.String()
+	}
+	return out, errs
+}
+
+// MapError is the error of one value of a map given to ShiftMap
+type MapError struct {
+	Key  string
+	Expr string
+	Err  error
+}
+
+// Error renders the error as `key "backup" ("*/5 9 * * *"): cause`
+func (e *MapError) Error() string {
+	return fmt.Sprintf("key %q (%q): %v", e.Key, e.Expr, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *MapError) Unwrap() error {
+	return e.Err
+}
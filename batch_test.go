@@ -0,0 +1,120 @@
+package cronmath
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShiftAll(t *testing.T) {
+	exprs := []string{
+		"0 9 * * 1-5",
+		"30 * * * *",
+		"not a cron",
+		"@reboot",
+		"15 0 * * *",
+		"0,30 9-17 * * *",
+	}
+	got, errs := ShiftAll(exprs, Minutes(-30))
+
+	want := []string{
+		"30 8 * * 1-5",
+		"0 * * * *",
+		"not a cron",
+		"@reboot",
+		"45 23 * * *",
+		"0,30 9-17 * * *",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ShiftAll() = %q, want %q", got, want)
+	}
+	if len(errs) != len(exprs) {
+		t.Fatalf("ShiftAll() returned %d errors, want %d", len(errs), len(exprs))
+	}
+
+	for i, err := range errs {
+		switch i {
+		case 2, 5:
+			var be *BatchError
+			if !errors.As(err, &be) || be.Index != i || be.Expr != exprs[i] {
+				t.Errorf("errs[%d] = %v, want a *BatchError for %q", i, err, exprs[i])
+			}
+		default:
+			if err != nil {
+				t.Errorf("errs[%d] = %v, want nil", i, err)
+			}
+		}
+	}
+	if !strings.HasPrefix(errs[2].Error(), `entry 2 ("not a cron"): invalid cron expression`) {
+		t.Errorf("errs[2] = %q", errs[2])
+	}
+}
+
+func TestShiftAll_Options(t *testing.T) {
+	got, errs := ShiftAll([]string{"0 0 9 * * MON"}, Hours(1), WithAutoFields(), WithNumericOutput())
+	if errs[0] != nil || got[0] != "0 0 10 * * 1" {
+		t.Errorf("ShiftAll() = %q, %v", got, errs)
+	}
+
+	got, errs = ShiftAll(nil, Hours(1))
+	if len(got) != 0 || len(errs) != 0 {
+		t.Errorf("ShiftAll(nil) = %q, %v", got, errs)
+	}
+}
+
+func TestShiftMap(t *testing.T) {
+	m := map[string]string{
+		"backup.schedule": "0 2 * * *",
+		"report.schedule": "30 23 * * 1-5",
+		"month.schedule":  "0 23 28 * *",
+		"typo.schedule":   "0 25 * * *",
+		"boot.schedule":   "@reboot",
+		"image":           "nginx:1.27",
+		"replicas":        "3",
+		"args":            "a b c d e",
+		"other.cron":      "0 4 * * *",
+	}
+	before := maps.Clone(m)
+
+	got, errs := ShiftMap(m, Hours(1), func(key string) bool {
+		return key == "args" || strings.HasSuffix(key, ".schedule") || key == "image"
+	})
+	want := map[string]string{
+		"backup.schedule": "0 3 * * *",
+		"report.schedule": "30 0 * * 2-6",
+		"month.schedule":  "0 23 28 * *",
+		"typo.schedule":   "0 25 * * *",
+		"boot.schedule":   "@reboot",
+		"image":           "nginx:1.27",
+		"replicas":        "3",
+		"args":            "a b c d e",
+		"other.cron":      "0 4 * * *",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ShiftMap() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(m, before) {
+		t.Errorf("ShiftMap() changed its input to %v", m)
+	}
+
+	var keys []string
+	for _, err := range errs {
+		var mapErr *MapError
+		if !errors.As(err, &mapErr) {
+			t.Fatalf("error %v is not a *MapError", err)
+		}
+		keys = append(keys, mapErr.Key)
+	}
+	if wantKeys := []string{"args", "month.schedule", "typo.schedule"}; !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("ShiftMap() errors for %q, want %q", keys, wantKeys)
+	}
+	if !strings.Contains(errs[1].Error(), `key "month.schedule" ("0 23 28 * *"): cannot shift day of month 28`) {
+		t.Errorf("errs[1] = %v", errs[1])
+	}
+
+	if got, errs := ShiftMap(m, Hours(1), nil); got["other.cron"] != "0 5 * * *" || len(errs) != 3 {
+		t.Errorf("ShiftMap(nil filter) = %v, %v", got, errs)
+	}
+}
@@ -0,0 +1,105 @@
+package cronmath
+
+import "testing"
+
+// sink keeps benchmarked results alive
+var sink string
+
+// chainOps is the number of shifts in the chain benchmarks
+const chainOps = 10
+
+func BenchmarkChain(b *testing.B) {
+	b.Run("CronTime", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			c, _ := ParseCron("30 9 1 * *")
+			for i := range chainOps {
+				if i%2 == 0 {
+					_ = c.Add(Minutes(45))
+				} else {
+					_ = c.Sub(Minutes(20))
+				}
+			}
+			sink = c.String()
+		}
+	})
+	b.Run("CronMath", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			cm := New("30 9 1 * *")
+			for i := range chainOps {
+				if i%2 == 0 {
+					cm.Add(Minutes(45))
+				} else {
+					cm.Sub(Minutes(20))
+				}
+			}
+			sink = cm.String()
+		}
+	})
+}
+
+// benchExprs covers the plain, named and six-field forms String writes
+var benchExprs = []struct {
+	name string
+	expr string
+	opts []Option
+}{
+	{"plain", "30 9 * * 1-5", nil},
+	{"names", "0 */2 1,15 JAN-JUN mon-fri", []Option{WithNameCase(TitleCase)}},
+	{"sunday", "0 0 * * 5-7", nil},
+	{"seconds", "15 30 9 * * ? 2030", []Option{WithAutoFields()}},
+}
+
+func BenchmarkParseCron(b *testing.B) {
+	for _, bb := range benchExprs {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := ParseCronWith(bb.expr, bb.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	for _, bb := range benchExprs {
+		c, err := ParseCronWith(bb.expr, bb.opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				sink = c.String()
+			}
+		})
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	for _, bb := range benchExprs {
+		c, err := ParseCronWith(bb.expr, bb.opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 64)
+			for range b.N {
+				buf = c.AppendString(buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkAddSub(b *testing.B) {
+	c, _ := ParseCron("30 9 1 * *")
+	b.ReportAllocs()
+	for range b.N {
+		_ = c.Add(Hours(20))
+		_ = c.Sub(Hours(20))
+	}
+}
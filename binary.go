@@ -0,0 +1,316 @@
+package cronmath
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// binaryVersion is the first byte of the MarshalBinary encoding. Decoders
+// keep accepting every earlier version. Version 2 describes dialects in
+// full, as RegisterDialect lets them be described, version 3 adds a byte
+// of further options and version 4 the comment and the wall clock of
+// NewAnchored.
+const binaryVersion = 4
+
+// Flags of the binary encoding
+const (
+	binSundaySeven = 1 << iota
+	binStrict
+	binAutoFields
+	binMacroOutput
+	binZeroPad
+	binDialect
+	binAnchor
+	binIntersectDays
+)
+
+// Flags of the further options byte, from version 3
+const (
+	binNoWrap = 1 << iota
+	binExpandSteps
+)
+
+// Flags of a dialect in the binary encoding. Version 1 sets binMacros for
+// every macro; version 2 lists the macros instead and uses the bit for
+// IntersectDays.
+const (
+	binSteps = 1 << iota
+	binNames
+	binMacros
+	binDialectSundaySeven
+	binLastDay
+	binNthWeekday
+	binNoSpecific
+	binRequireNoSpecific
+	binDialectIntersectDays = binMacros
+)
+
+// MarshalBinary encodes the expression as written along with its layout
+// and options, so encoding/gob and other users of encoding.BinaryMarshaler
+// restore it exactly. The encoding starts with a version byte. Calendars
+// given with WithCalendar are code and cannot be encoded.
+func (c *CronTime) MarshalBinary() ([]byte, error) {
+	if c.cfg.calendar != nil {
+		return nil, errors.New("cannot encode an expression with a calendar")
+	}
+
+	b := []byte{binaryVersion, byte(c.layout)}
+	for _, s := range []string{c.Second, c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek, c.Year, c.macro} {
+		b = appendString(b, s)
+	}
+
+	flags := packFlags(c.cfg.sundaySeven, c.cfg.strict, c.cfg.autoFields, c.cfg.macroOutput,
+		c.cfg.zeroPad, c.cfg.dialect != nil, !c.cfg.anchor.IsZero(), c.cfg.intersectDays)
+	// The value names share a byte with the name case, which needs only
+	// the low bits, so older encodings decode with the default
+	b = append(b, flags, byte(c.cfg.nameCase)|byte(c.cfg.valueNames)<<4, packFlags(c.cfg.noWrap, c.cfg.expandSteps))
+
+	if d := c.cfg.dialect; d != nil {
+		b = appendDialect(b, d)
+	}
+	if !c.cfg.anchor.IsZero() {
+		b = binary.AppendVarint(b, int64(c.cfg.anchor.Year()))
+		b = append(b, byte(c.cfg.anchor.Month()))
+	}
+
+	var loc string
+	if c.cfg.location != nil {
+		loc = c.cfg.location.String()
+	}
+	b = appendString(b, loc)
+	b = appendString(b, c.comment)
+
+	// The wall clock's location, then the instant shifts start from
+	var wall string
+	if c.cfg.wallLocation != nil {
+		wall = c.cfg.wallLocation.String()
+	}
+	b = appendString(b, wall)
+	if wall != "" {
+		b = binary.AppendVarint(b, c.cfg.wallTime.Unix())
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes an expression encoded by MarshalBinary,
+// replacing c
+func (c *CronTime) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	version := r.byte()
+	if version < 1 || version > binaryVersion {
+		if r.err != nil {
+			return r.err
+		}
+		return fmt.Errorf("unsupported encoding version %d", version)
+	}
+
+	var out CronTime
+	out.layout = Layout(r.byte())
+	for _, s := range []*string{&out.Second, &out.Minute, &out.Hour, &out.DayOfMonth, &out.Month, &out.DayOfWeek, &out.Year, &out.macro} {
+		*s = r.string()
+	}
+
+	flags, names := r.byte(), r.byte()
+	var more byte
+	if version >= 3 {
+		more = r.byte()
+	}
+	out.cfg = config{
+		sundaySeven:   flags&binSundaySeven != 0,
+		strict:        flags&binStrict != 0,
+		autoFields:    flags&binAutoFields != 0,
+		macroOutput:   flags&binMacroOutput != 0,
+		zeroPad:       flags&binZeroPad != 0,
+		intersectDays: flags&binIntersectDays != 0,
+		nameCase:      NameCase(names & 0x0f),
+		valueNames:    valueNames(names >> 4),
+		noWrap:        more&binNoWrap != 0,
+		expandSteps:   more&binExpandSteps != 0,
+	}
+	if flags&binDialect != 0 {
+		out.cfg.dialect = r.dialect(version)
+	}
+	if flags&binAnchor != 0 {
+		year, month := r.varint(), r.byte()
+		out.cfg.anchor = time.Date(int(year), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if name := r.string(); name != "" && r.err == nil {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("error decoding location: %v", err)
+		}
+		out.cfg.location = loc
+	}
+	if version >= 4 {
+		out.comment = r.string()
+		if name := r.string(); name != "" && r.err == nil {
+			loc, err := time.LoadLocation(name)
+			if err != nil {
+				return fmt.Errorf("error decoding wall clock location: %v", err)
+			}
+			out.cfg.wallLocation = loc
+			out.cfg.wallTime = time.Unix(r.varint(), 0).In(loc)
+		}
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) != 0 {
+		return fmt.Errorf("%d trailing bytes after encoded expression", len(r.data))
+	}
+	if out.layout > LayoutYear {
+		return fmt.Errorf("invalid layout %d", out.layout)
+	}
+	if !out.IsReboot() {
+		out.cacheClock()
+	}
+	*c = out
+	return nil
+}
+
+// packFlags returns a byte with bit i set when bits[i] is true, matching
+// the flag constants above
+func packFlags(bits ...bool) byte {
+	var b byte
+	for i, set := range bits {
+		if set {
+			b |= 1 << i
+		}
+	}
+	return b
+}
+
+// appendDialect appends the description of d to b
+func appendDialect(b []byte, d *Dialect) []byte {
+	b = appendString(b, d.Name)
+	b = append(b, packFlags(d.Steps, d.Names, d.IntersectDays, d.SundaySeven, d.LastDay, d.NthWeekday,
+		d.NoSpecific, d.RequireNoSpecific), byte(d.DayOfWeekBase))
+
+	b = binary.AppendVarint(b, int64(len(d.Layouts)))
+	for _, l := range d.Layouts {
+		b = append(b, byte(l))
+	}
+	b = binary.AppendVarint(b, int64(len(d.Macros)))
+	for _, m := range d.Macros {
+		b = appendString(b, m)
+	}
+	names := slices.Sorted(maps.Keys(d.Ranges))
+	b = binary.AppendVarint(b, int64(len(names)))
+	for _, name := range names {
+		b = binary.AppendVarint(appendString(b, name), int64(d.Ranges[name][0]))
+		b = binary.AppendVarint(b, int64(d.Ranges[name][1]))
+	}
+	return b
+}
+
+// appendString appends s to b prefixed by its length
+func appendString(b []byte, s string) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(s))), s...)
+}
+
+// binaryReader reads a MarshalBinary encoding, remembering the first
+// error so that callers can check once at the end
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+var errTruncated = errors.New("truncated encoding")
+
+func (r *binaryReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) == 0 {
+		r.err = errTruncated
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *binaryReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = errTruncated
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// dialect reads a dialect as appendDialect writes it, or as version 1
+// did
+func (r *binaryReader) dialect(version byte) *Dialect {
+	name, df := r.string(), r.byte()
+	d := &Dialect{
+		Name:        name,
+		Steps:       df&binSteps != 0,
+		Names:       df&binNames != 0,
+		SundaySeven: df&binDialectSundaySeven != 0,
+		LastDay:     df&binLastDay != 0,
+		NthWeekday:  df&binNthWeekday != 0,
+	}
+	if version == 1 {
+		if df&binMacros != 0 {
+			d.Macros = allMacros
+		}
+		return d
+	}
+
+	d.NoSpecific = df&binNoSpecific != 0
+	d.RequireNoSpecific = df&binRequireNoSpecific != 0
+	d.IntersectDays = df&binDialectIntersectDays != 0
+	d.DayOfWeekBase = int(r.byte())
+	for range r.count() {
+		d.Layouts = append(d.Layouts, Layout(r.byte()))
+	}
+	for range r.count() {
+		d.Macros = append(d.Macros, r.string())
+	}
+	for range r.count() {
+		if d.Ranges == nil {
+			d.Ranges = map[string][2]int{}
+		}
+		name := r.string()
+		d.Ranges[name] = [2]int{int(r.varint()), int(r.varint())}
+	}
+	return d
+}
+
+// count reads the length of a list, rejecting lengths longer than the
+// data left
+func (r *binaryReader) count() int {
+	n := r.varint()
+	if n < 0 || n > int64(len(r.data)) {
+		if r.err == nil {
+			r.err = errTruncated
+		}
+		return 0
+	}
+	return int(n)
+}
+
+func (r *binaryReader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	n, k := binary.Uvarint(r.data)
+	if k <= 0 || n > uint64(len(r.data)-k) {
+		r.err = errTruncated
+		return ""
+	}
+	s := string(r.data[k : k+int(n)])
+	r.data = r.data[k+int(n):]
+	return s
+}
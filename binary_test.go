@@ -0,0 +1,166 @@
+package cronmath
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCronTime_BinaryRoundTrip(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+	}{
+		{"plain", "05 9 * * MON-FRI", nil},
+		{"comment", "5 9 * * * # morning report", nil},
+		{"macro", "@daily", []Option{WithMacroOutput()}},
+		{"seconds and year", "30 0 9 ? * MON 2030", []Option{WithAutoFields()}},
+		{"output options", "0 9 * jan 0", []Option{WithSundayAsSeven(), WithNameCase(TitleCase), WithZeroPad()}},
+		{"named output", "0 9 * 1 1-5", []Option{WithNamedOutput(), WithNameCase(LowerCase)}},
+		{"dialect", "0 9 * * 1-5", []Option{WithDialect(BusyBox), WithStrict()}},
+		{"described dialect", "0 5 9 ? * 2-6 2030", []Option{WithDialect(Quartz)}},
+		{"dialect ranges", "@daily", []Option{WithDialect(Dialect{Name: "mine", Macros: []string{"@daily"}, Ranges: map[string][2]int{"hour": {0, 11}}})}},
+		{"day intersection", "0 9 13 * 5", []Option{WithDOMDOWIntersection()}},
+		{"no wrap and expanded steps", "0 9 * * *", []Option{WithNoWrap(), WithExpandSteps()}},
+		{"location and anchor", "0 2 1 * *", []Option{WithLocation(tokyo), WithAnchorMonth(2025, time.March)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+
+			data, err := cron.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() error = %v", err)
+			}
+			var got CronTime
+			if err := got.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() error = %v", err)
+			}
+			if !reflect.DeepEqual(&got, cron) {
+				t.Errorf("UnmarshalBinary() = %+v, want %+v", got, *cron)
+			}
+
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(cron); err != nil {
+				t.Fatalf("gob Encode() error = %v", err)
+			}
+			var decoded *CronTime
+			if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+				t.Fatalf("gob Decode() error = %v", err)
+			}
+			if decoded.String() != cron.String() {
+				t.Errorf("gob round trip String() = %q, want %q", decoded.String(), cron.String())
+			}
+		})
+	}
+}
+
+func TestCronTime_BinaryRoundTripAnchored(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Shifted once already, so the anchor has moved off midnight
+	cm := NewAnchored("30 2 * * *", ny, time.Date(2025, time.March, 8, 0, 0, 0, 0, ny)).Add(Hours(12))
+	data, err := cm.cron.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	var got CronTime
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if !reflect.DeepEqual(&got, cm.cron) {
+		t.Errorf("UnmarshalBinary() = %+v, want %+v", got, *cm.cron)
+	}
+
+	// Across the spring change, on the wall clock as before encoding
+	if err := got.Add(Hours(24)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if want := cm.Add(Hours(24)).String(); got.String() != want {
+		t.Errorf("Add() after decoding = %q, want %q", got.String(), want)
+	}
+}
+
+func TestCronTime_UnmarshalBinaryInvalid(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * *")
+	data, _ := cron.MarshalBinary()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"future version", append([]byte{binaryVersion + 1}, data[1:]...)},
+		{"truncated", data[:len(data)-3]},
+		{"trailing bytes", append(append([]byte(nil), data...), 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := *cron
+			if err := got.UnmarshalBinary(tt.data); err == nil {
+				t.Error("UnmarshalBinary() expected error, got nil")
+			}
+			if got.String() != cron.String() {
+				t.Errorf("UnmarshalBinary() changed the expression to %q on error", got.String())
+			}
+		})
+	}
+}
+
+func TestCronTime_MarshalBinaryCalendar(t *testing.T) {
+	cron, _ := ParseCronWith("0 9 * * *", WithCalendar(WeekendsOnly, time.Now()))
+	if _, err := cron.MarshalBinary(); err == nil {
+		t.Error("MarshalBinary() expected error for an expression with a calendar, got nil")
+	}
+}
+
+// The version 1 encoding must keep decoding as snapshots depend on it
+func TestCronTime_UnmarshalBinaryVersion1(t *testing.T) {
+	data := []byte{1, 0, 0, 1, '5', 1, '9', 1, '*', 1, '*', 3, 'M', 'O', 'N', 0, 0, 0, 0, 0}
+	var got CronTime
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if want := "5 9 * * MON"; got.String() != want {
+		t.Errorf("UnmarshalBinary() = %q, want %q", got.String(), want)
+	}
+}
+
+func TestCronTime_UnmarshalBinaryVersion3(t *testing.T) {
+	// Version 3 ends with the location, before the comment
+	data := []byte{3, 0, 0, 1, '5', 1, '9', 1, '*', 1, '*', 1, '*', 0, 0, 0, 0, 0, 0}
+	var got CronTime
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if want := "5 9 * * *"; got.String() != want || got.Comment() != "" {
+		t.Errorf("UnmarshalBinary() = %q, %q, want %q", got.String(), got.Comment(), want)
+	}
+}
+
+func TestCronTime_UnmarshalBinaryVersion1Dialect(t *testing.T) {
+	data := []byte{1, 0, 0, 1, '0', 1, '9', 1, '*', 1, '*', 1, '7', 0, 0, 1 << 5, 0, 5, 'V', 'i', 'x', 'i', 'e', 15, 0}
+	var got CronTime
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if d := got.cfg.dialect; d == nil || !reflect.DeepEqual(*d, Vixie) {
+		t.Errorf("UnmarshalBinary() dialect = %+v, want %+v", d, Vixie)
+	}
+}
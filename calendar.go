@@ -0,0 +1,89 @@
+package cronmath
+
+import (
+	"fmt"
+	"time"
+)
+
+// Calendar reports which dates are holidays. Business-day shifts never
+// land on a holiday.
+//
+// Holiday libraries rarely match this signature exactly; wrap them with
+// CalendarFunc instead.
+type Calendar interface {
+	IsHoliday(time.Time) bool
+}
+
+// CalendarFunc adapts an ordinary function to a Calendar
+type CalendarFunc func(time.Time) bool
+
+// IsHoliday calls f(t)
+func (f CalendarFunc) IsHoliday(t time.Time) bool {
+	return f(t)
+}
+
+// WeekendsOnly is a Calendar without any holidays, so business-day shifts
+// skip weekends alone
+var WeekendsOnly Calendar = CalendarFunc(func(time.Time) bool { return false })
+
+// shiftBusinessDays moves the days an expression fires on by n days, then
+// on to the next business day in the direction of the shift
+func (c *CronTime) shiftBusinessDays(n int) error {
+	if isRestricted(c.DayOfMonth) && isRestricted(c.DayOfWeek) {
+		return fmt.Errorf("cannot shift days of an expression restricted by both day of month and day of week: %s %s", c.DayOfMonth, c.DayOfWeek)
+	}
+
+	cal, anchor := c.cfg.calendar, c.cfg.calendarAnchor
+	if isRestricted(c.DayOfMonth) {
+		if cal == nil {
+			return fmt.Errorf("cannot shift an expression restricted by day of month onto business days without a calendar: %s", c.DayOfMonth)
+		}
+		dom, month, err := c.shiftDayOfMonth(n, calendarShift(anchor, cal))
+		if err != nil {
+			return err
+		}
+		c.DayOfMonth, c.Month = dom, month
+		return nil
+	}
+
+	days, err := parseSet(c.DayOfWeek, dayOfWeekField)
+	if err != nil {
+		return c.fieldError(dayOfWeekIndex, err)
+	}
+	if cal == nil {
+		c.DayOfWeek = formatDayOfWeek(businessDays(days, n), c.cfg.sundaySeven)
+		return nil
+	}
+
+	var moved valueSet
+	for _, v := range days.values() {
+		offset := (v - int(anchor.Weekday()) + 7) % 7
+		t, err := nextBusinessDay(anchor.AddDate(0, 0, offset+n), n, cal)
+		if err != nil {
+			return err
+		}
+		moved |= 1 << uint(t.Weekday())
+	}
+	c.DayOfWeek = formatDayOfWeek(moved, c.cfg.sundaySeven)
+	return nil
+}
+
+// maxHolidayRun bounds the search for a business day, so a calendar
+// without any cannot hang a shift
+const maxHolidayRun = 366
+
+// nextBusinessDay returns t if it is a business day under cal, or else the
+// first business day after it, or before it when n is negative
+func nextBusinessDay(t time.Time, n int, cal Calendar) (time.Time, error) {
+	step := 1
+	if n < 0 {
+		step = -1
+	}
+	for i := 0; i < maxHolidayRun; i++ {
+		day := t.AddDate(0, 0, i*step)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday && !cal.IsHoliday(day) {
+			return day, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no business day within %d days of %s", maxHolidayRun, t.Format(time.DateOnly))
+}
@@ -0,0 +1,54 @@
+package cronmath
+
+import (
+	"testing"
+	"time"
+)
+
+// holidays is a Calendar listing its holidays by date
+type holidays map[string]bool
+
+func (h holidays) IsHoliday(t time.Time) bool {
+	return h[t.Format(time.DateOnly)]
+}
+
+func TestCronTime_AddBusinessWithCalendar(t *testing.T) {
+	// Friday 2025-12-26 and Monday 2025-12-29 are holidays
+	cal := holidays{"2025-12-26": true, "2025-12-29": true}
+	// A Monday
+	anchor := time.Date(2025, time.December, 22, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cronStr  string
+		duration time.Duration
+		cal      Calendar
+		want     string
+		wantErr  bool
+	}{
+		{"thursday skips holiday friday and monday", "0 18 * * THU", Hours(6), cal, "0 0 * * 2", false},
+		{"weekends only", "0 18 * * THU", Hours(6), WeekendsOnly, "0 0 * * 5", false},
+		{"back over a holiday", "0 1 * * SAT", -Hours(2), cal, "0 23 * * 4", false},
+		{"day of month in anchor month", "0 18 25 * *", Hours(6), cal, "0 0 30 * *", false},
+		{"day of month into next month", "0 18 31 12 *", Hours(6), cal, "0 0 1 1 *", false},
+		{"every day a holiday", "0 18 * * THU", Hours(6), CalendarFunc(func(time.Time) bool { return true }), "", true},
+		{"both day fields", "0 18 1 * THU", Hours(6), cal, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithCalendar(tt.cal, anchor))
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+
+			err = cron.AddBusiness(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddBusiness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("AddBusiness() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
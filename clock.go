@@ -0,0 +1,42 @@
+package cronmath
+
+import "time"
+
+// Clock tells the time wherever cronmath needs "now", such as Conflicts
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock, reading the system time
+type realClock struct{}
+
+// Now returns time.Now()
+func (realClock) Now() time.Time { return time.Now() }
+
+// fixedClock is a Clock stopped at one instant
+type fixedClock struct{ t time.Time }
+
+// Now returns the instant the clock is stopped at
+func (c fixedClock) Now() time.Time { return c.t }
+
+// FixedClock returns a Clock that always reads t, for pinning "now" in
+// tests
+func FixedClock(t time.Time) Clock {
+	return fixedClock{t}
+}
+
+// WithClock makes the expression read the current time from c instead of
+// the system clock
+func WithClock(c Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = c
+	}
+}
+
+// now returns the current time from the configured clock
+func (cfg config) now() time.Time {
+	if cfg.clock == nil {
+		return realClock{}.Now()
+	}
+	return cfg.clock.Now()
+}
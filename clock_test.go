@@ -0,0 +1,54 @@
+package cronmath
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedClock(t *testing.T) {
+	at := time.Date(2025, 3, 14, 9, 26, 0, 0, time.UTC)
+	c := FixedClock(at)
+	if got := c.Now(); !got.Equal(at) {
+		t.Errorf("Now() = %v, want %v", got, at)
+	}
+	if got := c.Now(); !got.Equal(at) {
+		t.Errorf("second Now() = %v, want %v", got, at)
+	}
+}
+
+func TestWithClock_Conflicts(t *testing.T) {
+	clock := FixedClock(time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)) // a Monday
+	a, err := ParseCronWith("0 9 * * 1", WithClock(clock))
+	if err != nil {
+		t.Fatalf("ParseCronWith() error = %v", err)
+	}
+	b, _ := ParseCron("0 9 * * *")
+
+	ok, at, err := Conflicts(a, b)
+	if err != nil || !ok {
+		t.Fatalf("Conflicts() = %v, %v", ok, err)
+	}
+	if want := time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC); !at.Equal(want) {
+		t.Errorf("Conflicts() time = %v, want %v", at, want)
+	}
+}
+
+func TestWithClock_CronSetConflicts(t *testing.T) {
+	set := NewCronSet(WithClock(FixedClock(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))))
+	set.Add("backup", "30 2 * * *")
+	set.Add("report", "30 2 1 * *")
+
+	got := set.Conflicts()
+	want := []Conflict{{"backup", "report", time.Date(2030, 1, 1, 2, 30, 0, 0, time.UTC)}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Conflicts() = %v, want %v", got, want)
+	}
+}
+
+func TestConfig_NowDefaultsToSystemClock(t *testing.T) {
+	before := time.Now()
+	got := config{}.now()
+	if got.Before(before) || got.After(time.Now()) {
+		t.Errorf("now() = %v, want the system time", got)
+	}
+}
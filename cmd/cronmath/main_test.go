@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunNext(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantOut    string
+		wantErrOut string
+	}{
+		{
+			name:     "count in a time zone",
+			args:     []string{"next", "-n", "3", "--tz", "Asia/Tokyo", "--from", "2025-06-06T00:00:00Z", "30 9 * * 1-5"},
+			wantCode: 0,
+			wantOut:  "2025-06-06T09:30:00+09:00\n2025-06-09T09:30:00+09:00\n2025-06-10T09:30:00+09:00\n",
+		},
+		{
+			name:     "until a date",
+			args:     []string{"next", "--until", "2025-12-31", "--tz", "UTC", "--from", "2025-10-01T00:00:00Z", "0 0 1 */2 *"},
+			wantCode: 0,
+			wantOut:  "2025-11-01T00:00:00Z\n1 occurrence until 2025-12-31\n",
+		},
+		{
+			name:       "never fires",
+			args:       []string{"next", "--tz", "UTC", "0 0 30 2 *"},
+			wantCode:   1,
+			wantErrOut: "never fires",
+		},
+		{
+			name:       "not before the date",
+			args:       []string{"next", "--until", "2025-12-31", "--tz", "UTC", "--from", "2025-06-01T00:00:00Z", "0 0 29 2 *"},
+			wantCode:   1,
+			wantErrOut: "never fires before the end of 2025-12-31",
+		},
+		{
+			name:       "invalid expression",
+			args:       []string{"next", "0 9 * *"},
+			wantCode:   1,
+			wantErrOut: "invalid cron expression",
+		},
+		{
+			name:       "invalid zone",
+			args:       []string{"next", "--tz", "Mars/Olympus", "0 9 * * *"},
+			wantCode:   2,
+			wantErrOut: "invalid time zone",
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"prev", "0 9 * * *"},
+			wantCode:   2,
+			wantErrOut: "unknown command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d; stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantOut != "" && stdout.String() != tt.wantOut {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantOut)
+			}
+			if !strings.Contains(stderr.String(), tt.wantErrOut) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantErrOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ryutaro-asada/cronmath"
+)
+
+// runNext prints the next firings of an expression, either a fixed count
+// of them or all of them up to a date
+func runNext(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("next", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	n := fs.Int("n", 1, "number of firings to print")
+	until := fs.String("until", "", "print every firing up to the end of this date, YYYY-MM-DD")
+	tz := fs.String("tz", "Local", "time zone the expression fires in")
+	from := fs.String("from", "", "start after this RFC 3339 time instead of now")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: cronmath next [-n count] [--until date] [--tz zone] [--from time] expression")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	expr := fs.Arg(0)
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		fmt.Fprintf(stderr, "cronmath: invalid time zone %q: %v\n", *tz, err)
+		return 2
+	}
+	start := time.Now()
+	if *from != "" {
+		if start, err = time.Parse(time.RFC3339, *from); err != nil {
+			fmt.Fprintf(stderr, "cronmath: invalid --from time: %v\n", err)
+			return 2
+		}
+	}
+
+	cron, err := cronmath.ParseCronWith(expr, cronmath.WithAutoFields(), cronmath.WithLocation(loc))
+	if err != nil {
+		fmt.Fprintf(stderr, "cronmath: %v\n", err)
+		return 1
+	}
+
+	if *until == "" {
+		if *n < 1 {
+			fmt.Fprintf(stderr, "cronmath: -n must be at least 1, got %d\n", *n)
+			return 2
+		}
+		times, err := cron.NextN(start, *n)
+		if err != nil {
+			fmt.Fprintf(stderr, "cronmath: %v\n", err)
+			return 1
+		}
+		for _, t := range times {
+			fmt.Fprintln(stdout, t.Format(time.RFC3339))
+		}
+		return 0
+	}
+
+	day, err := time.ParseInLocation(time.DateOnly, *until, loc)
+	if err != nil {
+		fmt.Fprintf(stderr, "cronmath: invalid --until date: %v\n", err)
+		return 2
+	}
+	end := day.AddDate(0, 0, 1)
+
+	count := 0
+	for t := start; ; count++ {
+		t, err = cron.Next(t)
+		if errors.Is(err, cronmath.ErrNeverFires) {
+			break
+		} else if err != nil {
+			fmt.Fprintf(stderr, "cronmath: %v\n", err)
+			return 1
+		}
+		if !t.Before(end) {
+			break
+		}
+		fmt.Fprintln(stdout, t.Format(time.RFC3339))
+	}
+	if count == 0 {
+		fmt.Fprintf(stderr, "cronmath: %q never fires before the end of %s\n", expr, *until)
+		return 1
+	}
+	noun := "occurrences"
+	if count == 1 {
+		noun = "occurrence"
+	}
+	fmt.Fprintf(stdout, "%d %s until %s\n", count, noun, *until)
+	return 0
+}
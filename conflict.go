@@ -0,0 +1,169 @@
+package cronmath
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Conflicts reports whether a and b ever fire in the same minute, and if
+// so returns the first such minute from now on, as told by a's clock. See
+// ConflictsFrom.
+func Conflicts(a, b *CronTime) (bool, time.Time, error) {
+	return ConflictsContext(context.Background(), a, b, 0)
+}
+
+// ConflictsFrom reports whether a and b ever fire in the same minute at
+// or after start, returning the first such minute in start's location.
+// Each expression fires in its own location (see WithLocation), or else
+// start's.
+//
+// In the same location the minute and hour fields are intersected
+// analytically; days are then scanned for one on which both expressions
+// are active, honoring cron's day-of-month/day-of-week OR rule. In
+// different ones the firings of each are walked as Next would. The scan
+// covers a full 400-year Gregorian cycle, so a false result means the
+// expressions never collide.
+func ConflictsFrom(a, b *CronTime, start time.Time) (bool, time.Time, error) {
+	return conflictsContext(context.Background(), a, b, start, gregorianCycleDays)
+}
+
+// ConflictsContext is like Conflicts, but scans only up to horizon from
+// now, or the full cycle when horizon is 0 or less, and gives up when ctx
+// is done, returning a *ScanError with ctx's error and the date the scan
+// had reached. A false result then means the expressions do not collide
+// within the horizon.
+func ConflictsContext(ctx context.Context, a, b *CronTime, horizon Duration) (bool, time.Time, error) {
+	start := a.cfg.now()
+	if horizon <= 0 {
+		return conflictsContext(ctx, a, b, start, gregorianCycleDays)
+	}
+	end := start.Add(horizon)
+	ok, t, err := conflictsContext(ctx, a, b, start, int(min(horizon/(24*time.Hour)+2, gregorianCycleDays)))
+	if ok && !t.Before(end) {
+		return false, time.Time{}, nil
+	}
+	return ok, t, err
+}
+
+// conflictsContext is ConflictsFrom, scanning at most days days past the
+// day of start and checking ctx as it goes
+func conflictsContext(ctx context.Context, a, b *CronTime, start time.Time, days int) (bool, time.Time, error) {
+	sa, err := a.schedule()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	sb, err := b.schedule()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	loc := a.in(start).Location()
+	if !sameLocation(loc, b.in(start).Location()) {
+		return conflictsAcross(ctx, a, b, sa, sb, start, days)
+	}
+
+	minutes, hours := sa.minute&sb.minute, sa.hour&sb.hour
+	if minutes == 0 || hours == 0 {
+		return false, time.Time{}, nil
+	}
+
+	d := dateOf(start.In(loc))
+	for i := 0; i <= days; i++ {
+		if i%scanCheckDays == 0 {
+			if err := ctx.Err(); err != nil {
+				return false, time.Time{}, &ScanError{Reached: d.at(0, 0, loc), Err: err}
+			}
+		}
+		if sa.matchesDate(d) && sb.matchesDate(d) {
+			if t, ok := firstTimeOn(d, hours, minutes, loc, start); ok {
+				return true, t.In(start.Location()), nil
+			}
+		}
+		d = d.next()
+	}
+	return false, time.Time{}, nil
+}
+
+// conflictsAcross is conflictsContext for expressions in different
+// locations, leapfrogging the firings of each to the minute of the other
+// until they meet or pass the day days after start
+func conflictsAcross(ctx context.Context, a, b *CronTime, sa, sb *schedule, start time.Time, days int) (bool, time.Time, error) {
+	end := dateOf(start).at(0, 0, start.Location()).AddDate(0, 0, days+1)
+	for t := start; t.Before(end); {
+		na, ok, err := sa.nextContext(ctx, a.in(t), days)
+		if err != nil || !ok {
+			return false, time.Time{}, err
+		}
+		nb, ok, err := sb.nextContext(ctx, b.in(t), days)
+		if err != nil || !ok {
+			return false, time.Time{}, err
+		}
+		ma, mb := na.Truncate(time.Minute), nb.Truncate(time.Minute)
+		if ma.Equal(mb) {
+			if !ma.Before(end) {
+				break
+			}
+			return true, ma.In(start.Location()), nil
+		}
+		t = later(ma, mb)
+	}
+	return false, time.Time{}, nil
+}
+
+// later returns the later of a and b
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// sameLocation reports whether x and y are the same location, loaded
+// separately or not. Unnamed fixed zones are only the same as themselves.
+func sameLocation(x, y *time.Location) bool {
+	return x == y || x.String() == y.String() && x.String() != ""
+}
+
+// firstTimeOn returns the earliest time on d built from the given hours
+// and minutes that is not before start
+func firstTimeOn(d date, hours, minutes valueSet, loc *time.Location, start time.Time) (time.Time, bool) {
+	for _, h := range hours.values() {
+		for _, m := range minutes.values() {
+			if t := d.at(h, m, loc); !t.Before(start) {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// OverlapPerDay returns how many minutes of the day both a and b fire in,
+// on the days they are both active: "*/10 9-17 * * *" and
+// "0,30 * * * 1-5" share 18 minutes a day. Minutes are counted once
+// however many seconds fire in them. When the expressions are never
+// active on the same day, 0 is returned with an error wrapping
+// ErrEmptyIntersection that says so. Expressions set to different
+// locations (see WithLocation) have no day in common and are refused.
+func OverlapPerDay(a, b *CronTime) (int, error) {
+	if la, lb := a.locationOr(nil), b.locationOr(nil); !sameLocation(la, lb) {
+		return 0, fmt.Errorf("cannot overlap %q in %s and %q in %s: the days are in different locations", a.String(), la, b.String(), lb)
+	}
+	sa, err := a.schedule()
+	if err != nil {
+		return 0, err
+	}
+	sb, err := b.schedule()
+	if err != nil {
+		return 0, err
+	}
+
+	d := cycleStart
+	for i := 0; i <= gregorianCycleDays; i++ {
+		if sa.matchesDate(d) && sb.matchesDate(d) {
+			return (sa.hour & sb.hour).len() * (sa.minute & sb.minute).len(), nil
+		}
+		d = d.next()
+	}
+	return 0, fmt.Errorf("%w: %q and %q are never active on the same day", ErrEmptyIntersection, a.String(), b.String())
+}
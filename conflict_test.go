@@ -0,0 +1,247 @@
+package cronmath
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConflictsFrom(t *testing.T) {
+	// A Monday
+	start := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		a, b     string
+		want     bool
+		wantTime time.Time
+	}{
+		{
+			name:     "same fixed time",
+			a:        "5 9 * * *",
+			b:        "5 9 * * *",
+			want:     true,
+			wantTime: time.Date(2025, time.March, 3, 9, 5, 0, 0, time.UTC),
+		},
+		{
+			name: "different minutes",
+			a:    "5 9 * * *",
+			b:    "6 9 * * *",
+			want: false,
+		},
+		{
+			name:     "step and list overlap",
+			a:        "*/15 * * * *",
+			b:        "30 14,16 * * *",
+			want:     true,
+			wantTime: time.Date(2025, time.March, 3, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "ranges overlap on a later weekday",
+			a:        "0 9-17 * * 3",
+			b:        "0 17 * * 1-5",
+			want:     true,
+			wantTime: time.Date(2025, time.March, 5, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekdays against weekends",
+			a:    "0 9 * * 1-5",
+			b:    "0 9 * * 0,6",
+			want: false,
+		},
+		{
+			name:     "day of month or day of week",
+			a:        "0 9 13 * 5",
+			b:        "0 9 * * 2",
+			want:     true,
+			wantTime: time.Date(2025, time.May, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "rare leap day on a Monday",
+			a:        "0 0 29 2 *",
+			b:        "0 0 * 2 1",
+			want:     true,
+			wantTime: time.Date(2044, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "impossible day of month",
+			a:    "0 0 30 2 *",
+			b:    "0 0 * * *",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseCron(tt.a)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.a, err)
+			}
+			b, err := ParseCron(tt.b)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.b, err)
+			}
+
+			got, at, err := ConflictsFrom(a, b, start)
+			if err != nil {
+				t.Fatalf("ConflictsFrom() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("ConflictsFrom() = %v, want %v", got, tt.want)
+			}
+			if !at.Equal(tt.wantTime) {
+				t.Errorf("ConflictsFrom() time = %v, want %v", at, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestConflictsFrom_SkipsEarlierMinutesOnStartDay(t *testing.T) {
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("0 9 * * *")
+	start := time.Date(2025, time.March, 3, 9, 1, 0, 0, time.UTC)
+
+	_, at, err := ConflictsFrom(a, b, start)
+	if err != nil {
+		t.Fatalf("ConflictsFrom() error = %v", err)
+	}
+	if want := time.Date(2025, time.March, 4, 9, 0, 0, 0, time.UTC); !at.Equal(want) {
+		t.Errorf("ConflictsFrom() time = %v, want %v", at, want)
+	}
+}
+
+func TestConflicts_InvalidField(t *testing.T) {
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("0 9 * * 8")
+	if _, _, err := Conflicts(a, b); err == nil {
+		t.Error("Conflicts() expected error for out-of-range day of week, got nil")
+	}
+}
+
+func TestOverlapPerDay(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    int
+		wantErr error
+	}{
+		{"*/10 9-17 * * *", "0,30 * * * 1-5", 18, nil},
+		{"*/5 * * * *", "*/15 * * * *", 96, nil},
+		{"* * * * *", "* * * * *", 1440, nil},
+		{"0 9 * * *", "30 9 * * *", 0, nil},
+		{"*/15 * * * 1-5", "*/15 * * * 0,6", 0, ErrEmptyIntersection},
+		{"0 9 1 * *", "0 9 * * 1", 1, nil},
+		{"0 9 31 * *", "0 9 * 2 *", 0, ErrEmptyIntersection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" & "+tt.b, func(t *testing.T) {
+			a, _ := ParseCron(tt.a)
+			b, _ := ParseCron(tt.b)
+			got, err := OverlapPerDay(a, b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OverlapPerDay() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("OverlapPerDay() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("0 9 * * 8")
+	if _, err := OverlapPerDay(a, b); KindOf(err) != KindParse {
+		t.Errorf("OverlapPerDay() error = %v, want a parse error", err)
+	}
+
+	if tokyo, err := time.LoadLocation("Asia/Tokyo"); err == nil {
+		a, _ := ParseCronWith("0 9 * * *", WithLocation(tokyo))
+		b, _ := ParseCronWith("0 9 * * *", WithLocation(time.UTC))
+		if got, err := OverlapPerDay(a, b); err == nil {
+			t.Errorf("OverlapPerDay() across locations = %d, want an error", got)
+		}
+	}
+}
+
+func TestConflictsFrom_Locations(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	start := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		a, b     *CronTime
+		want     bool
+		wantTime time.Time
+	}{
+		{
+			name:     "same instant",
+			a:        mustParseWith(t, "0 9 * * *", WithLocation(tokyo)),
+			b:        mustParseWith(t, "0 0 * * *", WithLocation(time.UTC)),
+			want:     true,
+			wantTime: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "same clock time",
+			a:    mustParseWith(t, "0 9 * * *", WithLocation(tokyo)),
+			b:    mustParseWith(t, "0 9 * * *", WithLocation(time.UTC)),
+			want: false,
+		},
+		{
+			name:     "one in start's location",
+			a:        mustParseWith(t, "0 9 * * *", WithLocation(tokyo)),
+			b:        mustParseWith(t, "0 0 * * *"),
+			want:     true,
+			wantTime: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "after a daylight saving change",
+			a:        mustParseWith(t, "0 13 * * *", WithLocation(time.UTC)),
+			b:        mustParseWith(t, "0 9 * * *", WithLocation(ny)),
+			want:     true,
+			wantTime: time.Date(2025, time.March, 9, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, at, err := ConflictsFrom(tt.a, tt.b, start)
+			if err != nil {
+				t.Fatalf("ConflictsFrom() error = %v", err)
+			}
+			if got != tt.want || !at.Equal(tt.wantTime) {
+				t.Errorf("ConflictsFrom() = %v, %v, want %v, %v", got, at, tt.want, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestConflictsContext(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	a, _ := ParseCronWith("0 0 29 2 *", WithClock(FixedClock(now)))
+	b, _ := ParseCron("0 0 * * 2")
+
+	ok, at, err := ConflictsContext(context.Background(), a, b, 0)
+	if err != nil || !ok || !at.Equal(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ConflictsContext() = %v, %v, %v, want 2028-02-29", ok, at, err)
+	}
+	if ok, _, err := ConflictsContext(context.Background(), a, b, Hours(24*365)); ok || err != nil {
+		t.Errorf("ConflictsContext(1 year) = %v, %v, want no conflict", ok, err)
+	}
+	if ok, _, err := ConflictsContext(context.Background(), a, b, Hours(24*365*3)-Hours(1)); ok || err != nil {
+		t.Errorf("ConflictsContext(just short) = %v, %v, want no conflict", ok, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var scanErr *ScanError
+	if _, _, err := ConflictsContext(ctx, a, b, 0); !errors.As(err, &scanErr) || !errors.Is(err, context.Canceled) || !scanErr.Reached.Equal(now) {
+		t.Errorf("ConflictsContext() error = %v, want a *ScanError at %v", err, now)
+	}
+}
@@ -1,12 +1,20 @@
 package cronmath
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
+// minutesPerDay is the number of minutes in a daily schedule
+const minutesPerDay = 24 * 60
+
 // CronTime represents a cron expression that can be manipulated
 type CronTime struct {
 	Minute     string
@@ -14,144 +22,1351 @@ type CronTime struct {
 	DayOfMonth string
 	Month      string
 	DayOfWeek  string
+
+	// Second and Year hold the seconds and year fields of expressions
+	// with six or seven fields, as parsed with WithAutoFields
+	Second string
+	Year   string
+
+	// macro is the "@" macro the expression was parsed from, if any
+	macro string
+	// comment is the trailing "#" comment stripped on parse
+	comment string
+	layout  Layout
+	cfg     config
+	// parsed is the minute and hour as last parsed or written, so that
+	// chained shifts do not parse them again
+	parsed clockCache
+}
+
+// clockCache holds the minute and hour parsed from the fields it was
+// taken from. It is only used while the fields still read the same.
+type clockCache struct {
+	set                    bool
+	minuteField, hourField string
+	minute, hour           singleValue
+}
+
+// singleValue is a field parsed as a single number or a wildcard
+type singleValue struct {
+	// n is the value, or -1 for a wildcard
+	n   int
+	err error
+	// list is set when the field is not a number, such as a list, range
+	// or step
+	list bool
+}
+
+// parseSingle parses a field holding a single value between min and max
+// or a wildcard
+func parseSingle(field string, min, max int) singleValue {
+	if field == "*" {
+		return singleValue{n: -1}
+	}
+	val, err := strconv.Atoi(field)
+	if err != nil {
+		return singleValue{err: fmt.Errorf("unsupported field format: %s", field), list: true}
+	}
+	if val < min || val > max {
+		return singleValue{err: fmt.Errorf("value %d out of range [%d, %d]", val, min, max)}
+	}
+	return singleValue{n: val}
+}
+
+// clockValues returns the minute and hour fields parsed as single values,
+// from the cache when the fields have not changed since
+func (c *CronTime) clockValues() (minute, hour singleValue) {
+	if k := &c.parsed; k.set && k.minuteField == c.Minute && k.hourField == c.Hour {
+		return k.minute, k.hour
+	}
+	return parseSingle(c.Minute, 0, 59), parseSingle(c.Hour, 0, 23)
+}
+
+// cacheClock parses the minute and hour fields into the cache
+func (c *CronTime) cacheClock() {
+	minute, hour := c.clockValues()
+	c.parsed = clockCache{set: true, minuteField: c.Minute, hourField: c.Hour, minute: minute, hour: hour}
+}
+
+// Layout is the set of fields an expression was written with
+type Layout int
+
+const (
+	// LayoutStandard is the five fields of classic cron, minute to day of
+	// week
+	LayoutStandard Layout = iota
+	// LayoutSeconds adds a leading seconds field, as used by Quartz and
+	// robfig/cron
+	LayoutSeconds
+	// LayoutSecondsYear adds a trailing year field to LayoutSeconds, as
+	// allowed by Quartz
+	LayoutSecondsYear
+	// LayoutYear adds a trailing year field to LayoutStandard, as used by
+	// Amazon EventBridge
+	LayoutYear
+)
+
+// String returns the name of the layout
+func (l Layout) String() string {
+	switch l {
+	case LayoutSeconds:
+		return "seconds"
+	case LayoutSecondsYear:
+		return "seconds and year"
+	case LayoutYear:
+		return "year"
+	}
+	return "standard"
+}
+
+// hasSeconds reports whether the layout has a seconds field
+func (l Layout) hasSeconds() bool {
+	return l == LayoutSeconds || l == LayoutSecondsYear
+}
+
+// hasYear reports whether the layout has a year field
+func (l Layout) hasYear() bool {
+	return l == LayoutSecondsYear || l == LayoutYear
+}
+
+// layoutWith returns the layout with or without seconds and year fields
+func layoutWith(seconds, year bool) Layout {
+	switch {
+	case seconds && year:
+		return LayoutSecondsYear
+	case seconds:
+		return LayoutSeconds
+	case year:
+		return LayoutYear
+	}
+	return LayoutStandard
+}
+
+// fields returns the number of fields in the layout
+func (l Layout) fields() int {
+	n := 5
+	if l.hasSeconds() {
+		n++
+	}
+	if l.hasYear() {
+		n++
+	}
+	return n
+}
+
+// reboot is the macro for jobs run once at startup
+const reboot = "@reboot"
+
+// ErrNotShiftable is returned when shifting an expression that has no
+// time, as parsed from "@reboot"
+var ErrNotShiftable = errors.New("@reboot has no time to shift")
+
+// errNoSchedule is returned when expanding an expression that has no
+// schedule, as parsed from "@reboot"
+var errNoSchedule = errors.New("@reboot has no schedule")
+
+// IsReboot reports whether the expression was parsed from "@reboot",
+// which runs once when cron starts rather than on a schedule. Such an
+// expression keeps its fields empty, is written back as "@reboot", and
+// refuses shifts with ErrNotShiftable.
+func (c *CronTime) IsReboot() bool {
+	return c.macro == reboot
+}
+
+// Comment returns the trailing comment the expression was parsed with,
+// without the "#", so "5 9 * * *  # morning report" gives "morning
+// report"
+func (c *CronTime) Comment() string {
+	return c.comment
+}
+
+// splitComment trims a byte order mark and surrounding whitespace from s
+// and splits off a trailing comment. A comment starts with a "#" at the
+// start of a field, after any space fields are split on, so "1#2" in the
+// day of week is not one.
+func splitComment(s string) (expr, comment string) {
+	s = strings.TrimPrefix(s, "\ufeff")
+	for i := 0; i < len(s); i++ {
+		if prev, _ := utf8.DecodeLastRuneInString(s[:i]); s[i] == '#' && (i == 0 || unicode.IsSpace(prev)) {
+			return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+		}
+	}
+	return strings.TrimSpace(s), ""
+}
+
+// Layout returns the layout the expression was parsed from
+func (c *CronTime) Layout() Layout {
+	return c.layout
 }
 
 // ParseCron parses a cron expression string into a CronTime struct
 func ParseCron(cronStr string) (*CronTime, error) {
+	return ParseCronWith(cronStr)
+}
+
+// ParseCronWith parses a cron expression string into a CronTime struct,
+// applying the given options. Fields may be separated by any run of
+// spaces and tabs, a leading byte order mark and surrounding whitespace
+// such as a CRLF line ending are ignored, and a trailing "#" comment is
+// stripped and kept for Comment.
+func ParseCronWith(cronStr string, opts ...Option) (*CronTime, error) {
+	c, cronStr, err := splitFields(cronStr, newConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	cfg := c.cfg
+	if !c.IsReboot() {
+		c.cacheClock()
+	}
+
+	if c.layout.hasSeconds() {
+		if _, err := parseSet(c.Second, secondField); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, c.parseError(cronStr, secondIndex, err))
+		}
+	}
+	if c.layout.hasYear() {
+		if _, err := parseYears(c.Year); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, c.parseError(cronStr, yearIndex, err))
+		}
+	}
+
+	if cfg.dialect != nil {
+		if err := c.ValidateFor(*cfg.dialect); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.strict && !c.IsReboot() {
+		for i, field := range c.fieldPtrs() {
+			if err := parseStandardField(i, *field); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, c.parseError(cronStr, i, err))
+			}
+		}
+		for _, w := range c.Lint() {
+			if w.Severity == SeverityError {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidExpression, w.Message)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// splitFields splits cronStr into the fields of a CronTime without parsing
+// them, returning the expression the fields come from once the comment is
+// stripped and any macro expanded
+func splitFields(cronStr string, cfg config) (*CronTime, string, error) {
+	if cfg.dialectErr != nil {
+		return nil, "", cfg.dialectErr
+	}
+	cronStr, comment := splitComment(cronStr)
+	if cronStr == reboot {
+		return &CronTime{macro: reboot, comment: comment, cfg: cfg}, cronStr, nil
+	}
+
+	var macro string
+	if strings.HasPrefix(cronStr, "@") {
+		expansion, ok := macros[cronStr]
+		if !ok {
+			return nil, "", fmt.Errorf("%w: unsupported macro %s", ErrInvalidExpression, cronStr)
+		}
+		macro, cronStr = cronStr, expansion
+	}
+
 	parts := strings.Fields(cronStr)
-	if len(parts) != 5 {
-		return nil, fmt.Errorf("invalid cron expression: expected 5 fields, got %d", len(parts))
+	c := &CronTime{macro: macro, comment: comment, cfg: cfg}
+	switch {
+	case cfg.dialect != nil && len(cfg.dialect.Layouts) > 0:
+		layout, ok := cfg.dialect.layoutFor(len(parts))
+		if !ok {
+			return nil, "", fmt.Errorf("%w: expected %s fields, got %d", ErrInvalidExpression, cfg.dialect.fieldCounts(), len(parts))
+		}
+		c.layout = layout
+	case len(parts) == 5:
+	case cfg.autoFields && len(parts) == 6:
+		c.layout = LayoutSeconds
+	case cfg.autoFields && len(parts) == 7:
+		c.layout = LayoutSecondsYear
+	case cfg.autoFields:
+		return nil, "", fmt.Errorf("%w: expected 5, 6 or 7 fields, got %d", ErrInvalidExpression, len(parts))
+	default:
+		return nil, "", fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidExpression, len(parts))
+	}
+	if c.layout.hasSeconds() {
+		c.Second, parts = parts[0], parts[1:]
+	}
+	if c.layout.hasYear() {
+		c.Year, parts = parts[5], parts[:5]
 	}
 
-	return &CronTime{
-		Minute:     parts[0],
-		Hour:       parts[1],
-		DayOfMonth: parts[2],
-		Month:      parts[3],
-		DayOfWeek:  parts[4],
-	}, nil
+	c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek = parts[0], parts[1], parts[2], parts[3], parts[4]
+	if cfg.dialect != nil && cfg.dialect.DayOfWeekBase == 1 {
+		dow, err := fromQuartzDays(c.DayOfWeek)
+		if err != nil {
+			return nil, "", err
+		}
+		c.DayOfWeek = dow
+	}
+	return c, cronStr, nil
 }
 
-// String returns the cron expression as a string
+// String returns the cron expression as a string, with as many fields as
+// it was parsed from, or as a macro when WithMacroOutput was given. Sunday
+// is written as 0 unless WithSundayAsSeven was given, month and weekday
+// names in upper case unless WithNameCase was given, and minutes and hours
+// unpadded unless WithZeroPad was given.
 func (c *CronTime) String() string {
-	return fmt.Sprintf("%s %s %s %s %s", c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek)
+	var buf [64]byte
+	return string(c.AppendString(buf[:0]))
+}
+
+// AppendString appends the expression, as String writes it, to dst and
+// returns the extended buffer
+func (c *CronTime) AppendString(dst []byte) []byte {
+	if c.IsReboot() {
+		return append(dst, reboot...)
+	}
+	if c.cfg.macroOutput {
+		if m, ok := c.equivalentMacro(); ok && (c.cfg.dialect == nil || c.cfg.dialect.hasMacro(m)) {
+			return append(dst, m...)
+		}
+	}
+
+	fields := [5]string{c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek}
+	if c.cfg.dialect != nil {
+		fields = c.formatFor(*c.cfg.dialect)
+	}
+	if c.cfg.zeroPad {
+		fields[minuteIndex], fields[hourIndex] = zeroPad(fields[minuteIndex]), zeroPad(fields[hourIndex])
+	}
+	month, dow := c.convertValueNames(fields[monthIndex], fields[dayOfWeekIndex])
+
+	if c.layout.hasSeconds() {
+		dst = append(append(dst, c.Second...), ' ')
+	}
+	for _, field := range fields[:monthIndex] {
+		dst = append(append(dst, field...), ' ')
+	}
+	dst = append(appendNames(dst, month, monthField, c.cfg.nameCase), ' ')
+	if d := c.cfg.dialect; d != nil && d.DayOfWeekBase == 1 {
+		if quartz, err := toQuartzDays(dow); err == nil {
+			dow = quartz
+		}
+		dst = appendNames(dst, dow, dayOfWeekField, c.cfg.nameCase)
+	} else {
+		seven := c.cfg.sundaySeven && (d == nil || d.SundaySeven)
+		dst = appendDayOfWeek(dst, dow, seven, c.cfg.nameCase)
+	}
+	if c.layout.hasYear() {
+		dst = append(append(dst, ' '), c.Year...)
+	}
+	return dst
 }
 
-// Add adds a duration to the cron expression
+// fieldString returns the fields joined as written
+func (c *CronTime) fieldString() string {
+	return c.Minute + " " + c.Hour + " " + c.DayOfMonth + " " + c.Month + " " + c.DayOfWeek
+}
+
+// Add adds a duration to the cron expression. The duration is cut to
+// whole minutes towards zero, and may be negative: Add(-d) is the same as
+// Sub(d), results and errors alike. The saturated durations Minutes and
+// Hours return on overflow are rejected with *OverflowError.
 func (c *CronTime) Add(d time.Duration) error {
-	return c.adjustTime(d)
+	m, err := durationMinutes(d)
+	if err != nil {
+		return err
+	}
+	return c.adjustTime(m)
 }
 
-// Sub subtracts a duration from the cron expression
+// Sub subtracts a duration from the cron expression, as Add(-d)
 func (c *CronTime) Sub(d time.Duration) error {
-	return c.adjustTime(-d)
+	m, err := durationMinutes(d)
+	if err != nil {
+		return err
+	}
+	return c.adjustTime(-m)
 }
 
-// adjustTime adjusts the cron time by the given duration
-func (c *CronTime) adjustTime(d time.Duration) error {
-	// Only handle minute and hour adjustments for now
-	// More complex adjustments (days, months) would require more sophisticated logic
+// AddClock shifts the expression later by hours and minutes, which may
+// have opposite signs: AddClock(1, -15) shifts it by 45 minutes. It wraps
+// and carries into the day fields as Add does.
+func (c *CronTime) AddClock(hours, minutes int) error {
+	m, err := clockMinutes(hours, minutes)
+	if err != nil {
+		return err
+	}
+	return c.adjustTime(m)
+}
 
-	totalMinutes := int(d.Minutes())
+// SubClock shifts the expression earlier by hours and minutes, as
+// AddClock(-hours, -minutes)
+func (c *CronTime) SubClock(hours, minutes int) error {
+	m, err := clockMinutes(hours, minutes)
+	if err != nil {
+		return err
+	}
+	return c.adjustTime(-m)
+}
+
+// adjustTime adjusts the cron time by the given number of minutes. When
+// the shift crosses midnight, the restricted day field moves along with
+// it: "15 0 * * 1-5" less 30 minutes becomes "45 23 * * 0-4". Days of the
+// week wrapping through Sunday are written as a list, such as "0,6",
+// which every dialect accepts. An expression with a
+// wildcard minute can be shifted by whole hours. Lists, ranges and steps,
+// bounded ones such as "0-30/5" included, move as a whole and keep their
+// step.
+func (c *CronTime) adjustTime(totalMinutes int64) error {
+	if c.IsReboot() {
+		return ErrNotShiftable
+	}
+	if c.cfg.wallLocation != nil {
+		return c.adjustWallClock(totalMinutes)
+	}
+	m, h := c.clockValues()
+	hours := c.Hour
+	if m.n == -1 && h.n != -1 && totalMinutes%60 == 0 {
+		if err := c.shiftHours(totalMinutes / 60); err != nil {
+			return err
+		}
+		c.expandHourSteps(hours, totalMinutes)
+		return nil
+	}
+	if m.list || h.list && m.n != -1 {
+		if err := c.shiftMinutes(totalMinutes); err != nil {
+			return err
+		}
+		c.expandHourSteps(hours, totalMinutes)
+		return nil
+	}
 
-	// Parse current minute and hour
-	currentMinute, err := c.parseField(c.Minute, 0, 59)
+	minute, hour, dayShift, err := c.shiftClock(totalMinutes)
 	if err != nil {
-		return fmt.Errorf("error parsing minute: %v", err)
+		return err
+	}
+
+	if err := c.carryDays(dayShift); err != nil {
+		return err
+	}
+
+	c.setClock(minute, hour)
+	return nil
+}
+
+// expandHourSteps writes the hours as a list under WithExpandSteps when
+// the hour field was stepped, as hours, before a shift of totalMinutes
+// that is not a whole number of steps. Shifts by whole steps keep the
+// hours compressed, as shiftHours writes them.
+func (c *CronTime) expandHourSteps(hours string, totalMinutes int64) {
+	if !c.cfg.expandSteps || !strings.Contains(hours, "/") {
+		return
+	}
+	if step := int64(fieldStep(hours)); totalMinutes%(step*60) == 0 {
+		return
 	}
+	if s, err := parseSet(c.Hour, hourField); err == nil {
+		c.Hour = formatRuns(s)
+	}
+}
+
+// carryDays moves the day fields by the n days a shift of the clock
+// carries into, as ShiftDays does. Expressions firing every day are left
+// alone.
+func (c *CronTime) carryDays(n int) error {
+	if n == 0 || !c.restrictsDays() {
+		return nil
+	}
+	return c.moveDays(n)
+}
+
+// restrictsDays reports whether either day field restricts the days the
+// expression fires on
+func (c *CronTime) restrictsDays() bool {
+	return limitsDays(c.DayOfMonth, dayOfMonthField) || limitsDays(c.DayOfWeek, dayOfWeekField)
+}
+
+// ErrWildcardMinute is returned when shifting an expression whose minute
+// is a wildcard, as every minute fires and there is nothing to move
+var ErrWildcardMinute = errors.New("cannot adjust wildcards")
+
+// ErrDayBoundary is matched by the *DayBoundaryError returned when a shift
+// under WithNoWrap crosses midnight
+var ErrDayBoundary = errors.New("shift crosses midnight")
+
+// DayBoundaryError reports a shift refused by WithNoWrap. Times are
+// minutes of the day: From is where the shift started and To where it
+// ended before wrapping, outside [0, 1440), with Wrapped the time it would
+// have wrapped to.
+type DayBoundaryError struct {
+	From, To, Wrapped int
+}
+
+func (e *DayBoundaryError) Error() string {
+	return fmt.Sprintf("%v: shift from %s to %s would wrap to %s", ErrDayBoundary, clockTime(e.From), clockTime(e.To), clockTime(e.Wrapped))
+}
+
+// Is reports whether target is ErrDayBoundary
+func (e *DayBoundaryError) Is(target error) bool {
+	return target == ErrDayBoundary
+}
+
+// newDayBoundaryError reports a shift from the minute of the day from by
+// n minutes, or nil when it stays within the day
+func newDayBoundaryError(from int, n int64) error {
+	to := int64(from) + n
+	if to >= 0 && to < minutesPerDay {
+		return nil
+	}
+	wrapped := int(to % minutesPerDay)
+	if wrapped < 0 {
+		wrapped += minutesPerDay
+	}
+	return &DayBoundaryError{From: from, To: int(to), Wrapped: wrapped}
+}
+
+// clockTime writes a minute of the day as "HH:MM", carrying on past
+// "24:00" and below "00:00" as "-00:30"
+func clockTime(m int) string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, m/60, m%60)
+}
 
-	currentHour, err := c.parseField(c.Hour, 0, 23)
+// ErrWildcardCarry is returned when shifting an expression with a
+// wildcard hour by so much that the minute leaves its hour, as in
+// "30 * * * *" plus 45 minutes, making the shifted hours ambiguous
+var ErrWildcardCarry = errors.New("shift carries into a wildcard hour")
+
+// shiftMinutes moves every minute of a list, range or step by n minutes,
+// so "0,20,40 9 * * *" plus 10 minutes becomes "10,30,50 9 * * *". The
+// minutes must all stay in their hour or all carry into the same one. The
+// minutes of a wildcard hour are rotated within the hour instead, as
+// every hour fires: "50,55,0 * * * *" plus 10 minutes becomes
+// "0,5,10 * * * *".
+func (c *CronTime) shiftMinutes(n int64) error {
+	if n > maxShiftMinutes || n < -maxShiftMinutes {
+		return &OverflowError{Value: n, Unit: "minutes"}
+	}
+	minutes, err := parseSet(c.Minute, minuteField)
+	if err != nil {
+		return c.fieldError(minuteIndex, err)
+	}
+
+	if c.Hour == "*" {
+		c.Minute = formatSet(rotateSet(minutes, int(n%60), minuteField), minuteField)
+		return nil
+	}
+
+	if c.cfg.noWrap {
+		if hours, err := parseSet(c.Hour, hourField); err == nil {
+			if err := clockBoundary(hours, minutes, n); err != nil {
+				return err
+			}
+		}
+	}
+
+	shifted, carry, ok := shiftSet(minutes, int(n%60), 60)
+	if !ok {
+		return fmt.Errorf("cannot shift minute %s by %d minutes: %s", c.Minute, n, splitCarry(c.Minute, minuteField, int(n%60), 60, "hour"))
+	}
+
+	if hours := n/60 + int64(carry); hours != 0 {
+		if err := c.shiftHours(hours); err != nil {
+			return err
+		}
+	}
+	c.Minute = formatSet(shifted, minuteField)
+	return nil
+}
+
+// shiftHours moves every hour the expression fires in by n hours, so
+// "* 9-17 * * *" plus 2 hours becomes "* 11-19 * * *"
+func (c *CronTime) shiftHours(n int64) error {
+	if n > maxShiftMinutes/60 || n < -maxShiftMinutes/60 {
+		return &OverflowError{Value: n, Unit: "hours"}
+	}
+	hours, err := parseSet(c.Hour, hourField)
 	if err != nil {
-		return fmt.Errorf("error parsing hour: %v", err)
+		return c.fieldError(hourIndex, err)
+	}
+
+	if c.cfg.noWrap {
+		if minutes, err := parseSet(c.Minute, minuteField); err == nil {
+			if err := clockBoundary(hours, minutes, n*60); err != nil {
+				return err
+			}
+		}
+	}
+
+	shifted, carry, ok := shiftSet(hours, int(n%24), 24)
+	if c.restrictsDays() {
+		if !ok {
+			return fmt.Errorf("cannot shift hour %s by %d hours: %s", c.Hour, n, splitCarry(c.Hour, hourField, int(n%24), 24, "day"))
+		}
+		if err := c.carryDays(int(n/24) + carry); err != nil {
+			return err
+		}
+	}
+
+	c.Hour = formatSet(shifted, hourField)
+	return nil
+}
+
+// clockBoundary reports the first time the expression fires at, among
+// hours and minutes, that a shift of n minutes moves past midnight
+func clockBoundary(hours, minutes valueSet, n int64) error {
+	for _, h := range hours.values() {
+		for _, m := range minutes.values() {
+			if err := newDayBoundaryError(h*60+m, n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// shiftSet moves every value of s by rest, which is less than size in
+// magnitude, wrapping values past size around. It returns by how much the
+// values carry into the next unit, and reports false when they carry
+// differently.
+func shiftSet(s valueSet, rest, size int) (shifted valueSet, carry int, ok bool) {
+	ok = true
+	for i, v := range s.values() {
+		v, c := v+rest, 0
+		switch {
+		case v < 0:
+			v, c = v+size, -1
+		case v >= size:
+			v, c = v-size, 1
+		}
+		shifted |= 1 << uint(v)
+		if i == 0 {
+			carry = c
+		} else if c != carry {
+			ok = false
+		}
+	}
+	return shifted, carry, ok
+}
+
+// splitCarry explains why shiftSet failed for field, naming the element
+// of the list that does not carry with the others, e.g. "45 moves into
+// the next hour while 0-10 stays in its hour"
+func splitCarry(field string, f fieldSpec, rest, size int, unit string) string {
+	describe := func(carry int) string {
+		switch carry {
+		case -1:
+			return "moves into the previous " + unit
+		case 1:
+			return "moves into the next " + unit
+		}
+		return "stays in its " + unit
+	}
+
+	var first string
+	firstCarry := 0
+	for _, part := range strings.Split(field, ",") {
+		set, err := parseSetPart(part, f)
+		if err != nil {
+			continue
+		}
+		_, carry, ok := shiftSet(set, rest, size)
+		switch {
+		case !ok:
+			return fmt.Sprintf("%s lands in different %ss", part, unit)
+		case first == "":
+			first, firstCarry = part, carry
+		case carry != firstCarry:
+			return fmt.Sprintf("%s %s while %s %s", part, describe(carry), first, describe(firstCarry))
+		}
+	}
+	return fmt.Sprintf("the values land in different %ss", unit)
+}
+
+// setClock writes the minute and hour fields, where -1 is a wildcard
+func (c *CronTime) setClock(minute, hour int) {
+	c.Minute = strconv.Itoa(minute)
+	c.Hour = "*"
+	if hour != -1 {
+		c.Hour = strconv.Itoa(hour)
+	}
+	c.parsed = clockCache{
+		set:         true,
+		minuteField: c.Minute,
+		hourField:   c.Hour,
+		minute:      singleValue{n: minute},
+		hour:        singleValue{n: hour},
+	}
+}
+
+// shiftClock computes the minute and hour after shifting the expression
+// by the given number of minutes, and the number of days the shift
+// carries into. A wildcard hour, returned as -1, only allows shifts that
+// keep the minute within its hour.
+func (c *CronTime) shiftClock(totalMinutes int64) (minute, hour, dayShift int, err error) {
+	// Bounding the shift keeps the day count within an int on 32-bit
+	// platforms
+	if totalMinutes > maxShiftMinutes || totalMinutes < -maxShiftMinutes {
+		return 0, 0, 0, &OverflowError{Value: totalMinutes, Unit: "minutes"}
+	}
+
+	m, h := c.clockValues()
+	if m.err != nil {
+		return 0, 0, 0, c.fieldError(minuteIndex, m.err)
 	}
+	if h.err != nil {
+		return 0, 0, 0, c.fieldError(hourIndex, h.err)
+	}
+	currentMinute, currentHour := m.n, h.n
 
-	// Skip if wildcards
-	if currentMinute == -1 || currentHour == -1 {
-		return fmt.Errorf("cannot adjust wildcards")
+	switch {
+	case currentMinute == -1:
+		return 0, 0, 0, ErrWildcardMinute
+	case currentHour == -1:
+		// Every hour fires, so the minute moves within it unless the shift
+		// would carry into the next or previous hour
+		if m := int64(currentMinute) + totalMinutes; m >= 0 && m < 60 {
+			return int(m), -1, 0, nil
+		}
+		return 0, 0, 0, fmt.Errorf("%w: minute %d shifted by %d minutes", ErrWildcardCarry, currentMinute, totalMinutes)
 	}
 
-	// Calculate new time
+	// Calculate new time, wrapping into a single day. Shifts longer than
+	// a day are reduced first so the addition below cannot overflow.
 	totalCurrentMinutes := currentHour*60 + currentMinute
-	newTotalMinutes := totalCurrentMinutes + totalMinutes
+	if c.cfg.noWrap {
+		if err := newDayBoundaryError(totalCurrentMinutes, totalMinutes); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	newTotalMinutes := totalCurrentMinutes + int(totalMinutes%minutesPerDay)
+	dayShift = int(totalMinutes / minutesPerDay)
+	switch {
+	case newTotalMinutes < 0:
+		newTotalMinutes += minutesPerDay
+		dayShift--
+	case newTotalMinutes >= minutesPerDay:
+		newTotalMinutes -= minutesPerDay
+		dayShift++
+	}
+
+	return newTotalMinutes % 60, newTotalMinutes / 60, dayShift, nil
+}
+
+// MinuteOfDay returns the minute of the day (0-1439) at which an
+// expression with a single fixed time fires, so "30 9 * * *" gives 570
+func (c *CronTime) MinuteOfDay() (int, error) {
+	minute, err := c.parseField(c.Minute, 0, 59)
+	if err != nil {
+		return 0, c.fieldError(minuteIndex, err)
+	}
+
+	hour, err := c.parseField(c.Hour, 0, 23)
+	if err != nil {
+		return 0, c.fieldError(hourIndex, err)
+	}
+
+	if minute == -1 || hour == -1 {
+		return 0, fmt.Errorf("wildcards have no minute of day")
+	}
+	return hour*60 + minute, nil
+}
+
+// TimeOfDay returns the time since midnight at which an expression with a
+// single fixed time fires
+func (c *CronTime) TimeOfDay() (time.Duration, error) {
+	m, err := c.MinuteOfDay()
+	if err != nil {
+		return 0, err
+	}
+	return Minutes(m), nil
+}
+
+// ClockString returns the fixed time the expression fires at as "HH:MM",
+// or "HH:MM:SS" when it has a seconds field, so "5 9 * * *" is "09:05"
+func (c *CronTime) ClockString() (string, error) {
+	m, sec, err := c.clock()
+	if err != nil {
+		return "", err
+	}
+	if !c.layout.hasSeconds() {
+		return fmt.Sprintf("%02d:%02d", m/60, m%60), nil
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", m/60, m%60, sec), nil
+}
 
-	// Handle overflow/underflow for daily schedule
-	if newTotalMinutes < 0 {
-		// Go to previous day
-		newTotalMinutes += 24 * 60
-	} else if newTotalMinutes >= 24*60 {
-		// Go to next day
-		newTotalMinutes -= 24 * 60
+// ClockStringIn is ClockString for the wall clock of loc: the time the
+// expression fires at on anchor's date, in the expression's location (see
+// WithLocation) or else anchor's, read off a clock in loc. The date
+// matters only where a daylight saving change moves the offset between
+// the two.
+func (c *CronTime) ClockStringIn(loc *time.Location, anchor time.Time) (string, error) {
+	m, sec, err := c.clock()
+	if err != nil {
+		return "", err
 	}
 
-	newHour := newTotalMinutes / 60
-	newMinute := newTotalMinutes % 60
+	a := c.in(anchor)
+	t := time.Date(a.Year(), a.Month(), a.Day(), m/60, m%60, sec, 0, a.Location()).In(loc)
+	if !c.layout.hasSeconds() {
+		return t.Format("15:04"), nil
+	}
+	return t.Format("15:04:05"), nil
+}
 
-	c.Minute = strconv.Itoa(newMinute)
-	c.Hour = strconv.Itoa(newHour)
+// clock returns the fixed minute of day and second the expression fires
+// at
+func (c *CronTime) clock() (int, int, error) {
+	m, err := c.MinuteOfDay()
+	if err != nil {
+		return 0, 0, err
+	}
+	if !c.layout.hasSeconds() {
+		return m, 0, nil
+	}
+
+	sec, err := c.parseField(c.Second, secondField.min, secondField.max)
+	if err != nil {
+		return 0, 0, c.fieldError(secondIndex, err)
+	}
+	if sec == -1 {
+		return 0, 0, fmt.Errorf("wildcards have no second of minute")
+	}
+	return m, sec, nil
+}
 
+// SetTime replaces the minute and hour fields with the fixed time
+// hour:minute, whatever they held before, keeping the day fields as they
+// are
+func (c *CronTime) SetTime(hour, minute int) error {
+	if c.IsReboot() {
+		return ErrNotShiftable
+	}
+	t, err := atTimeOn(hour, minute, "*", "*")
+	if err != nil {
+		return err
+	}
+	c.Minute, c.Hour = t.Minute, t.Hour
 	return nil
 }
 
+// ToStep returns a copy of the expression firing every interval, anchored
+// at its fixed time: "15 9 * * *" every 6 hours becomes
+// "15 3,9,15,21 * * *". The interval must be a whole number of hours
+// dividing a day, or of minutes dividing an hour.
+func (c *CronTime) ToStep(every Duration) (*CronTime, error) {
+	m, err := c.MinuteOfDay()
+	if err != nil {
+		return nil, err
+	}
+
+	stepped := *c
+	switch {
+	case every > 0 && every%time.Hour == 0 && Hours(24)%every == 0:
+		n := int(every / time.Hour)
+		stepped.Hour = formatRuns(rangeSet(m/60%n, hourField.max, n))
+	case every > 0 && every < time.Hour && every%time.Minute == 0 && time.Hour%every == 0:
+		n := int(every / time.Minute)
+		stepped.Minute = formatRuns(rangeSet(m%60%n, minuteField.max, n))
+		stepped.Hour = "*"
+	default:
+		return nil, fmt.Errorf("interval %v does not divide a day into whole hours or an hour into whole minutes", every)
+	}
+	return &stepped, nil
+}
+
+// Until returns the signed offset from the expression's fixed time to the
+// wall-clock time hour:minute, taking the shorter way around midnight, so
+// c.Add of the result moves the expression to that time. From 23:00 to
+// 01:00 is 2 hours; from 01:00 to 23:00 is -2 hours. Exactly twelve hours
+// is returned as positive.
+func (c *CronTime) Until(hour, minute int) (Duration, error) {
+	target, err := atTimeOn(hour, minute, "*", "*")
+	if err != nil {
+		return 0, err
+	}
+	from, err := c.MinuteOfDay()
+	if err != nil {
+		return 0, err
+	}
+	to, _ := target.MinuteOfDay()
+
+	diff := ((to-from)%minutesPerDay + minutesPerDay) % minutesPerDay
+	if diff > minutesPerDay/2 {
+		diff -= minutesPerDay
+	}
+	return Minutes(diff), nil
+}
+
+// Round moves the fixed time of the expression to the nearest multiple of
+// d since midnight, rounding halves up, so "7 9 * * *" rounded to 15
+// minutes becomes "0 9 * * *" and "53 23 * * *" rounded to an hour
+// becomes "0 0 * * *". Like Add, rounding past midnight carries into the
+// day of month. d must be a whole number of minutes up to a day.
+func (c *CronTime) Round(d Duration) error {
+	n, err := c.roundShift(d)
+	if err != nil {
+		return err
+	}
+	return c.adjustTime(n)
+}
+
+// roundShift returns the minutes Round(d) shifts the expression by
+func (c *CronTime) roundShift(d Duration) (int64, error) {
+	if d <= 0 || d > Hours(24) || d%time.Minute != 0 {
+		return 0, fmt.Errorf("cannot round to %v: want whole minutes up to a day", d)
+	}
+	if c.IsReboot() {
+		return 0, ErrNotShiftable
+	}
+	m, err := c.MinuteOfDay()
+	if err != nil {
+		return 0, err
+	}
+
+	step := int(d / time.Minute)
+	rounded := (m + step/2) / step * step
+	return int64(rounded - m), nil
+}
+
+// FromMinuteOfDay returns the daily expression "M H * * *" firing at the
+// given minute of the day
+func FromMinuteOfDay(m int) (*CronTime, error) {
+	if m < 0 || m >= minutesPerDay {
+		return nil, fmt.Errorf("minute of day %d out of range [0, %d]", m, minutesPerDay-1)
+	}
+	return atTimeOn(m/60, m%60, "*", "*")
+}
+
+// FromClock returns the daily expression firing at a 24-hour clock time,
+// "H:MM" or "HH:MM", so "09:05" is "5 9 * * *". "HH:MM:SS" gives an
+// expression in LayoutSeconds.
+func FromClock(clock string) (*CronTime, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, fmt.Errorf("invalid clock time %q: want HH:MM or HH:MM:SS", clock)
+	}
+
+	vals := make([]int, len(parts))
+	for i, p := range parts {
+		if len(p) != 2 && !(i == 0 && len(p) == 1) || strings.Trim(p, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid clock time %q: want HH:MM or HH:MM:SS", clock)
+		}
+		vals[i], _ = strconv.Atoi(p)
+	}
+
+	c, err := atTimeOn(vals[0], vals[1], "*", "*")
+	if err != nil {
+		return nil, fmt.Errorf("invalid clock time %q: %v", clock, err)
+	}
+	if len(vals) == 3 {
+		if _, err := secondField.parseValue(parts[2]); err != nil {
+			return nil, fmt.Errorf("invalid clock time %q: invalid second: %v", clock, err)
+		}
+		c.layout, c.Second = LayoutSeconds, strconv.Itoa(vals[2])
+	}
+	return c, nil
+}
+
 // parseField parses a cron field value
 func (c *CronTime) parseField(field string, min, max int) (int, error) {
-	if field == "*" {
-		return -1, nil // Wildcard
+	v := parseSingle(field, min, max)
+	if v.err != nil {
+		return 0, v.err
 	}
+	return v.n, nil
+}
 
-	// Handle simple numeric values
-	val, err := strconv.Atoi(field)
+// AddBusiness adds a duration to the cron expression like Add, but when
+// the shift crosses midnight the days of the week keep moving forward
+// until they land on Monday to Friday. "0 18 * * FRI" plus 6 hours becomes
+// "0 0 * * 1". An expression firing every day becomes one firing on every
+// weekday. WithCalendar additionally skips holidays.
+func (c *CronTime) AddBusiness(d time.Duration) error {
+	m, err := durationMinutes(d)
 	if err != nil {
-		return 0, fmt.Errorf("unsupported field format: %s", field)
+		return err
 	}
+	return c.adjustBusiness(m)
+}
 
-	if val < min || val > max {
-		return 0, fmt.Errorf("value %d out of range [%d, %d]", val, min, max)
+// SubBusiness subtracts a duration from the cron expression like Sub, but
+// when the shift crosses midnight the days of the week keep moving back
+// until they land on Monday to Friday
+func (c *CronTime) SubBusiness(d time.Duration) error {
+	m, err := durationMinutes(d)
+	if err != nil {
+		return err
 	}
+	return c.adjustBusiness(-m)
+}
 
-	return val, nil
+// adjustBusiness adjusts the cron time by the given number of minutes,
+// moving the days of the week onto business days
+func (c *CronTime) adjustBusiness(totalMinutes int64) error {
+	if c.IsReboot() {
+		return ErrNotShiftable
+	}
+	minute, hour, dayShift, err := c.shiftClock(totalMinutes)
+	if err != nil {
+		return err
+	}
+
+	if dayShift != 0 {
+		if err := c.shiftBusinessDays(dayShift); err != nil {
+			return err
+		}
+	}
+
+	c.setClock(minute, hour)
+	return nil
+}
+
+// ShiftDays moves the days the expression fires on by n days, so
+// "0 9 * * FRI" shifted by 1 becomes "0 9 * * 6". Sunday may be written as
+// 0 or 7; both are the same day. Days of the month are moved as described
+// for WithAnchorMonth, and expressions restricted by both day fields
+// cannot be shifted. The anchor of NewAnchored moves along.
+func (c *CronTime) ShiftDays(n int) error {
+	if c.IsReboot() {
+		return ErrNotShiftable
+	}
+	if err := c.moveDays(n); err != nil {
+		return err
+	}
+	if c.cfg.wallLocation != nil {
+		c.cfg.wallTime = c.cfg.wallTime.AddDate(0, 0, n)
+	}
+	return nil
+}
+
+// moveDays moves the day fields by n days, as described for ShiftDays
+func (c *CronTime) moveDays(n int) error {
+	if limitsDays(c.DayOfMonth, dayOfMonthField) {
+		dom, month, err := c.shiftDays(n)
+		if err != nil {
+			return err
+		}
+		c.DayOfMonth, c.Month = dom, month
+		return nil
+	}
+
+	days, err := parseSet(c.DayOfWeek, dayOfWeekField)
+	if err != nil {
+		return c.fieldError(dayOfWeekIndex, err)
+	}
+	if days == dayOfWeekField.fullSet() {
+		return nil
+	}
+
+	c.DayOfWeek = formatDayOfWeek(rotateSet(days, n, dayOfWeekField), c.cfg.sundaySeven)
+	return nil
+}
+
+// shiftDays moves the days of an expression restricted by day of month,
+// returning the new day-of-month and month fields
+func (c *CronTime) shiftDays(n int) (string, string, error) {
+	if limitsDays(c.DayOfWeek, dayOfWeekField) {
+		return "", "", fmt.Errorf("cannot shift days of an expression restricted by both day of month and day of week: %s %s", c.DayOfMonth, c.DayOfWeek)
+	}
+	return c.shiftDayOfMonth(n, c.dayShifter())
 }
 
 // Duration represents a time duration for cron operations
 type Duration = time.Duration
 
-// Minutes creates a duration of n minutes
+// Minutes creates a duration of n minutes. n may be negative, so
+// Add(Minutes(-5)) shifts an expression 5 minutes earlier. Beyond the
+// range of a time.Duration it saturates to math.MaxInt64 or
+// math.MinInt64, which Add and Sub reject.
 func Minutes(n int) Duration {
-	return time.Duration(n) * time.Minute
+	return saturate(int64(n), time.Minute)
 }
 
-// Hours creates a duration of n hours
+// Hours creates a duration of n hours, which may be negative and
+// saturates like Minutes
 func Hours(n int) Duration {
-	return time.Duration(n) * time.Hour
+	return saturate(int64(n), time.Hour)
+}
+
+// saturate returns n units, clamped to the range of a time.Duration
+func saturate(n int64, unit time.Duration) Duration {
+	switch {
+	case n > math.MaxInt64/int64(unit):
+		return math.MaxInt64
+	case n < math.MinInt64/int64(unit):
+		return math.MinInt64
+	}
+	return time.Duration(n) * unit
+}
+
+// maxShiftMinutes is the largest shift, in minutes, that a time.Duration
+// can hold
+const maxShiftMinutes = math.MaxInt64 / int64(time.Minute)
+
+// OverflowError reports a shift too large for a time.Duration
+type OverflowError struct {
+	// Value is the size of the shift in Unit
+	Value int64
+	Unit  string
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("shift of %d %s overflows a time.Duration", e.Value, e.Unit)
+}
+
+// durationMinutes returns the whole minutes of d, rejecting the saturated
+// durations that stand for an overflow
+func durationMinutes(d time.Duration) (int64, error) {
+	if d == math.MaxInt64 || d == math.MinInt64 {
+		return 0, &OverflowError{Value: int64(d), Unit: "nanoseconds"}
+	}
+	return int64(d / time.Minute), nil
+}
+
+// clockMinutes returns hours and minutes as a number of minutes, checking
+// that each fits a time.Duration
+func clockMinutes(hours, minutes int) (int64, error) {
+	const maxHours = math.MaxInt64 / int64(time.Hour)
+	if h := int64(hours); h > maxHours || h < -maxHours {
+		return 0, &OverflowError{Value: h, Unit: "hours"}
+	}
+	if m := int64(minutes); m > maxShiftMinutes || m < -maxShiftMinutes {
+		return 0, &OverflowError{Value: m, Unit: "minutes"}
+	}
+	return int64(hours)*60 + int64(minutes), nil
 }
 
-// CronMath provides a fluent interface for cron arithmetic
+// CronMath provides a fluent interface for cron arithmetic. Its methods
+// change it in place and return it, so a CronMath must not be used from
+// several goroutines at once. To work on variants of one base in
+// parallel, give each goroutine its own copy with Derive: copies share
+// nothing they can change, and the base may be derived from concurrently
+// as long as it is not itself changed meanwhile.
 type CronMath struct {
-	cron *CronTime
-	err  error
+	cron     *CronTime
+	err      error
+	warnings []Warning
+
+	// start is the expression the chain started from, and history the
+	// operations applied since, each with the state it started from in
+	// saved for Undo
+	start   string
+	history []Step
+	saved   []state
+
+	// pinned lists the fields Pin keeps as they are
+	pinned []FieldName
 }
 
 // New creates a new CronMath instance from a cron string
-func New(cronStr string) *CronMath {
-	c, err := ParseCron(cronStr)
-	return &CronMath{cron: c, err: err}
+func New(cronStr string, opts ...Option) *CronMath {
+	c, err := ParseCronWith(cronStr, opts...)
+	return &CronMath{cron: c, err: err, start: cronStr}
 }
 
-// Add adds duration to the cron expression
-func (cm *CronMath) Add(d Duration) *CronMath {
-	if cm.err != nil {
-		return cm
+// From wraps the result of a constructor such as LastDayOfMonth for use
+// with the fluent interface, as in From(LastDayOfMonth(23, 0)).Sub(Hours(1))
+func From(c *CronTime, err error) *CronMath {
+	cm := &CronMath{cron: c, err: err}
+	if c != nil {
+		cm.start = c.String()
 	}
-	cm.err = cm.cron.Add(d)
 	return cm
 }
 
+// Derive returns an independent copy of the chain, with its expression,
+// error, warnings and history, so that
+//
+//	base := cronmath.New("0 9 * * *")
+//	go func() { fmt.Println(base.Derive().Add(cronmath.Hours(1))) }()
+//	go func() { fmt.Println(base.Derive().Sub(cronmath.Hours(1))) }()
+//
+// leaves base untouched. Undo on the copy can go back past the point it
+// was derived at. A chain made with From no longer changes the CronTime
+// it was given once derived.
+func (cm *CronMath) Derive() *CronMath {
+	d := &CronMath{
+		err:      cm.err,
+		warnings: slices.Clone(cm.warnings),
+		start:    cm.start,
+		history:  slices.Clone(cm.history),
+		saved:    slices.Clone(cm.saved),
+		pinned:   slices.Clone(cm.pinned),
+	}
+	if cm.cron != nil {
+		c := *cm.cron
+		d.cron = &c
+	}
+	return d
+}
+
+// NewFromClock creates a new CronMath instance from a clock time, as
+// accepted by FromClock
+func NewFromClock(clock string) *CronMath {
+	return From(FromClock(clock))
+}
+
+// Add adds duration to the cron expression
+func (cm *CronMath) Add(d Duration) *CronMath {
+	return cm.applyShift("Add", []any{d}, func() (int, error) {
+		return carriedDays(cm.cron, int64(d/time.Minute)), cm.cron.Add(d)
+	})
+}
+
 // Sub subtracts duration from the cron expression
 func (cm *CronMath) Sub(d Duration) *CronMath {
-	if cm.err != nil {
-		return cm
-	}
-	cm.err = cm.cron.Sub(d)
-	return cm
+	return cm.applyShift("Sub", []any{d}, func() (int, error) {
+		return carriedDays(cm.cron, int64(-d/time.Minute)), cm.cron.Sub(d)
+	})
+}
+
+// AddClock shifts the cron expression later by hours and minutes
+func (cm *CronMath) AddClock(hours, minutes int) *CronMath {
+	return cm.applyShift("AddClock", []any{hours, minutes}, func() (int, error) {
+		m, err := clockMinutes(hours, minutes)
+		if err != nil {
+			return 0, err
+		}
+		return carriedDays(cm.cron, m), cm.cron.AddClock(hours, minutes)
+	})
+}
+
+// SubClock shifts the cron expression earlier by hours and minutes
+func (cm *CronMath) SubClock(hours, minutes int) *CronMath {
+	return cm.applyShift("SubClock", []any{hours, minutes}, func() (int, error) {
+		m, err := clockMinutes(hours, minutes)
+		if err != nil {
+			return 0, err
+		}
+		return carriedDays(cm.cron, -m), cm.cron.SubClock(hours, minutes)
+	})
+}
+
+// OnDays limits the expression to the days of spec, as RestrictDays
+func (cm *CronMath) OnDays(spec DaySpec) *CronMath {
+	return cm.apply("OnDays", []any{spec}, func() error {
+		return cm.cron.RestrictDays(spec)
+	})
+}
+
+// ShiftDays moves the days of the week the expression fires on by n days
+func (cm *CronMath) ShiftDays(n int) *CronMath {
+	return cm.apply("ShiftDays", []any{n}, func() error {
+		return cm.cron.ShiftDays(n)
+	})
+}
+
+// AddBusiness adds duration to the cron expression, landing on business
+// days when midnight is crossed
+func (cm *CronMath) AddBusiness(d Duration) *CronMath {
+	return cm.applyShift("AddBusiness", []any{d}, func() (int, error) {
+		return carriedDays(cm.cron, int64(d/time.Minute)), cm.cron.AddBusiness(d)
+	})
+}
+
+// SubBusiness subtracts duration from the cron expression, landing on
+// business days when midnight is crossed
+func (cm *CronMath) SubBusiness(d Duration) *CronMath {
+	return cm.applyShift("SubBusiness", []any{d}, func() (int, error) {
+		return carriedDays(cm.cron, int64(-d/time.Minute)), cm.cron.SubBusiness(d)
+	})
+}
+
+// SetTime pins the expression to the fixed time hour:minute
+func (cm *CronMath) SetTime(hour, minute int) *CronMath {
+	return cm.apply("SetTime", []any{hour, minute}, func() error {
+		return cm.cron.SetTime(hour, minute)
+	})
+}
+
+// At pins the expression to the fixed time hour:minute, as SetTime, for
+// chains built from Daily
+func (cm *CronMath) At(hour, minute int) *CronMath {
+	return cm.apply("At", []any{hour, minute}, func() error {
+		return cm.cron.SetTime(hour, minute)
+	})
+}
+
+// After shifts the expression forward just enough to fire at least gap
+// after other. See EnsureGap.
+func (cm *CronMath) After(other *CronTime, gap Duration) *CronMath {
+	return cm.applyShift("After", []any{other, gap}, func() (int, error) {
+		shift, err := EnsureGap(other, cm.cron, gap)
+		if err != nil {
+			return 0, err
+		}
+		return carriedDays(cm.cron, int64(shift/time.Minute)), cm.cron.Add(shift)
+	})
+}
+
+// AddYears moves the years of the expression by n, collecting a warning
+// when there is no year to move
+func (cm *CronMath) AddYears(n int) *CronMath {
+	return cm.apply("AddYears", []any{n}, func() error {
+		warnings, err := cm.cron.AddYears(n)
+		cm.warnings = append(cm.warnings, warnings...)
+		return err
+	})
+}
+
+// Normalize rewrites the expression into its canonical form
+func (cm *CronMath) Normalize() *CronMath {
+	return cm.apply("Normalize", nil, func() error {
+		return cm.cron.Normalize()
+	})
+}
+
+// Compress rewrites the expression into its shortest equivalent syntax
+func (cm *CronMath) Compress() *CronMath {
+	return cm.apply("Compress", nil, func() error {
+		return cm.cron.Compress()
+	})
+}
+
+// Minimize drops the redundant parts of every field
+func (cm *CronMath) Minimize() *CronMath {
+	return cm.apply("Minimize", nil, func() error {
+		return cm.cron.Minimize()
+	})
+}
+
+// Round moves the fixed time of the expression to the nearest multiple of
+// d since midnight
+func (cm *CronMath) Round(d Duration) *CronMath {
+	return cm.applyShift("Round", []any{d}, func() (int, error) {
+		n, err := cm.cron.roundShift(d)
+		if err != nil {
+			return 0, err
+		}
+		return carriedDays(cm.cron, n), cm.cron.adjustTime(n)
+	})
+}
+
+// ScaleFrequency makes the expression fire factor times as often, or
+// -factor times less often when factor is negative
+func (cm *CronMath) ScaleFrequency(factor int) *CronMath {
+	return cm.apply("ScaleFrequency", []any{factor}, func() error {
+		return cm.cron.ScaleFrequency(factor)
+	})
+}
+
+// Halve makes the expression fire half as often
+func (cm *CronMath) Halve() *CronMath {
+	return cm.apply("Halve", nil, func() error {
+		return cm.cron.Halve()
+	})
+}
+
+// Double makes the expression fire twice as often
+func (cm *CronMath) Double() *CronMath {
+	return cm.apply("Double", nil, func() error {
+		return cm.cron.Double()
+	})
 }
 
 // String returns the resulting cron expression
@@ -162,6 +1377,11 @@ func (cm *CronMath) String() string {
 	return cm.cron.String()
 }
 
+// Warnings returns the warnings collected by the operations so far
+func (cm *CronMath) Warnings() []Warning {
+	return cm.warnings
+}
+
 // Error returns any error that occurred during operations
 func (cm *CronMath) Error() error {
 	return cm.err
@@ -1,7 +1,16 @@
 package cronmath
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"testing/quick"
 	"time"
 )
 
@@ -57,6 +66,12 @@ func TestCronTime_Sub(t *testing.T) {
 			duration: Minutes(60),
 			want:     "30 23 * * *",
 		},
+		{
+			name:     "subtract more than a day",
+			cronStr:  "30 0 * * *",
+			duration: Hours(73),
+			want:     "30 23 * * *",
+		},
 	}
 
 	for _, tt := range tests {
@@ -115,6 +130,12 @@ func TestCronTime_Add(t *testing.T) {
 			duration: Hours(2),
 			want:     "15 12 * * *",
 		},
+		{
+			name:     "add more than a day",
+			cronStr:  "30 23 * * *",
+			duration: Hours(49),
+			want:     "30 0 * * *",
+		},
 	}
 
 	for _, tt := range tests {
@@ -136,6 +157,390 @@ func TestCronTime_Add(t *testing.T) {
 	}
 }
 
+func TestCronTime_AddWildcardHour(t *testing.T) {
+	tests := []struct {
+		name     string
+		cronStr  string
+		duration time.Duration
+		want     string
+		wantErr  error
+	}{
+		{"forward within the hour", "30 * * * *", Minutes(15), "45 * * * *", nil},
+		{"back within the hour", "30 * * * 1-5", -Minutes(30), "0 * * * 1-5", nil},
+		{"to the end of the hour", "0 * * * *", Minutes(59), "59 * * * *", nil},
+		{"carries forward", "30 * * * *", Minutes(45), "30 * * * *", ErrWildcardCarry},
+		{"carries back", "10 * * * *", -Minutes(11), "10 * * * *", ErrWildcardCarry},
+		{"whole hour", "30 * * * *", Hours(1), "30 * * * *", ErrWildcardCarry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+
+			if err := cron.Add(tt.duration); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("Add() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddWildcardMinute(t *testing.T) {
+	tests := []struct {
+		name     string
+		cronStr  string
+		duration time.Duration
+		want     string
+		wantErr  bool
+	}{
+		{"single hour", "* 9 * * *", Hours(2), "* 11 * * *", false},
+		{"range", "* 9-17 * * 1-5", Hours(2), "* 11-19 * * 1-5", false},
+		{"back across midnight", "* 0,12 * * *", -Hours(1), "* 11,23 * * *", false},
+		{"step", "* */6 * * *", Hours(1), "* 1-19/6 * * *", false},
+		{"carries into day of month", "* 23 14 * *", Hours(2), "* 1 15 * *", false},
+		{"hours land on different days", "* 12,23 14 * *", Hours(2), "", true},
+		{"not whole hours", "* 9 * * *", Minutes(90), "", true},
+		{"both wildcards", "* * * * *", Hours(1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+
+			err = cron.Add(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("Add() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddMinuteList(t *testing.T) {
+	tests := []struct {
+		name     string
+		cronStr  string
+		duration time.Duration
+		want     string
+		wantErr  bool
+	}{
+		{"wildcard hour wraps", "50,55,0 * * * *", Minutes(10), "0,5,10 * * * *", false},
+		{"wildcard hour backwards", "0,5,10 * * * *", -Minutes(10), "0,50,55 * * * *", false},
+		{"within the hour", "0,20,40 9 * * *", Minutes(10), "10,30,50 9 * * *", false},
+		{"all carry", "40,50 9 * * *", Minutes(30), "10,20 10 * * *", false},
+		{"range carries across midnight", "45-50 23 14 * *", Minutes(20), "5-10 0 15 * *", false},
+		{"step with hour list", "*/30 9,17 * * *", Hours(1), "0,30 10,18 * * *", false},
+		{"minutes land in different hours", "0,50 9 * * *", Minutes(20), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+
+			err = cron.Add(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("Add() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddDayOfWeek(t *testing.T) {
+	tests := []struct {
+		name     string
+		cronStr  string
+		duration time.Duration
+		opts     []Option
+		want     string
+		wantErr  bool
+	}{
+		{"weekdays back", "15 0 * * 1-5", -Minutes(30), nil, "45 23 * * 0-4", false},
+		{"weekdays forward", "30 23 * * 1-5", Hours(1), nil, "30 0 * * 2-6", false},
+		{"wraps forward through Sunday", "0 22 * * 5-6", Hours(3), nil, "0 1 * * 0,6", false},
+		{"wraps back through Sunday", "0 1 * * 0,1", -Hours(2), nil, "0 23 * * 0,6", false},
+		{"Sunday as seven", "0 22 * * 5-6", Hours(3), []Option{WithSundayAsSeven()}, "0 1 * * 6,7", false},
+		{"names", "0 0 * * MON,WED", -Minutes(1), nil, "59 23 * * 0,2", false},
+		{"several days", "0 12 * * 1", Hours(24 * 3), nil, "0 12 * * 4", false},
+		{"within the day", "0 9 * * 1-5", Hours(2), nil, "0 11 * * 1-5", false},
+		{"stepped", "0 23 * * */2", Hours(1), nil, "0 0 * * 0,1,3,5", false},
+		{"minute list", "0,30 23 * * 5", Hours(1), nil, "0,30 0 * * 6", false},
+		{"wildcard minute", "* 23 * * 6", Hours(2), nil, "* 1 * * 0", false},
+		{"hours on different days", "* 12,23 * * 6", Hours(2), nil, "", true},
+		{"both day fields", "0 0 13 * 5", -Minutes(1), nil, "", true},
+		{"nth weekday", "0 0 * * 5#2", -Minutes(1), nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+
+			err = cron.Add(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if got := cron.String(); got != tt.cronStr {
+					t.Errorf("expression changed to %q on error", got)
+				}
+				return
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("Add() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_ShiftCollisions(t *testing.T) {
+	tests := []struct {
+		name  string
+		shift func(*CronTime) error
+		cron  string
+		want  string
+	}{
+		// Friday and Saturday both move on to Monday
+		{"business days", func(c *CronTime) error { return c.AddBusiness(Hours(3)) }, "0 22 * * 5,6", "0 1 * * 1"},
+		{"normalized list", func(c *CronTime) error { return c.Normalize() }, "10,5,0,10 9 * * *", "0,5,10 9 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, _ := ParseCron(tt.cron)
+			if err := tt.shift(cron); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddClock(t *testing.T) {
+	tests := []struct {
+		name           string
+		cronStr        string
+		hours, minutes int
+		want           string
+		wantErr        bool
+	}{
+		{"hours and minutes", "0 9 * * *", 2, 30, "30 11 * * *", false},
+		{"mixed signs", "0 9 * * *", 1, -15, "45 9 * * *", false},
+		{"negative", "0 9 * * *", -2, -30, "30 6 * * *", false},
+		{"wraps midnight", "30 23 * * *", 0, 45, "15 0 * * *", false},
+		{"carries into day of month", "0 23 14 * *", 2, 0, "0 1 15 * *", false},
+		{"wildcard minute by whole hours", "* 9 * * *", 1, 0, "* 10 * * *", false},
+		{"wildcard minute", "* 9 * * *", 1, 30, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			err = cron.AddClock(tt.hours, tt.minutes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddClock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("AddClock() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_SubClock(t *testing.T) {
+	if got, want := New("0 9 * * *").SubClock(2, 30).String(), "30 6 * * *"; got != want {
+		t.Errorf("SubClock() = %q, want %q", got, want)
+	}
+	if got, want := New("0 9 * * *").AddClock(1, -15).SubClock(0, 45).String(), "0 9 * * *"; got != want {
+		t.Errorf("AddClock().SubClock() = %q, want %q", got, want)
+	}
+}
+
+func TestDurationOverflow(t *testing.T) {
+	if got := Minutes(math.MaxInt); got != math.MaxInt64 {
+		t.Errorf("Minutes(math.MaxInt) = %d, want math.MaxInt64", got)
+	}
+	if got := Hours(math.MinInt); got != math.MinInt64 {
+		t.Errorf("Hours(math.MinInt) = %d, want math.MinInt64", got)
+	}
+	if got, want := Minutes(90), 90*time.Minute; got != want {
+		t.Errorf("Minutes(90) = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name  string
+		shift func(*CronTime) error
+	}{
+		{"Add minutes", func(c *CronTime) error { return c.Add(Minutes(math.MaxInt)) }},
+		{"Sub hours", func(c *CronTime) error { return c.Sub(Hours(math.MinInt)) }},
+		{"AddBusiness", func(c *CronTime) error { return c.AddBusiness(Hours(math.MaxInt)) }},
+		{"AddClock hours", func(c *CronTime) error { return c.AddClock(math.MaxInt, 0) }},
+		{"AddClock minutes", func(c *CronTime) error { return c.AddClock(0, math.MinInt) }},
+		{"SubClock combined", func(c *CronTime) error { return c.SubClock(2000000, 100000000) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, _ := ParseCron("30 9 * * *")
+			err := tt.shift(cron)
+			var overflow *OverflowError
+			if !errors.As(err, &overflow) {
+				t.Fatalf("error = %v, want *OverflowError", err)
+			}
+			if got := cron.String(); got != "30 9 * * *" {
+				t.Errorf("expression changed to %q on overflow", got)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddNegative(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		d       time.Duration
+		want    string
+		wantErr bool
+	}{
+		{"back to midnight", "5 0 * * *", Minutes(-5), "0 0 * * *", false},
+		{"back across midnight", "0 0 * * *", Minutes(-1), "59 23 * * *", false},
+		{"day of month follows", "0 0 15 * *", Hours(-1), "0 23 14 * *", false},
+		{"first of the month", "30 0 1 * *", Minutes(-31), "59 23 L * *", false},
+		{"more than a day", "0 1 10 * *", Hours(-49), "0 0 8 * *", false},
+		{"seconds cut towards zero", "0 9 * * *", -90 * time.Second, "59 8 * * *", false},
+		{"minute list", "10,20 0 * * *", Minutes(-15), "", true},
+		{"wildcard hour", "10 * * * *", Minutes(-11), "", true},
+		{"saturated", "0 9 * * *", Minutes(math.MinInt), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, _ := ParseCron(tt.cronStr)
+			subbed, _ := ParseCron(tt.cronStr)
+			addErr, subErr := added.Add(tt.d), subbed.Sub(-tt.d)
+			if tt.d == math.MinInt64 {
+				// -d overflows, so compare with the opposite saturation
+				subbed, _ = ParseCron(tt.cronStr)
+				subErr = subbed.Sub(Minutes(math.MaxInt))
+			}
+
+			if (addErr != nil) != tt.wantErr || (subErr != nil) != tt.wantErr {
+				t.Fatalf("Add() error = %v, Sub() error = %v, wantErr %v", addErr, subErr, tt.wantErr)
+			}
+			if added.String() != subbed.String() {
+				t.Errorf("Add(%v) = %q but Sub(%v) = %q", tt.d, added, -tt.d, subbed)
+			}
+			if !tt.wantErr && added.String() != tt.want {
+				t.Errorf("Add(%v) = %q, want %q", tt.d, added, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddNegativeIsSub(t *testing.T) {
+	exprs := []string{"30 0 1 * *", "0 0 * * *", "59 23 31 12 *", "0,30 12 * * *", "* 3 * * *", "15 * * * *"}
+	f := func(i uint8, n int32) bool {
+		expr := exprs[int(i)%len(exprs)]
+		d := time.Duration(n) * time.Second
+		added, _ := ParseCron(expr)
+		subbed, _ := ParseCron(expr)
+		addErr, subErr := added.Add(-d), subbed.Sub(d)
+		return (addErr == nil) == (subErr == nil) && added.String() == subbed.String()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCronMath_MixedSigns(t *testing.T) {
+	tests := []struct {
+		name string
+		cm   *CronMath
+		want string
+	}{
+		{"round trip across midnight", New("0 0 15 * *").Add(Minutes(-1)).Sub(Minutes(-1)), "0 0 15 * *"},
+		{"negative sub", New("45 23 * * *").Add(Minutes(-50)).Sub(Hours(-2)).Add(Hours(-1)), "55 23 * * *"},
+		{"cancelling", New("30 9 1 * *").Add(Hours(-10)).Add(Hours(10)), "30 9 1 * *"},
+		{"clock", New("10 0 * * *").AddClock(-1, 30).SubClock(0, -20), "0 0 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cm.Error(); err != nil {
+				t.Fatalf("Error() = %v", err)
+			}
+			if got := tt.cm.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddLargestShift(t *testing.T) {
+	cron, _ := ParseCron("0 0 * * *")
+	if err := cron.Add(math.MaxInt64 - 1); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	// 153722867 minutes is 106751 days, 23 hours and 47 minutes
+	if got, want := cron.String(), "47 23 * * *"; got != want {
+		t.Errorf("Add() = %q, want %q", got, want)
+	}
+}
+
+func TestCronTime_AddAfterFieldsChanged(t *testing.T) {
+	// The parsed clock must not outlive fields written directly
+	tests := []struct {
+		name         string
+		minute, hour string
+		want         string
+		wantErr      bool
+	}{
+		{name: "value", minute: "15", hour: "23", want: "15 0 2 * *"},
+		{name: "list", minute: "0,30", hour: "9", want: "0,30 10 1 * *"},
+		{name: "wildcard minute", minute: "*", hour: "9", want: "* 10 1 * *"},
+		{name: "out of range", minute: "75", hour: "9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, _ := ParseCron("30 9 1 * *")
+			if err := cron.Add(Hours(1)); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			cron.Minute, cron.Hour = tt.minute, tt.hour
+			err := cron.Add(Hours(1))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("Add() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
 func TestCronMath_FluentInterface(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -185,6 +590,38 @@ func TestCronMath_FluentInterface(t *testing.T) {
 	}
 }
 
+func TestCronMath_Derive(t *testing.T) {
+	base := New("0 9 1 * *").Add(Hours(1))
+
+	var wg sync.WaitGroup
+	got := make([]string, 48)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := base.Derive().Add(Hours(i))
+			if i%2 == 0 {
+				d.Undo().Undo()
+			}
+			got[i] = d.String()
+		}()
+	}
+	wg.Wait()
+
+	for i, s := range got {
+		want := From(ParseCron("0 10 1 * *")).Add(Hours(i)).String()
+		if i%2 == 0 {
+			want = "0 9 1 * *"
+		}
+		if s != want {
+			t.Errorf("Derive().Add(Hours(%d)) = %q, want %q", i, s, want)
+		}
+	}
+	if s := base.String(); s != "0 10 1 * *" || len(base.History()) != 1 {
+		t.Errorf("base = %q with %d steps, want it untouched", s, len(base.History()))
+	}
+}
+
 func TestCronTime_Wildcards(t *testing.T) {
 	cron, _ := ParseCron("* 9 * * *")
 	err := cron.Sub(Minutes(5))
@@ -193,3 +630,750 @@ func TestCronTime_Wildcards(t *testing.T) {
 		t.Errorf("Expected error when adjusting wildcards, got nil")
 	}
 }
+
+// fixedTime is a random fixed-time expression for property tests.
+type fixedTime struct {
+	Minute, Hour int
+}
+
+func (fixedTime) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(fixedTime{Minute: r.Intn(60), Hour: r.Intn(24)})
+}
+
+func (f fixedTime) cron() *CronTime {
+	return &CronTime{
+		Minute:     strconv.Itoa(f.Minute),
+		Hour:       strconv.Itoa(f.Hour),
+		DayOfMonth: "*",
+		Month:      "*",
+		DayOfWeek:  "*",
+	}
+}
+
+func (f fixedTime) String() string {
+	return fmt.Sprintf("%02d:%02d", f.Hour, f.Minute)
+}
+
+// shift is a random whole-minute duration spanning several days either way.
+type shift time.Duration
+
+func (shift) Generate(r *rand.Rand, _ int) reflect.Value {
+	const span = 10 * minutesPerDay
+	return reflect.ValueOf(shift(Minutes(r.Intn(2*span+1) - span)))
+}
+
+func TestCronTime_Properties(t *testing.T) {
+	config := &quick.Config{MaxCount: 2000}
+
+	t.Run("add then sub restores the expression", func(t *testing.T) {
+		property := func(f fixedTime, d shift) bool {
+			cron := f.cron()
+			want := cron.String()
+			if err := cron.Add(time.Duration(d)); err != nil {
+				return false
+			}
+			if err := cron.Sub(time.Duration(d)); err != nil {
+				return false
+			}
+			return cron.String() == want
+		}
+		if err := quick.Check(property, config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("consecutive adds equal a single add", func(t *testing.T) {
+		property := func(f fixedTime, a, b shift) bool {
+			stepwise, combined := f.cron(), f.cron()
+			if err := stepwise.Add(time.Duration(a)); err != nil {
+				return false
+			}
+			if err := stepwise.Add(time.Duration(b)); err != nil {
+				return false
+			}
+			if err := combined.Add(time.Duration(a + b)); err != nil {
+				return false
+			}
+			return stepwise.String() == combined.String()
+		}
+		if err := quick.Check(property, config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("result stays in range", func(t *testing.T) {
+		property := func(f fixedTime, d shift) bool {
+			cron := f.cron()
+			if err := cron.Add(time.Duration(d)); err != nil {
+				return false
+			}
+			minute, err := cron.parseField(cron.Minute, 0, 59)
+			if err != nil || minute < 0 {
+				return false
+			}
+			hour, err := cron.parseField(cron.Hour, 0, 23)
+			if err != nil || hour < 0 {
+				return false
+			}
+			want := ((f.Hour*60+f.Minute+int(time.Duration(d)/time.Minute))%minutesPerDay + minutesPerDay) % minutesPerDay
+			return hour*60+minute == want
+		}
+		if err := quick.Check(property, config); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestCronTime_MinuteOfDay(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		want    int
+		wantErr bool
+	}{
+		{"0 0 * * *", 0, false},
+		{"30 9 * * 1-5", 570, false},
+		{"59 23 * * *", 1439, false},
+		{"* 9 * * *", 0, true},
+		{"0,30 9 * * *", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			got, err := cron.MinuteOfDay()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MinuteOfDay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MinuteOfDay() = %d, want %d", got, tt.want)
+			}
+			if d, err := cron.TimeOfDay(); !tt.wantErr && (err != nil || d != Minutes(tt.want)) {
+				t.Errorf("TimeOfDay() = %v, %v, want %v", d, err, Minutes(tt.want))
+			}
+		})
+	}
+}
+
+func TestFromMinuteOfDay(t *testing.T) {
+	tests := []struct {
+		m       int
+		want    string
+		wantErr bool
+	}{
+		{0, "0 0 * * *", false},
+		{570, "30 9 * * *", false},
+		{1439, "59 23 * * *", false},
+		{-1, "", true},
+		{1440, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.m), func(t *testing.T) {
+			cron, err := FromMinuteOfDay(tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromMinuteOfDay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("FromMinuteOfDay() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFromClock(t *testing.T) {
+	tests := []struct {
+		clock   string
+		want    string
+		wantErr bool
+	}{
+		{"09:05", "5 9 * * *", false},
+		{"9:05", "5 9 * * *", false},
+		{"00:00", "0 0 * * *", false},
+		{"23:59", "59 23 * * *", false},
+		{"09:05:30", "30 5 9 * * *", false},
+		{"25:99", "", true},
+		{"12:60", "", true},
+		{"12:00:60", "", true},
+		{"9:5", "", true},
+		{"+9:05", "", true},
+		{"0905", "", true},
+		{"09:05:00:00", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.clock, func(t *testing.T) {
+			cron, err := FromClock(tt.clock)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromClock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("FromClock() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFromClock(t *testing.T) {
+	if got, want := NewFromClock("09:05").Sub(Minutes(15)).String(), "50 8 * * *"; got != want {
+		t.Errorf("NewFromClock().Sub() = %q, want %q", got, want)
+	}
+	if err := NewFromClock("25:99").Sub(Minutes(15)).Error(); err == nil {
+		t.Error("NewFromClock() expected error for an invalid clock time, got nil")
+	}
+}
+
+func TestCronTime_Until(t *testing.T) {
+	tests := []struct {
+		name         string
+		cronStr      string
+		hour, minute int
+		want         time.Duration
+		wantErr      bool
+	}{
+		{"later the same day", "0 7 * * *", 9, 0, Hours(2), false},
+		{"earlier the same day", "30 9 * * *", 9, 0, -Minutes(30), false},
+		{"forward across midnight", "0 23 * * *", 1, 0, Hours(2), false},
+		{"back across midnight", "0 1 * * *", 23, 0, -Hours(2), false},
+		{"half a day", "0 0 * * *", 12, 0, Hours(12), false},
+		{"same time", "0 9 * * *", 9, 0, 0, false},
+		{"wildcard", "* 9 * * *", 9, 0, 0, true},
+		{"target out of range", "0 9 * * *", 24, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, _ := ParseCron(tt.cronStr)
+			got, err := cron.Until(tt.hour, tt.minute)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Until() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Until() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_SetTime(t *testing.T) {
+	tests := []struct {
+		name         string
+		cronStr      string
+		hour, minute int
+		want         string
+		wantErr      bool
+	}{
+		{"fixed time", "17 4 1 * *", 9, 30, "30 9 1 * *", false},
+		{"wildcards", "* * * * MON", 6, 0, "0 6 * * MON", false},
+		{"complex fields", "*/15 9-17 * * 1-5", 12, 45, "45 12 * * 1-5", false},
+		{"minute out of range", "0 9 * * *", 9, 60, "", true},
+		{"hour out of range", "0 9 * * *", -1, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, _ := ParseCron(tt.cronStr)
+			err := cron.SetTime(tt.hour, tt.minute)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if cron.String() != tt.cronStr {
+					t.Errorf("SetTime() changed the expression to %q on error", cron.String())
+				}
+				return
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("SetTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_SetTime(t *testing.T) {
+	if got, want := New("17 4 1 * *").SetTime(9, 30).Add(Minutes(15)).String(), "45 9 1 * *"; got != want {
+		t.Errorf("SetTime() = %q, want %q", got, want)
+	}
+}
+
+func TestCronTime_ToStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		every   time.Duration
+		want    string
+		wantErr bool
+	}{
+		{"every 6 hours", "15 9 * * *", Hours(6), "15 3,9,15,21 * * *", false},
+		{"every 12 hours keeps days", "0 2 * * 1-5", Hours(12), "0 2,14 * * 1-5", false},
+		{"every 8 hours from midnight", "30 0 * * *", Hours(8), "30 0,8,16 * * *", false},
+		{"every 20 minutes", "5 9 * * *", Minutes(20), "5,25,45 * * * *", false},
+		{"every day", "15 9 * * *", Hours(24), "15 9 * * *", false},
+		{"does not divide a day", "15 9 * * *", Hours(5), "", true},
+		{"not whole minutes", "15 9 * * *", 90 * time.Second, "", true},
+		{"wildcard", "* 9 * * *", Hours(6), "", true},
+		{"list", "0 9,10 * * *", Hours(6), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, _ := ParseCron(tt.cronStr)
+			got, err := cron.ToStep(tt.every)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToStep() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ToStep() = %q, want %q", got.String(), tt.want)
+			}
+			if cron.String() != tt.cronStr {
+				t.Errorf("ToStep() modified the receiver to %q", cron.String())
+			}
+		})
+	}
+}
+
+func TestParseCronWith_AutoFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantLayout Layout
+		want       string
+		wantErr    bool
+	}{
+		{"standard", "30 9 * * 1-5", LayoutStandard, "30 9 * * 1-5", false},
+		{"seconds", "15 30 9 * * 1-5", LayoutSeconds, "15 30 9 * * 1-5", false},
+		{"quartz with year", "0 30 9 ? * MON-FRI 2026", LayoutSecondsYear, "0 30 9 ? * MON-FRI 2026", false},
+		{"invalid seconds", "60 30 9 * * *", LayoutStandard, "", true},
+		{"too many fields", "0 0 0 * * * * *", LayoutStandard, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.input, WithAutoFields())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCronWith() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cron.Layout() != tt.wantLayout {
+				t.Errorf("Layout() = %v, want %v", cron.Layout(), tt.wantLayout)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCron_RejectsSecondsWithoutAutoFields(t *testing.T) {
+	if _, err := ParseCron("0 30 9 * * *"); err == nil {
+		t.Error("ParseCron() expected error for six fields, got nil")
+	}
+}
+
+func TestCronTime_AutoFieldsArithmetic(t *testing.T) {
+	got := New("15 30 23 ? * * 2026", WithAutoFields()).Add(Hours(1)).String()
+	if want := "15 30 0 ? * * 2026"; got != want {
+		t.Errorf("Add() = %q, want %q", got, want)
+	}
+}
+
+func TestCronTime_AppendString(t *testing.T) {
+	tests := []struct {
+		expr string
+		opts []Option
+		want string
+	}{
+		{"30 9 * * 1-5", nil, "30 9 * * 1-5"},
+		{"0 0 * jan 5-7", []Option{WithNameCase(TitleCase)}, "0 0 * Jan 0,5-6"},
+		{"0 0 * * sun,0-2", []Option{WithSundayAsSeven(), WithNameCase(LowerCase)}, "0 0 * * sun,1-2,7"},
+		{"15 30 9 * * ? 2030", []Option{WithAutoFields()}, "15 30 9 * * ? 2030"},
+		{"0 0 * * *", []Option{WithMacroOutput()}, "@daily"},
+		{"@reboot", nil, "@reboot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.expr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if got := string(cron.AppendString([]byte("cron: "))); got != "cron: "+tt.want {
+				t.Errorf("AppendString() = %q, want %q", got, "cron: "+tt.want)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_ClockString(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		want    string
+		wantErr bool
+	}{
+		{"5 9 * * *", "09:05", false},
+		{"0 0 1 * *", "00:00", false},
+		{"30 15 10 * * *", "10:15:30", false},
+		{"*/5 9 * * *", "", true},
+		{"* 10 * * * *", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithAutoFields())
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			got, err := cron.ClockString()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClockString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ClockString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_ClockStringIn(t *testing.T) {
+	tokyo, err1 := time.LoadLocation("Asia/Tokyo")
+	newYork, err2 := time.LoadLocation("America/New_York")
+	if err1 != nil || err2 != nil {
+		t.Skip("time zone data unavailable")
+	}
+
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		loc     *time.Location
+		anchor  time.Time
+		want    string
+	}{
+		{"tokyo to utc", "30 9 * * *", []Option{WithLocation(tokyo)}, time.UTC, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), "00:30"},
+		{"anchor location", "0 9 * * *", nil, time.UTC, time.Date(2025, 1, 15, 0, 0, 0, 0, newYork), "14:00"},
+		{"daylight saving", "0 9 * * *", nil, time.UTC, time.Date(2025, 7, 15, 0, 0, 0, 0, newYork), "13:00"},
+		{"seconds", "15 0 9 * * *", []Option{WithAutoFields(), WithLocation(time.UTC)}, tokyo, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), "18:00:15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			got, err := cron.ClockStringIn(tt.loc, tt.anchor)
+			if err != nil {
+				t.Fatalf("ClockStringIn() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ClockStringIn() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_Round(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		d       Duration
+		want    string
+		wantErr bool
+	}{
+		{"down to quarter", "7 9 * * *", Minutes(15), "0 9 * * *", false},
+		{"half rounds up", "30 9 * * *", Hours(1), "0 10 * * *", false},
+		{"already rounded", "45 9 * * *", Minutes(15), "45 9 * * *", false},
+		{"carries into next day", "53 23 5 * *", Hours(1), "0 0 6 * *", false},
+		{"whole day", "0 13 * * *", Hours(24), "0 0 * * *", false},
+		{"not whole minutes", "0 9 * * *", Duration(90 * time.Second), "", true},
+		{"zero", "0 9 * * *", 0, "", true},
+		{"longer than a day", "0 9 * * *", Hours(25), "", true},
+		{"not fixed time", "*/5 9 * * *", Minutes(15), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			err = cron.Round(tt.d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Round() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("Round() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCron_CommentsAndWhitespace(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        string
+		wantComment string
+	}{
+		{"trailing comment", "5 9 * * *  # morning report", "5 9 * * *", "morning report"},
+		{"comment without space", "5 9 * * * #nightly", "5 9 * * *", "nightly"},
+		{"nth weekday is not a comment", "0 9 * * 1#2 # second Monday", "0 9 * * 1#2", "second Monday"},
+		{"tabs", "0\t9\t*\t*\t1-5", "0 9 * * 1-5", ""},
+		{"multiple spaces", "  0   9 *  * *  ", "0 9 * * *", ""},
+		{"CRLF", "30 2 * * 0\r\n", "30 2 * * 0", ""},
+		{"CRLF after comment", "30 2 * * 0 # weekly backup\r\n", "30 2 * * 0", "weekly backup"},
+		{"byte order mark", "\ufeff0 0 1 * *\n", "0 0 1 * *", ""},
+		{"macro with comment", "@daily # cleanup", "0 0 * * *", "cleanup"},
+		{"comment after a carriage return", "5 9 * * *\r# nightly", "5 9 * * *", "nightly"},
+		{"comment after a no-break space", "5 9 * * *\u00a0#nightly", "5 9 * * *", "nightly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.input)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := cron.Comment(); got != tt.wantComment {
+				t.Errorf("Comment() = %q, want %q", got, tt.wantComment)
+			}
+		})
+	}
+}
+
+func TestParseCron_OnlyComment(t *testing.T) {
+	if _, err := ParseCron("# 0 9 * * *"); err == nil {
+		t.Error("ParseCron() expected error for a commented-out line, got nil")
+	}
+}
+
+func TestParseCron_Reboot(t *testing.T) {
+	cron, err := ParseCron("@reboot # start the queue")
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	if !cron.IsReboot() || cron.String() != "@reboot" || cron.Comment() != "start the queue" {
+		t.Errorf("ParseCron() = %q, IsReboot() %v, Comment() %q", cron.String(), cron.IsReboot(), cron.Comment())
+	}
+
+	shifts := map[string]func() error{
+		"Add":         func() error { return cron.Add(Hours(1)) },
+		"Sub":         func() error { return cron.Sub(Minutes(5)) },
+		"ShiftDays":   func() error { return cron.ShiftDays(1) },
+		"AddBusiness": func() error { return cron.AddBusiness(Hours(1)) },
+		"SetTime":     func() error { return cron.SetTime(9, 0) },
+		"Round":       func() error { return cron.Round(Hours(1)) },
+	}
+	for name, shift := range shifts {
+		if err := shift(); !errors.Is(err, ErrNotShiftable) {
+			t.Errorf("%s() error = %v, want ErrNotShiftable", name, err)
+		}
+	}
+	if err := cron.Normalize(); err != nil || cron.String() != "@reboot" {
+		t.Errorf("Normalize() = %q, error %v", cron.String(), err)
+	}
+	if w := cron.Lint(); len(w) != 0 {
+		t.Errorf("Lint() = %v, want none", w)
+	}
+	if _, err := cron.Next(time.Now()); err == nil {
+		t.Error("Next() expected error for @reboot, got nil")
+	}
+
+	if _, err := ParseCronWith("@reboot", WithDialect(POSIX)); err == nil {
+		t.Error("ParseCronWith(POSIX) expected error for @reboot, got nil")
+	}
+	if got := New("@reboot").Add(Hours(1)).Error(); !errors.Is(got, ErrNotShiftable) {
+		t.Errorf("CronMath.Add() error = %v, want ErrNotShiftable", got)
+	}
+}
+
+func TestCronTime_AddSteppedRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		d       Duration
+		want    string
+		wantErr bool
+	}{
+		{"keeps step", "10-40/5 9 * * *", Minutes(10), "20-50/5 9 * * *", false},
+		{"subtract", "10-40/5 9 * * *", Minutes(-10), "0-30/5 9 * * *", false},
+		{"whole range carries", "40-55/5 9 * * *", Minutes(20), "0-15/5 10 * * *", false},
+		{"splits under wildcard hour", "10-40/5 * * * *", Minutes(40), "0,5,10,15,20,50,55 * * * *", false},
+		{"splits under fixed hour", "10-40/5 9 * * *", Minutes(40), "", true},
+		{"hour step", "0 9-17/2 * * *", Hours(1), "0 10-18/2 * * *", false},
+		{"hour step with minutes", "30 9-17/2 * * *", Minutes(45), "15 10-18/2 * * *", false},
+		{"hour step past midnight", "0 18-22/2 * * *", Hours(4), "0 0,2,22 * * *", false},
+		{"hour step splits day of month", "0 18-22/2 1 * *", Hours(4), "", true},
+		{"hour step carries day of month", "0 20-22/2 1 * *", Hours(4), "0 0,2 2 * *", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			err = cron.Add(tt.d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("Add() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddListOfRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		d       Duration
+		want    string
+		wantErr string
+	}{
+		{"every segment", "0-10,20-30,45 9 * * *", Minutes(5), "5-15,25-35,50 9 * * *", ""},
+		{"sorted after wrap", "0-10,20-30,45 * * * *", Minutes(20), "5,20-30,40-50 * * * *", ""},
+		{"merges touching segments", "0-4,10-14 * * * *", Minutes(5), "5-9,15-19 * * * *", ""},
+		{"all carry", "50-55,58 9 * * *", Minutes(15), "5-10,13 10 * * *", ""},
+		{"segment carries alone", "0-10,20-30,45 9 * * *", Minutes(20), "", "45 moves into the next hour while 0-10 stays in its hour"},
+		{"segment splits", "0-10,20-30 9 * * *", Minutes(35), "", "20-30 lands in different hours"},
+		{"earlier hour", "5,30-40 9 * * *", Minutes(-10), "", "30-40 stays in its hour while 5 moves into the previous hour"},
+		{"hour segment", "0 1-3,22 1 * *", Hours(3), "", "22 moves into the next day while 1-3 stays in its day"},
+		{"hour list", "30 8,12-14 * * 1-5", Hours(2), "30 10,14-16 * * 1-5", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			err = cron.Add(tt.d)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Add() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			if cron.String() != tt.want {
+				t.Errorf("Add() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_AddNoWrap(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		d       time.Duration
+		want    string
+		wantErr *DayBoundaryError
+	}{
+		{"within the day", "30 22 * * *", Hours(1), "30 23 * * *", nil},
+		{"to the last minute", "30 23 * * *", Minutes(29), "59 23 * * *", nil},
+		{"past midnight", "30 23 * * *", Hours(1), "", &DayBoundaryError{From: 1410, To: 1470, Wrapped: 30}},
+		{"before midnight", "15 0 * * 1-5", -Minutes(30), "", &DayBoundaryError{From: 15, To: -15, Wrapped: 1425}},
+		{"a whole day", "0 9 * * *", Hours(24), "", &DayBoundaryError{From: 540, To: 1980, Wrapped: 540}},
+		{"hour range", "0 20-23 * * *", Hours(1), "", &DayBoundaryError{From: 1380, To: 1440, Wrapped: 0}},
+		{"wildcard minute", "* 23 * * *", Hours(1), "", &DayBoundaryError{From: 1380, To: 1440, Wrapped: 0}},
+		{"minute list", "30,50 23 * * *", Minutes(10), "", &DayBoundaryError{From: 1430, To: 1440, Wrapped: 0}},
+		{"minute list within the day", "0,20 22 * * *", Minutes(10), "10,30 22 * * *", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithNoWrap())
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			err = cron.Add(tt.d)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Add() error = %v", err)
+				}
+				if got := cron.String(); got != tt.want {
+					t.Errorf("Add() = %q, want %q", got, tt.want)
+				}
+				return
+			}
+
+			var be *DayBoundaryError
+			if !errors.As(err, &be) || !errors.Is(err, ErrDayBoundary) {
+				t.Fatalf("Add() error = %v, want a *DayBoundaryError", err)
+			}
+			if *be != *tt.wantErr {
+				t.Errorf("Add() error = %+v, want %+v", *be, *tt.wantErr)
+			}
+			if got := cron.String(); got != tt.cronStr {
+				t.Errorf("Add() changed the expression to %q", got)
+			}
+		})
+	}
+}
+
+func TestCronMath_NoWrap(t *testing.T) {
+	cm := New("30 23 * * *", WithNoWrap()).Add(Hours(1))
+	if KindOf(cm.Error()) != KindDayBoundary {
+		t.Fatalf("Error() = %v, want a day boundary error", cm.Error())
+	}
+	want := "shift crosses midnight: shift from 23:30 to 24:30 would wrap to 00:30"
+	if !strings.Contains(cm.Error().Error(), want) {
+		t.Errorf("Error() = %q, want it to contain %q", cm.Error(), want)
+	}
+}
+
+func TestCronTime_AddExpandSteps(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		d       time.Duration
+		want    string
+	}{
+		{"0 */6 * * *", Minutes(30), "30 0,6,12,18 * * *"},
+		{"0 */6 * * *", Hours(2), "0 2,8,14,20 * * *"},
+		{"0 */6 * * *", -Hours(1), "0 5,11,17,23 * * *"},
+		{"0 */6 * * *", Hours(6), "0 */6 * * *"},
+		{"0 */6 * * *", Hours(24), "0 */6 * * *"},
+		{"50 1-23/6 * * *", Minutes(20), "10 2,8,14,20 * * *"},
+		{"* */4 * * *", Hours(1), "* 1,5,9,13,17,21 * * *"},
+		{"0 9-17 * * *", Minutes(30), "30 9-17 * * *"},
+		{"0,30 */12 * * *", Minutes(15), "15,45 0,12 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithExpandSteps())
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if err := cron.Add(tt.d); err != nil {
+				t.Fatalf("Add(%v) error = %v", tt.d, err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("Add(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+
+	// Without the option the step is kept
+	if got := New("0 */6 * * *").Add(Minutes(30)).String(); got != "30 */6 * * *" {
+		t.Errorf("Add() without WithExpandSteps = %q, want %q", got, "30 */6 * * *")
+	}
+}
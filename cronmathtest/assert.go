@@ -0,0 +1,126 @@
+package cronmathtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryutaro-asada/cronmath"
+)
+
+// comparedRuns is how many firings AssertEquivalent compares to find the
+// first one that differs
+const comparedRuns = 1000
+
+// AssertShift checks that expr shifted with Add by d is written as want,
+// as in AssertShift(t, "5 9 * * *", cronmath.Minutes(-5), "0 9 * * *").
+// Failures show the normalized forms, so a result that fires as wanted
+// but is written differently is told apart from a wrong one.
+func AssertShift(tb testing.TB, expr string, d cronmath.Duration, want string) {
+	tb.Helper()
+	c, ok := parse(tb, expr)
+	if !ok {
+		return
+	}
+	if err := c.Add(d); err != nil {
+		tb.Errorf("shifting %q by %v: %v, want %q", expr, d, err, want)
+		return
+	}
+	if got := c.String(); got != want {
+		tb.Errorf("shifting %q by %v = %q, want %q\n%s", expr, d, got, want, compare(c, want))
+	}
+}
+
+// AssertEquivalent checks that a and b fire at exactly the same times,
+// reporting the first firing they disagree on otherwise
+func AssertEquivalent(tb testing.TB, a, b string) {
+	tb.Helper()
+	ca, ok := parse(tb, a)
+	if !ok {
+		return
+	}
+	cb, ok := parse(tb, b)
+	if !ok {
+		return
+	}
+	same, err := cronmath.Equivalent(ca, cb)
+	switch {
+	case err != nil:
+		tb.Errorf("comparing %q and %q: %v", a, b, err)
+	case !same:
+		tb.Errorf("%q and %q fire at different times\n%s%s", a, b, compare(ca, b), firstDifference(ca, cb))
+	}
+}
+
+// AssertNextRuns checks that the firings of expr after after are want,
+// in order. Times are compared as instants, whatever their location.
+func AssertNextRuns(tb testing.TB, expr string, after time.Time, want []time.Time) {
+	tb.Helper()
+	c, ok := parse(tb, expr)
+	if !ok {
+		return
+	}
+	got, err := c.NextN(after, len(want))
+	if err != nil {
+		tb.Errorf("next runs of %q after %v: %v", expr, after.Format(time.RFC3339), err)
+		return
+	}
+
+	var b strings.Builder
+	mismatch := false
+	for i := range want {
+		mark := " "
+		if !got[i].Equal(want[i]) {
+			mark, mismatch = "!", true
+		}
+		fmt.Fprintf(&b, "  %s %-25s want %s\n", mark, got[i].Format(time.RFC3339), want[i].Format(time.RFC3339))
+	}
+	if mismatch {
+		tb.Errorf("next runs of %q (normalized %q) after %v:\n%s", expr, normalized(c), after.Format(time.RFC3339), b.String())
+	}
+}
+
+// parse parses expr for an assertion, failing tb when it does not parse
+func parse(tb testing.TB, expr string) (*cronmath.CronTime, bool) {
+	tb.Helper()
+	c, err := cronmath.ParseCron(expr)
+	if err != nil {
+		tb.Errorf("parsing %q: %v", expr, err)
+		return nil, false
+	}
+	return c, true
+}
+
+// compare lines up the normalized forms of got and want
+func compare(got *cronmath.CronTime, want string) string {
+	w := want
+	if c, err := cronmath.ParseCron(want); err == nil {
+		w = normalized(c)
+	}
+	return fmt.Sprintf("  normalized: %q\n        want: %q\n", normalized(got), w)
+}
+
+// normalized returns the normalized form of c, leaving c as it is
+func normalized(c *cronmath.CronTime) string {
+	n := *c
+	if err := n.Normalize(); err != nil {
+		return c.String()
+	}
+	return n.String()
+}
+
+// firstDifference describes the first of the next firings from the start
+// of 2000 on which a and b disagree
+func firstDifference(a, b *cronmath.CronTime) string {
+	start := time.Date(1999, time.December, 31, 23, 59, 59, 0, time.UTC)
+	ra, _ := a.NextN(start, comparedRuns)
+	rb, _ := b.NextN(start, comparedRuns)
+	for i := range min(len(ra), len(rb)) {
+		if !ra[i].Equal(rb[i]) {
+			return fmt.Sprintf("  run %d from 2000: %s for %q, %s for %q\n", i+1,
+				ra[i].Format(time.RFC3339), a.String(), rb[i].Format(time.RFC3339), b.String())
+		}
+	}
+	return ""
+}
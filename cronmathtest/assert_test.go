@@ -0,0 +1,68 @@
+package cronmathtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryutaro-asada/cronmath"
+)
+
+// recorder is a testing.TB that records failures instead of reporting
+// them
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	june := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.June, day, hour, minute, 0, 0, time.UTC)
+	}
+	from := june(6, 0, 0)
+
+	tests := []struct {
+		name   string
+		assert func(testing.TB)
+		// want is a substring of the failure, or empty for none
+		want string
+	}{
+		{"shift", func(tb testing.TB) { AssertShift(tb, "5 9 * * *", cronmath.Minutes(-5), "0 9 * * *") }, ""},
+		{"wrong shift", func(tb testing.TB) { AssertShift(tb, "5 9 * * *", cronmath.Minutes(5), "0 9 * * *") },
+			`shifting "5 9 * * *" by 5m0s = "10 9 * * *", want "0 9 * * *"`},
+		{"shift written differently", func(tb testing.TB) { AssertShift(tb, "0 9 * * MON", cronmath.Hours(1), "0 10 * * 1") },
+			`normalized: "0 10 * * MON"`},
+		{"failed shift", func(tb testing.TB) { AssertShift(tb, "* 9 * * *", cronmath.Minutes(5), "5 9 * * *") }, "cannot adjust wildcards"},
+		{"invalid", func(tb testing.TB) { AssertShift(tb, "5 9 * *", cronmath.Minutes(5), "10 9 * * *") }, `parsing "5 9 * *"`},
+		{"equivalent", func(tb testing.TB) { AssertEquivalent(tb, "*/15 * * * *", "0,15,30,45 * * * *") }, ""},
+		{"not equivalent", func(tb testing.TB) { AssertEquivalent(tb, "0 9 * * *", "0 9 * * 1-5") },
+			`run 1 from 2000: 2000-01-01T09:00:00Z for "0 9 * * *", 2000-01-03T09:00:00Z for "0 9 * * 1-5"`},
+		{"next runs", func(tb testing.TB) {
+			AssertNextRuns(tb, "30 9 * * 1-5", from, []time.Time{june(6, 9, 30), june(9, 9, 30)})
+		}, ""},
+		{"wrong next runs", func(tb testing.TB) {
+			AssertNextRuns(tb, "30 9 * * 1-5", from, []time.Time{june(6, 9, 30), june(7, 9, 30)})
+		}, "! 2025-06-09T09:30:00Z      want 2025-06-07T09:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			tt.assert(r)
+			got := strings.Join(r.errors, "\n")
+			switch {
+			case tt.want == "" && got != "":
+				t.Errorf("unexpected failure:\n%s", got)
+			case tt.want != "" && !strings.Contains(got, tt.want):
+				t.Errorf("failure =\n%s\nwant it to contain\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package cronmathtest helps test code that produces or consumes cron
+// expressions. Its assertions parse, shift and compare expressions in a
+// line, taking a testing.TB so they serve benchmarks and fuzz targets too:
+//
+//	cronmathtest.AssertShift(t, "5 9 * * *", cronmath.Minutes(-5), "0 9 * * *")
+//
+// Its generators write random valid expressions, deterministically given
+// the *rand.Rand, so a failing case is reproduced from its seed:
+//
+//	r := rand.New(rand.NewSource(seed))
+//	c := cronmathtest.Random(r, cronmathtest.WithFeatures(cronmathtest.Lists|cronmathtest.Steps))
+package cronmathtest
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/ryutaro-asada/cronmath"
+)
+
+// Feature is a kind of syntax a generated field may use. Features combine
+// with |.
+type Feature int
+
+const (
+	// Wildcards writes a field as "*"
+	Wildcards Feature = 1 << iota
+	// Values writes a field as a single value, such as "5"
+	Values
+	// Lists writes a field as a list of values, such as "1,15,30"
+	Lists
+	// Ranges writes a field as a range, such as "9-17"
+	Ranges
+	// Steps writes a field with a step, such as "*/15" or "0-30/10"
+	Steps
+	// Names writes months and days of the week by name, such as "MON",
+	// wherever a value is written
+	Names
+
+	// AllFeatures is every feature, the default
+	AllFeatures = Wildcards | Values | Lists | Ranges | Steps | Names
+)
+
+// forms are the features that decide how a field is written, as opposed
+// to Names, which only changes how its values are spelt
+const forms = Wildcards | Values | Lists | Ranges | Steps
+
+// GenOption configures a generator
+type GenOption func(*genConfig)
+
+type genConfig struct {
+	features Feature
+}
+
+// WithFeatures limits generated fields to the given features, so
+// WithFeatures(Wildcards) only yields "* * * * *" and WithFeatures(Values)
+// only fixed values. Names alone writes single named values.
+func WithFeatures(f Feature) GenOption {
+	return func(cfg *genConfig) {
+		cfg.features = f
+	}
+}
+
+func newGenConfig(opts []GenOption) genConfig {
+	cfg := genConfig{features: AllFeatures}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.features&forms == 0 {
+		cfg.features |= Values
+	}
+	return cfg
+}
+
+// field describes the values of a standard field and their names
+type field struct {
+	min, max int
+	names    []string
+}
+
+var (
+	minute     = field{min: 0, max: 59}
+	hour       = field{min: 0, max: 23}
+	dayOfMonth = field{min: 1, max: 31}
+	month      = field{min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	dayOfWeek  = field{min: 0, max: 6, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// Random returns a random five-field expression using the syntax allowed
+// by opts. It always parses with cronmath.WithStrict and fires on some
+// date.
+func Random(r *rand.Rand, opts ...GenOption) *cronmath.CronTime {
+	cfg := newGenConfig(opts)
+	for {
+		expr := strings.Join([]string{
+			cfg.field(r, minute),
+			cfg.field(r, hour),
+			cfg.field(r, dayOfMonth),
+			cfg.field(r, month),
+			cfg.field(r, dayOfWeek),
+		}, " ")
+		if c := mustParse(expr); !neverFires(c) {
+			return c
+		}
+	}
+}
+
+// RandomFixedTime returns a random expression firing at a single fixed
+// time of day, on any day of the month. Such expressions can be shifted
+// by any duration with Add and Sub, for property tests of the arithmetic.
+// The month and day of week use the syntax allowed by opts.
+func RandomFixedTime(r *rand.Rand, opts ...GenOption) *cronmath.CronTime {
+	cfg := newGenConfig(opts)
+	expr := fmt.Sprintf("%d %d * %s %s", r.Intn(60), r.Intn(24), cfg.field(r, month), cfg.field(r, dayOfWeek))
+	return mustParse(expr)
+}
+
+// mustParse parses a generated expression, which is a bug in the
+// generator when it fails
+func mustParse(expr string) *cronmath.CronTime {
+	c, err := cronmath.ParseCronWith(expr, cronmath.WithStrict())
+	if err != nil {
+		panic(fmt.Sprintf("cronmathtest: generated invalid expression %q: %v", expr, err))
+	}
+	return c
+}
+
+func neverFires(c *cronmath.CronTime) bool {
+	never, _ := c.NeverFires()
+	return never
+}
+
+// field writes a random field of f using one of the enabled forms
+func (cfg genConfig) field(r *rand.Rand, f field) string {
+	var enabled []Feature
+	for _, form := range []Feature{Wildcards, Values, Lists, Ranges, Steps} {
+		if cfg.features&form != 0 {
+			enabled = append(enabled, form)
+		}
+	}
+
+	switch enabled[r.Intn(len(enabled))] {
+	case Wildcards:
+		return "*"
+	case Lists:
+		n := 2 + r.Intn(3)
+		values := r.Perm(f.max - f.min + 1)[:n]
+		parts := make([]string, n)
+		for i, v := range values {
+			parts[i] = cfg.value(r, f, f.min+v)
+		}
+		return strings.Join(parts, ",")
+	case Ranges:
+		lo, hi := cfg.bounds(r, f)
+		return cfg.value(r, f, lo) + "-" + cfg.value(r, f, hi)
+	case Steps:
+		if r.Intn(2) == 0 {
+			return "*/" + strconv.Itoa(2+r.Intn((f.max-f.min+1)/2-1))
+		}
+		lo, hi := cfg.bounds(r, f)
+		return strconv.Itoa(lo) + "-" + strconv.Itoa(hi) + "/" + strconv.Itoa(1+r.Intn(hi-lo))
+	}
+	return cfg.value(r, f, f.min+r.Intn(f.max-f.min+1))
+}
+
+// bounds returns the ends of a random range of f with at least two values
+func (cfg genConfig) bounds(r *rand.Rand, f field) (lo, hi int) {
+	lo = f.min + r.Intn(f.max-f.min)
+	hi = lo + 1 + r.Intn(f.max-lo)
+	return lo, hi
+}
+
+// value writes v, by name when Names is enabled and f has names
+func (cfg genConfig) value(r *rand.Rand, f field, v int) string {
+	if cfg.features&Names != 0 && f.names != nil && r.Intn(2) == 0 {
+		return f.names[v-f.min]
+	}
+	return strconv.Itoa(v)
+}
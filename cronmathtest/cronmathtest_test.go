@@ -0,0 +1,82 @@
+package cronmathtest
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryutaro-asada/cronmath"
+)
+
+func TestRandom(t *testing.T) {
+	tests := []struct {
+		name     string
+		features Feature
+		check    func(field string) bool
+	}{
+		{"all features", AllFeatures, func(string) bool { return true }},
+		{"wildcards only", Wildcards, func(f string) bool { return f == "*" }},
+		{"values only", Values, func(f string) bool { return !strings.ContainsAny(f, "*,-/") }},
+		{"lists", Lists, func(f string) bool { return strings.Contains(f, ",") }},
+		{"ranges", Ranges, func(f string) bool { return strings.Contains(f, "-") && !strings.Contains(f, "/") }},
+		{"steps", Steps, func(f string) bool { return strings.Contains(f, "/") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			for range 1000 {
+				c := Random(r, WithFeatures(tt.features))
+				expr := c.String()
+				if _, err := cronmath.ParseCronWith(expr, cronmath.WithStrict()); err != nil {
+					t.Fatalf("Random() = %q, which does not parse strictly: %v", expr, err)
+				}
+				if never, reason := c.NeverFires(); never {
+					t.Fatalf("Random() = %q, which never fires: %s", expr, reason)
+				}
+				for _, f := range strings.Fields(expr) {
+					if !tt.check(f) {
+						t.Fatalf("Random() = %q, with field %q outside the features", expr, f)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRandomNames(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	named := false
+	for range 100 {
+		fields := strings.Fields(Random(r, WithFeatures(Names)).String())
+		named = named || strings.ContainsAny(fields[3]+fields[4], "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	}
+	if !named {
+		t.Error("Random() with Names wrote no month or weekday by name")
+	}
+}
+
+func TestRandomDeterministic(t *testing.T) {
+	a, b := rand.New(rand.NewSource(42)), rand.New(rand.NewSource(42))
+	for range 100 {
+		if x, y := Random(a).String(), Random(b).String(); x != y {
+			t.Fatalf("Random() with the same seed gave %q and %q", x, y)
+		}
+	}
+}
+
+func TestRandomFixedTime(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		c := RandomFixedTime(r)
+		expr := c.String()
+		if _, err := c.MinuteOfDay(); err != nil {
+			t.Fatalf("RandomFixedTime() = %q, without a fixed time: %v", expr, err)
+		}
+		d := time.Duration(r.Int63n(int64(30*24*time.Hour))) - 15*24*time.Hour
+		if err := c.Add(d.Truncate(time.Minute)); err != nil {
+			t.Fatalf("RandomFixedTime() = %q, which cannot be shifted by %v: %v", expr, d, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package cronmath
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CronSet is a group of named expressions that are shifted as a unit,
+// such as the stages of a pipeline. Members keep the order they were
+// added in.
+type CronSet struct {
+	opts  []Option
+	names []string
+	crons map[string]*CronTime
+}
+
+// NewCronSet returns an empty set whose expressions are parsed with opts
+func NewCronSet(opts ...Option) *CronSet {
+	return &CronSet{opts: opts, crons: make(map[string]*CronTime)}
+}
+
+// Add parses expr and adds it to the set as name. Names must be unique,
+// and the expression must expand to a valid schedule or be "@reboot".
+func (s *CronSet) Add(name, expr string) error {
+	if _, ok := s.crons[name]; ok {
+		return fmt.Errorf("duplicate name %q", name)
+	}
+	c, err := ParseCronWith(expr, s.opts...)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	if _, err := c.schedule(); err != nil && !c.IsReboot() {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+
+	s.names = append(s.names, name)
+	s.crons[name] = c
+	return nil
+}
+
+// Get returns the member called name
+func (s *CronSet) Get(name string) (*CronTime, bool) {
+	c, ok := s.crons[name]
+	return c, ok
+}
+
+// Names returns the names of the members in the order they were added
+func (s *CronSet) Names() []string {
+	return append([]string(nil), s.names...)
+}
+
+// Len returns the number of members
+func (s *CronSet) Len() int {
+	return len(s.names)
+}
+
+// ShiftAll adds d to every member. Members that cannot be shifted are
+// left as they were and reported in a *SetError, while the others keep
+// their shift. "@reboot" members have no time and are passed over.
+func (s *CronSet) ShiftAll(d Duration) error {
+	var errs []*EntryError
+	for _, name := range s.names {
+		if s.crons[name].IsReboot() {
+			continue
+		}
+		shifted := *s.crons[name]
+		if err := shifted.Add(d); err != nil {
+			errs = append(errs, &EntryError{Name: name, Err: err})
+			continue
+		}
+		*s.crons[name] = shifted
+	}
+	if errs != nil {
+		return &SetError{Errors: errs}
+	}
+	return nil
+}
+
+// Strings returns the expressions of the members by name
+func (s *CronSet) Strings() map[string]string {
+	out := make(map[string]string, len(s.names))
+	for name, c := range s.crons {
+		out[name] = c.String()
+	}
+	return out
+}
+
+// Conflict is a pair of members firing in the same minute
+type Conflict struct {
+	// A and B name the members, A having been added first
+	A, B string
+	// At is the first minute both fire in
+	At time.Time
+}
+
+// Conflicts returns the pairs of members that fire in the same minute,
+// from now on as told by the set's WithClock option. See ConflictsFrom.
+func (s *CronSet) Conflicts() []Conflict {
+	return s.ConflictsFrom(newConfig(s.opts).now())
+}
+
+// ConflictsFrom returns the pairs of members that fire in the same minute
+// at or after start, in the order the members were added. "@reboot"
+// members conflict with nothing.
+func (s *CronSet) ConflictsFrom(start time.Time) []Conflict {
+	var conflicts []Conflict
+	for i, a := range s.names {
+		for _, b := range s.names[i+1:] {
+			// Members are validated by Add, so this only fails for @reboot
+			if ok, at, _ := ConflictsFrom(s.crons[a], s.crons[b], start); ok {
+				conflicts = append(conflicts, Conflict{A: a, B: b, At: at})
+			}
+		}
+	}
+	return conflicts
+}
+
+// EntryError is the error of one member of a CronSet
+type EntryError struct {
+	Name string
+	Err  error
+}
+
+func (e *EntryError) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+// SetError collects the errors of the members of a CronSet an operation
+// failed for
+type SetError struct {
+	Errors []*EntryError
+}
+
+func (e *SetError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the members, for errors.Is and errors.As
+func (e *SetError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
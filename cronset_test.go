@@ -0,0 +1,104 @@
+package cronmath
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCronSet_ShiftAll(t *testing.T) {
+	set := NewCronSet()
+	for _, m := range []struct{ name, expr string }{
+		{"extract", "0 1 * * *"},
+		{"transform", "30 1 * * *"},
+		{"report", "*/15 * * * *"},
+		{"close", "0 23 28 * *"},
+		{"load", "0 23 14 * *"},
+	} {
+		if err := set.Add(m.name, m.expr); err != nil {
+			t.Fatalf("Add(%q) error = %v", m.name, err)
+		}
+	}
+
+	err := set.ShiftAll(Hours(2))
+	var setErr *SetError
+	if !errors.As(err, &setErr) {
+		t.Fatalf("ShiftAll() error = %v, want *SetError", err)
+	}
+	if len(setErr.Errors) != 1 || setErr.Errors[0].Name != "close" {
+		t.Errorf("ShiftAll() errors = %v, want one for close", err)
+	}
+
+	want := map[string]string{
+		"extract":   "0 3 * * *",
+		"transform": "30 3 * * *",
+		"report":    "*/15 * * * *",
+		"close":     "0 23 28 * *",
+		"load":      "0 1 15 * *",
+	}
+	if got := set.Strings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Strings() = %v, want %v", got, want)
+	}
+	if got, want := set.Names(), []string{"extract", "transform", "report", "close", "load"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
+
+func TestCronSet_Add(t *testing.T) {
+	set := NewCronSet(WithAutoFields())
+	if err := set.Add("a", "0 0 9 * * *"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := set.Add("a", "0 9 * * *"); err == nil {
+		t.Error("Add() expected error for a duplicate name, got nil")
+	}
+	if err := set.Add("b", "0 9 * * 9"); err == nil {
+		t.Error("Add() expected error for an out-of-range day of week, got nil")
+	}
+	if set.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", set.Len())
+	}
+	if c, ok := set.Get("a"); !ok || c.String() != "0 0 9 * * *" {
+		t.Errorf("Get() = %v, %v", c, ok)
+	}
+}
+
+func TestCronSet_ConflictsFrom(t *testing.T) {
+	set := NewCronSet()
+	set.Add("backup", "0 2 * * *")
+	set.Add("vacuum", "0 3 * * *")
+	set.Add("rotate", "0 */2 * * *")
+	set.Add("weekly", "0 3 * * SUN")
+
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC) // a Monday
+	want := []Conflict{
+		{A: "backup", B: "rotate", At: time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC)},
+		{A: "vacuum", B: "weekly", At: time.Date(2025, 6, 8, 3, 0, 0, 0, time.UTC)},
+	}
+	if got := set.ConflictsFrom(start); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConflictsFrom() = %v, want %v", got, want)
+	}
+}
+
+func TestCronSet_Reboot(t *testing.T) {
+	set := NewCronSet()
+	for name, expr := range map[string]string{"boot": "@reboot", "backup": "30 2 * * *", "report": "30 2 1 * *"} {
+		if err := set.Add(name, expr); err != nil {
+			t.Fatalf("Add(%q) error = %v", expr, err)
+		}
+	}
+
+	if err := set.ShiftAll(Hours(1)); err != nil {
+		t.Fatalf("ShiftAll() error = %v", err)
+	}
+	want := map[string]string{"boot": "@reboot", "backup": "30 3 * * *", "report": "30 3 1 * *"}
+	if got := set.Strings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Strings() = %v, want %v", got, want)
+	}
+
+	got := set.ConflictsFrom(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(got) != 1 || got[0].A == "boot" || got[0].B == "boot" {
+		t.Errorf("ConflictsFrom() = %v, want backup and report only", got)
+	}
+}
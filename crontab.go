@@ -0,0 +1,397 @@
+package cronmath
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// MigrationReport describes the entries of a crontab rewritten by
+// MigrateCrontabTZ. Lines are numbered from 1.
+type MigrationReport struct {
+	// Offset is the shift applied to every schedule
+	Offset time.Duration
+	// Approximate is set when either location observes daylight saving
+	// time, so that Offset holds for part of the year only
+	Approximate bool
+	// Shifted lists every entry written with a new schedule, and
+	// DaysChanged those of them whose day fields moved across midnight
+	Shifted     []CrontabChange
+	DaysChanged []CrontabChange
+	// Failed lists the entries left as they were because they could not
+	// be converted
+	Failed []CrontabFailure
+}
+
+// CrontabChange is an entry of a crontab given a new schedule
+type CrontabChange struct {
+	Line          int
+	Before, After string
+}
+
+// CrontabFailure is an entry of a crontab that could not be converted
+type CrontabFailure struct {
+	Line  int
+	Entry string
+	Err   error
+}
+
+// MigrateCrontabTZ rewrites the crontab read from r for a host whose time
+// zone changes from from to to, writing it to w. Each schedule is shifted
+// by the difference between the two offsets, moving its day fields where
+// a job crosses midnight, so "0 3 * * 1" in Asia/Tokyo becomes "0 18 * * 0"
+// in UTC. Comments, blank lines, environment settings and commands are
+// copied byte for byte, and entries after a CRON_TZ or TZ setting keep
+// their schedule, as they do not run in the host's time zone.
+//
+// Entries are parsed with opts, and offsets are taken at the time of the
+// call, as told by the clock given with WithClock. Entries that do not
+// parse or cannot be shifted are copied unchanged and listed in the
+// report, which also lists every change; the error is for reading and
+// writing only.
+func MigrateCrontabTZ(r io.Reader, w io.Writer, from, to *time.Location, opts ...Option) (MigrationReport, error) {
+	now := newConfig(opts).now()
+	report := MigrationReport{
+		Offset:      offsetAt(now, to) - offsetAt(now, from),
+		Approximate: observesDST(from, now) || observesDST(to, now),
+	}
+
+	br, bw := bufio.NewReader(r), bufio.NewWriter(w)
+	ownZone := false
+	for n := 1; ; n++ {
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return report, err
+		}
+		if line == "" {
+			break
+		}
+
+		out := line
+		schedule, rest, ok := splitCrontabLine(line)
+		switch {
+		case !ok:
+			if name, value, ok := envSetting(line); ok && (name == "CRON_TZ" || name == "TZ") {
+				ownZone = value != ""
+			}
+		case ownZone:
+		default:
+			before, after, err := migrateSchedule(schedule, report.Offset, opts)
+			if err != nil {
+				report.Failed = append(report.Failed, CrontabFailure{Line: n, Entry: strings.TrimRight(line, "\r\n"), Err: err})
+				break
+			}
+			if after.String() == before.String() {
+				break
+			}
+			change := CrontabChange{Line: n, Before: schedule, After: after.String()}
+			report.Shifted = append(report.Shifted, change)
+			if after.DayOfMonth != before.DayOfMonth || after.DayOfWeek != before.DayOfWeek {
+				report.DaysChanged = append(report.DaysChanged, change)
+			}
+			out = strings.TrimSuffix(line, schedule+rest) + change.After + rest
+		}
+
+		if _, err := bw.WriteString(out); err != nil {
+			return report, err
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	return report, bw.Flush()
+}
+
+// migrateSchedule parses schedule with opts and returns it before and
+// after shifting it by d. "@reboot" is left alone.
+func migrateSchedule(schedule string, d time.Duration, opts []Option) (before, after *CronTime, err error) {
+	before, err = ParseCronWith(schedule, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	shifted := *before
+	if !before.IsReboot() {
+		if err := shifted.Add(d); err != nil {
+			return nil, nil, err
+		}
+	}
+	return before, &shifted, nil
+}
+
+// splitCrontabLine splits an entry of a crontab into its schedule, a
+// macro or five fields, and the rest of the line, the command with the
+// whitespace before it. It reports false for comments, blank lines and
+// environment settings.
+func splitCrontabLine(line string) (schedule, rest string, ok bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if strings.TrimSpace(trimmed) == "" || trimmed[0] == '#' {
+		return "", "", false
+	}
+	if _, _, ok := envSetting(line); ok {
+		return "", "", false
+	}
+
+	fields := 5
+	if trimmed[0] == '@' {
+		fields = 1
+	}
+	start := len(line) - len(trimmed)
+	end := start
+	for i := 0; i < fields; i++ {
+		end += len(line[end:]) - len(strings.TrimLeft(line[end:], " \t"))
+		next := strings.IndexAny(line[end:], " \t\r\n")
+		if next < 0 {
+			next = len(line) - end
+		}
+		end += next
+	}
+	return line[start:end], line[end:], true
+}
+
+// envSetting splits an environment setting such as "MAILTO=ops", which
+// is a name and "=" before any blank
+func envSetting(line string) (name, value string, ok bool) {
+	name, value, ok = strings.Cut(strings.TrimSpace(line), "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", false
+	}
+	return name, strings.TrimSpace(value), true
+}
+
+// offsetAt returns the offset of loc from UTC at t
+func offsetAt(t time.Time, loc *time.Location) time.Duration {
+	_, offset := t.In(loc).Zone()
+	return time.Duration(offset) * time.Second
+}
+
+// observesDST reports whether the offset of loc changes in the year
+// starting at t
+func observesDST(loc *time.Location, t time.Time) bool {
+	first := offsetAt(t, loc)
+	for month := 1; month < 12; month++ {
+		if offsetAt(t.AddDate(0, month, 0), loc) != first {
+			return true
+		}
+	}
+	return false
+}
+
+// CrontabEntry is an entry of a crontab: a schedule and the command it
+// runs, which may be empty
+type CrontabEntry struct {
+	Cron    *CronTime
+	Command string
+}
+
+// FormatAligned renders entries as the lines of a crontab with their
+// fields padded into columns, so that
+//
+//	0 9 * * 1-5 backup
+//	*/15 * * * * poll
+//	@reboot start
+//
+// is written as
+//
+//	0    9 * * 1-5 backup
+//	*/15 * * * *   poll
+//	@reboot        start
+//
+// Macros take up the width of the whole schedule and commands all start
+// in the same column. Only the blanks between fields change, so every
+// schedule parses back as it was.
+func FormatAligned(entries []CrontabEntry) string {
+	fields := make([][]string, len(entries))
+	var widths []int
+	for i, e := range entries {
+		fields[i] = strings.Fields(e.Cron.String())
+		if len(fields[i]) == 1 {
+			continue
+		}
+		for j, f := range fields[i] {
+			if j == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], len(f))
+		}
+	}
+
+	total := 0
+	for _, w := range widths {
+		total += w + 1
+	}
+	total = max(total-1, 0)
+	for _, f := range fields {
+		if len(f) == 1 {
+			total = max(total, len(f[0]))
+		}
+	}
+
+	var b strings.Builder
+	for i, e := range entries {
+		var line strings.Builder
+		if len(fields[i]) == 1 {
+			line.WriteString(fields[i][0])
+		} else {
+			for j, f := range fields[i] {
+				if j > 0 {
+					line.WriteByte(' ')
+				}
+				line.WriteString(f)
+				line.WriteString(strings.Repeat(" ", widths[j]-len(f)))
+			}
+		}
+		if e.Command == "" {
+			b.WriteString(strings.TrimRight(line.String(), " "))
+		} else {
+			b.WriteString(line.String())
+			b.WriteString(strings.Repeat(" ", total-line.Len()))
+			b.WriteString(" " + e.Command)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Crontab is a crontab read by ParseCrontab or LoadUserCrontab. Its
+// entries can be shifted in place, and it is written back with every
+// other line as it was read.
+type Crontab struct {
+	lines []crontabLine
+	cfg   config
+}
+
+// crontabLine is a line of a crontab. Entries are split around their
+// schedule, parsed into cron; other lines, and entries that did not
+// parse, are kept as text with err set for the latter.
+type crontabLine struct {
+	text         string
+	prefix, rest string
+	cron         *CronTime
+	err          error
+}
+
+// ParseCrontab reads a crontab from r, parsing the schedule of each entry
+// with opts. Entries that do not parse are kept as they were and listed
+// by Failed; the error is for reading only.
+func ParseCrontab(r io.Reader, opts ...Option) (*Crontab, error) {
+	tab := &Crontab{cfg: newConfig(opts)}
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if line == "" {
+			break
+		}
+
+		l := crontabLine{text: line}
+		if schedule, rest, ok := splitCrontabLine(line); ok {
+			if c, perr := ParseCronWith(schedule, opts...); perr != nil {
+				l.err = perr
+			} else {
+				l.prefix = strings.TrimSuffix(line, schedule+rest)
+				l.rest, l.cron = rest, c
+			}
+		}
+		tab.lines = append(tab.lines, l)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	return tab, nil
+}
+
+// Entries returns the entries of the crontab whose schedule parsed, with
+// the command and anything before it, such as the user of a system
+// crontab. The CronTimes are those of the crontab, so that shifting one
+// changes the entry.
+func (t *Crontab) Entries() []CrontabEntry {
+	var entries []CrontabEntry
+	for _, l := range t.lines {
+		if l.cron != nil {
+			entries = append(entries, CrontabEntry{Cron: l.cron, Command: strings.TrimSpace(l.rest)})
+		}
+	}
+	return entries
+}
+
+// Failed returns the entries whose schedule did not parse
+func (t *Crontab) Failed() []CrontabFailure {
+	var failed []CrontabFailure
+	for i, l := range t.lines {
+		if l.err != nil {
+			failed = append(failed, CrontabFailure{Line: i + 1, Entry: strings.TrimRight(l.text, "\r\n"), Err: l.err})
+		}
+	}
+	return failed
+}
+
+// ShiftAll adds d to every entry. Entries that cannot be shifted are
+// left as they were and reported in a *SetError naming their line, while
+// the others keep their shift. "@reboot" entries are passed over.
+func (t *Crontab) ShiftAll(d Duration) error {
+	var errs []*EntryError
+	for i, l := range t.lines {
+		if l.cron == nil || l.cron.IsReboot() {
+			continue
+		}
+		shifted := *l.cron
+		if err := shifted.Add(d); err != nil {
+			errs = append(errs, &EntryError{Name: fmt.Sprintf("line %d", i+1), Err: err})
+			continue
+		}
+		*l.cron = shifted
+	}
+	if errs != nil {
+		return &SetError{Errors: errs}
+	}
+	return nil
+}
+
+// String renders the crontab, writing each entry with its current
+// schedule
+func (t *Crontab) String() string {
+	var b strings.Builder
+	for _, l := range t.lines {
+		if l.cron == nil {
+			b.WriteString(l.text)
+			continue
+		}
+		b.WriteString(l.prefix)
+		b.WriteString(l.cron.String())
+		b.WriteString(l.rest)
+	}
+	return b.String()
+}
+
+// check reports the entries that String would not write as a crontab
+// line reading back the same schedule, such as those given a seconds
+// field, in a *SetError
+func (t *Crontab) check() error {
+	var errs []*EntryError
+	for i, l := range t.lines {
+		if l.cron == nil {
+			continue
+		}
+		s := l.cron.String()
+		err := fmt.Errorf("schedule %q is not a five-field crontab entry", s)
+		if schedule, _, ok := splitCrontabLine(s + l.rest); ok && schedule == s {
+			var back *CronTime
+			if back, err = ParseCron(s); err == nil && back.String() != s {
+				err = fmt.Errorf("schedule %q reads back as %q", s, back.String())
+			}
+		}
+		if err != nil {
+			errs = append(errs, &EntryError{Name: fmt.Sprintf("line %d", i+1), Err: err})
+		}
+	}
+	if errs != nil {
+		return &SetError{Errors: errs}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package cronmath
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrateCrontabTZ(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	in := strings.Join([]string{
+		"# m h dom mon dow command",
+		"MAILTO=ops@example.com",
+		"",
+		"30 12 * * *   /usr/local/bin/report --daily",
+		"0 3 * * 1-5\t/usr/bin/backup # nightly",
+		"@daily /usr/bin/rotate",
+		"@reboot /usr/bin/start",
+		"  0 10 1 * * root /usr/bin/invoice",
+		"0 3 1 * 1 /usr/bin/both-days",
+		"0 8-10 * * 1 /usr/bin/poll",
+		"61 9 * * * /usr/bin/typo",
+		"CRON_TZ=Asia/Tokyo",
+		"0 9 * * * /usr/bin/tokyo",
+	}, "\n") + "\n"
+
+	var out strings.Builder
+	report, err := MigrateCrontabTZ(strings.NewReader(in), &out, jst, time.UTC)
+	if err != nil {
+		t.Fatalf("MigrateCrontabTZ() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"# m h dom mon dow command",
+		"MAILTO=ops@example.com",
+		"",
+		"30 3 * * *   /usr/local/bin/report --daily",
+		"0 18 * * 0-4\t/usr/bin/backup # nightly",
+		"0 15 * * * /usr/bin/rotate",
+		"@reboot /usr/bin/start",
+		"  0 1 1 * * root /usr/bin/invoice",
+		"0 3 1 * 1 /usr/bin/both-days",
+		"0 8-10 * * 1 /usr/bin/poll",
+		"61 9 * * * /usr/bin/typo",
+		"CRON_TZ=Asia/Tokyo",
+		"0 9 * * * /usr/bin/tokyo",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Errorf("MigrateCrontabTZ() wrote\n%s\nwant\n%s", out.String(), want)
+	}
+
+	if report.Offset != -9*time.Hour || report.Approximate {
+		t.Errorf("Offset, Approximate = %v, %v, want -9h, false", report.Offset, report.Approximate)
+	}
+	lines := func(changes []CrontabChange) []int {
+		var n []int
+		for _, c := range changes {
+			n = append(n, c.Line)
+		}
+		return n
+	}
+	if got := lines(report.Shifted); !slices.Equal(got, []int{4, 5, 6, 8}) {
+		t.Errorf("Shifted lines = %v, want [4 5 6 8]", got)
+	}
+	if got := report.DaysChanged; len(got) != 1 || got[0] != (CrontabChange{Line: 5, Before: "0 3 * * 1-5", After: "0 18 * * 0-4"}) {
+		t.Errorf("DaysChanged = %+v, want line 5 only", got)
+	}
+
+	var failed []int
+	for _, f := range report.Failed {
+		failed = append(failed, f.Line)
+	}
+	if !slices.Equal(failed, []int{9, 10, 11}) {
+		t.Errorf("Failed lines = %v, want [9 10 11]", failed)
+	}
+	if len(report.Failed) == 3 {
+		if f := report.Failed[1]; f.Entry != "0 8-10 * * 1 /usr/bin/poll" || !strings.Contains(f.Err.Error(), "cannot shift hour 8-10") {
+			t.Errorf("Failed[1] = %+v, want the hours split across midnight", f)
+		}
+		if f := report.Failed[2]; !errors.Is(f.Err, ErrInvalidExpression) && KindOf(f.Err) != KindParse {
+			t.Errorf("Failed[2].Err = %v, want a parse error", f.Err)
+		}
+	}
+}
+
+func TestMigrateCrontabTZ_LineEndings(t *testing.T) {
+	in := "0 9 * * * a\r\n0 10 * * * b"
+	var out strings.Builder
+	if _, err := MigrateCrontabTZ(strings.NewReader(in), &out, time.UTC, time.FixedZone("", 60*60)); err != nil {
+		t.Fatalf("MigrateCrontabTZ() error = %v", err)
+	}
+	if want := "0 10 * * * a\r\n0 11 * * * b"; out.String() != want {
+		t.Errorf("MigrateCrontabTZ() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestMigrateCrontabTZ_DST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	tests := []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{"winter", time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC), "0 14 * * * a\n"},
+		{"summer", time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC), "0 13 * * * a\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			report, err := MigrateCrontabTZ(strings.NewReader("0 9 * * * a\n"), &out, ny, time.UTC, WithClock(FixedClock(tt.now)))
+			if err != nil {
+				t.Fatalf("MigrateCrontabTZ() error = %v", err)
+			}
+			if !report.Approximate {
+				t.Error("Approximate = false, want true for a zone with daylight saving time")
+			}
+			if out.String() != tt.want {
+				t.Errorf("MigrateCrontabTZ() = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAligned(t *testing.T) {
+	var entries []CrontabEntry
+	for _, line := range []string{"0 9 * * 1-5", "*/15 * * * *", "@reboot", "30 23 L * *"} {
+		c, err := ParseCron(line)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error = %v", line, err)
+		}
+		entries = append(entries, CrontabEntry{Cron: c, Command: "job " + line})
+	}
+	entries[3].Command = ""
+
+	got := FormatAligned(entries)
+	want := "0    9  * * 1-5 job 0 9 * * 1-5\n" +
+		"*/15 *  * * *   job */15 * * * *\n" +
+		"@reboot         job @reboot\n" +
+		"30   23 L * *\n"
+	if got != want {
+		t.Fatalf("FormatAligned() =\n%s\nwant\n%s", got, want)
+	}
+
+	for i, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		schedule, rest, ok := splitCrontabLine(line)
+		if !ok {
+			t.Fatalf("line %d %q is not an entry", i+1, line)
+		}
+		c, err := ParseCron(schedule)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error = %v", schedule, err)
+		}
+		if c.String() != entries[i].Cron.String() || strings.TrimSpace(rest) != entries[i].Command {
+			t.Errorf("line %d = %q, %q, want %q, %q", i+1, c, strings.TrimSpace(rest), entries[i].Cron, entries[i].Command)
+		}
+	}
+
+	if got := FormatAligned(nil); got != "" {
+		t.Errorf("FormatAligned(nil) = %q, want empty", got)
+	}
+}
+
+func TestParseCrontab(t *testing.T) {
+	in := "# jobs\n" +
+		"SHELL=/bin/sh\n" +
+		"  30 23 * * 1-5  root /usr/bin/backup\n" +
+		"@reboot /usr/bin/start\n" +
+		"0 25 * * 1 /usr/bin/typo\n" +
+		"0 8 * * * /usr/bin/report"
+
+	tab, err := ParseCrontab(strings.NewReader(in), WithStrict())
+	if err != nil {
+		t.Fatalf("ParseCrontab() error = %v", err)
+	}
+	if got := tab.String(); got != in {
+		t.Errorf("String() =\n%s\nwant it as read", got)
+	}
+
+	var commands []string
+	for _, e := range tab.Entries() {
+		commands = append(commands, e.Command)
+	}
+	if want := []string{"root /usr/bin/backup", "/usr/bin/start", "/usr/bin/report"}; !slices.Equal(commands, want) {
+		t.Errorf("Entries() commands = %q, want %q", commands, want)
+	}
+	if failed := tab.Failed(); len(failed) != 1 || failed[0].Line != 5 || failed[0].Entry != "0 25 * * 1 /usr/bin/typo" {
+		t.Errorf("Failed() = %+v, want line 5", failed)
+	}
+
+	if err := tab.ShiftAll(Hours(1)); err != nil {
+		t.Fatalf("ShiftAll() error = %v", err)
+	}
+	want := "# jobs\n" +
+		"SHELL=/bin/sh\n" +
+		"  30 0 * * 2-6  root /usr/bin/backup\n" +
+		"@reboot /usr/bin/start\n" +
+		"0 25 * * 1 /usr/bin/typo\n" +
+		"0 9 * * * /usr/bin/report"
+	if got := tab.String(); got != want {
+		t.Errorf("String() after ShiftAll =\n%s\nwant\n%s", got, want)
+	}
+
+	var setErr *SetError
+	tab, _ = ParseCrontab(strings.NewReader("* 9 * * * /usr/bin/poll\n0 9 * * * /usr/bin/report\n"))
+	err = tab.ShiftAll(Minutes(5))
+	if !errors.As(err, &setErr) || len(setErr.Errors) != 1 || setErr.Errors[0].Name != "line 1" || !errors.Is(err, ErrWildcardMinute) {
+		t.Errorf("ShiftAll() error = %v, want line 1 failing", err)
+	}
+	if got := tab.String(); got != "* 9 * * * /usr/bin/poll\n5 9 * * * /usr/bin/report\n" {
+		t.Errorf("String() after a failed ShiftAll = %q", got)
+	}
+}
@@ -0,0 +1,335 @@
+package cronmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayShifter shifts day, or "L-day" when fromEnd is set, by n days
+type dayShifter func(day int, fromEnd bool, n int) (shiftedDay, error)
+
+// dayShifter returns how days of the month are shifted.
+//
+// Without an anchor month, days that fall off the start of a month are
+// written from the end of the previous one, so day 1 moved back a day
+// becomes "L", and "L" moved forward a day becomes 1. Under a dialect
+// without "L", such as POSIX, days landing on the end of a month are
+// refused instead. Shifts that would depend on the length of a month,
+// such as moving day 28 forward, are refused. With an anchor month the
+// calendar of that month decides, and the result is written with
+// concrete days.
+func (c *CronTime) dayShifter() dayShifter {
+	if c.cfg.wallLocation != nil {
+		return anchoredShift(c.cfg.wallTime)
+	}
+	if !c.cfg.anchor.IsZero() {
+		return anchoredShift(c.cfg.anchor)
+	}
+	if d := c.cfg.dialect; d != nil && !d.LastDay {
+		return withoutLastDay(*d)
+	}
+	return shiftRelative
+}
+
+// shiftDayOfMonth moves the days of the month the expression fires on by
+// n days using shift, returning the new day-of-month and month fields
+func (c *CronTime) shiftDayOfMonth(n int, shift dayShifter) (string, string, error) {
+	m, err := parseDayOfMonth(c.DayOfMonth)
+	if err != nil {
+		return "", "", c.fieldError(dayOfMonthIndex, err)
+	}
+	if m.lastWeekday {
+		return "", "", fmt.Errorf("cannot shift the last weekday of the month: %s", c.DayOfMonth)
+	}
+	months, err := parseSet(c.Month, monthField)
+	if err != nil {
+		return "", "", c.fieldError(monthIndex, err)
+	}
+
+	var shifted monthDays
+	delta, mixed := 0, false
+	add := func(d shiftedDay, first bool) {
+		shifted.days |= d.days
+		shifted.fromEnd |= d.fromEnd
+		if first {
+			delta = d.months
+		} else if d.months != delta {
+			mixed = true
+		}
+	}
+
+	first := true
+	for _, day := range m.days.values() {
+		d, err := shift(day, false, n)
+		if err != nil {
+			return "", "", err
+		}
+		add(d, first)
+		first = false
+	}
+	for _, k := range m.fromEnd.values() {
+		d, err := shift(k, true, n)
+		if err != nil {
+			return "", "", err
+		}
+		add(d, first)
+		first = false
+	}
+
+	month := c.Month
+	if months != monthField.fullSet() {
+		if mixed {
+			return "", "", fmt.Errorf("cannot shift day of month %s by %d days: the days land in different months", c.DayOfMonth, n)
+		}
+		month = formatSet(rotateSet(months, delta, monthField), monthField)
+	}
+	return shifted.format(), month, nil
+}
+
+// shiftedDay is a day of the month after shifting, along with the number
+// of months it moved by
+type shiftedDay struct {
+	days, fromEnd valueSet
+	months        int
+}
+
+// minMonthDays is the length of the shortest month. Days up to it exist in
+// every month, as do the same number of days counted from the end.
+const minMonthDays = 28
+
+// shiftRelative shifts day, or "L-day" when fromEnd is set, by n days in a
+// way that holds in every month
+func shiftRelative(day int, fromEnd bool, n int) (shiftedDay, error) {
+	if fromEnd {
+		switch k := day - n; {
+		case k >= 0 && k <= maxFromEnd:
+			return shiftedDay{fromEnd: 1 << uint(k)}, nil
+		case k < 0 && -k <= minMonthDays:
+			return shiftedDay{days: 1 << uint(-k), months: 1}, nil
+		}
+		return shiftedDay{}, fmt.Errorf("cannot shift day of month L-%d by %d days without an anchor month; use WithAnchorMonth", day, n)
+	}
+
+	switch nd := day + n; {
+	case day <= minMonthDays && nd >= 1 && nd <= minMonthDays:
+		return shiftedDay{days: 1 << uint(nd)}, nil
+	case day <= minMonthDays && nd < 1 && -nd <= maxFromEnd:
+		return shiftedDay{fromEnd: 1 << uint(-nd), months: -1}, nil
+	}
+	return shiftedDay{}, fmt.Errorf("cannot shift day of month %d by %d days without an anchor month; use WithAnchorMonth", day, n)
+}
+
+// withoutLastDay returns a dayShifter that shifts as shiftRelative does
+// but refuses days landing on the end of a month, which d cannot write
+func withoutLastDay(d Dialect) dayShifter {
+	return func(day int, fromEnd bool, n int) (shiftedDay, error) {
+		shifted, err := shiftRelative(day, fromEnd, n)
+		if err == nil && shifted.fromEnd != 0 {
+			return shiftedDay{}, fmt.Errorf("cannot shift day of month %d by %d days: %s cron has no \"L\" for the last day; use WithAnchorMonth", day, n, d.Name)
+		}
+		return shifted, err
+	}
+}
+
+// anchoredShift returns a dayShifter that resolves days against the
+// calendar of the anchor month
+func anchoredShift(anchor time.Time) dayShifter {
+	return calendarShift(anchor, nil)
+}
+
+// calendarShift returns a dayShifter that resolves days against the
+// calendar of the anchor's month. With a non-nil cal, days that land on a
+// weekend or holiday keep moving in the direction of the shift.
+func calendarShift(anchor time.Time, cal Calendar) dayShifter {
+	year, month := anchor.Year(), anchor.Month()
+	last := daysIn(year, month)
+
+	return func(day int, fromEnd bool, n int) (shiftedDay, error) {
+		if fromEnd {
+			day = last - day
+		}
+		if day > last {
+			return shiftedDay{}, fmt.Errorf("day of month %d does not exist in anchor month %s %d", day, month, year)
+		}
+
+		t := time.Date(year, month, day+n, 0, 0, 0, 0, time.UTC)
+		if cal != nil {
+			var err error
+			if t, err = nextBusinessDay(t, n, cal); err != nil {
+				return shiftedDay{}, err
+			}
+		}
+		months := (t.Year()-year)*12 + int(t.Month()-month)
+		return shiftedDay{days: 1 << uint(t.Day()), months: months}, nil
+	}
+}
+
+// businessDays moves every day of the week in s by n days, then keeps
+// moving weekend days in the same direction until they reach a weekday
+func businessDays(s valueSet, n int) valueSet {
+	step := 1
+	if n < 0 {
+		step = -1
+	}
+
+	var moved valueSet
+	for _, v := range s.values() {
+		day := time.Weekday(((v+n)%7 + 7) % 7)
+		for day == time.Saturday || day == time.Sunday {
+			day = (day + time.Weekday(step) + 7) % 7
+		}
+		moved |= 1 << uint(day)
+	}
+	return moved
+}
+
+// LastDayOfMonth returns an expression firing at hour:minute on the last
+// day of every month, "M H L * *"
+func LastDayOfMonth(hour, minute int) (*CronTime, error) {
+	return atTimeOn(hour, minute, "L", "*")
+}
+
+// LastWeekdayOfMonth returns an expression firing at hour:minute on the
+// last Monday to Friday of every month, "M H LW * *"
+func LastWeekdayOfMonth(hour, minute int) (*CronTime, error) {
+	return atTimeOn(hour, minute, "LW", "*")
+}
+
+// NthWeekdayOfMonth returns an expression firing at hour:minute on the nth
+// given weekday of every month, so the second Monday at 09:00 is
+// "0 9 * * 1#2". n ranges from 1 to 5.
+func NthWeekdayOfMonth(n int, dow time.Weekday, hour, minute int) (*CronTime, error) {
+	if n < 1 || n > maxNth {
+		return nil, fmt.Errorf("invalid occurrence: value %d out of range [1, %d]", n, maxNth)
+	}
+	if dow < time.Sunday || dow > time.Saturday {
+		return nil, fmt.Errorf("invalid day of week: %d", dow)
+	}
+	return atTimeOn(hour, minute, "*", fmt.Sprintf("%d#%d", dow, n))
+}
+
+// EveryNDays returns the usual approximation of running every n days at
+// hour:minute, "M H */n * *", along with a Warning of kind UnevenStep
+// saying how it goes wrong: the step restarts on the 1st of every month,
+// so "*/3" fires on the 31st and again the next day. The Warning is zero
+// for n = 1, which is exact. n ranges from 1 to 31.
+func EveryNDays(n, hour, minute int) (*CronTime, Warning, error) {
+	if n < 1 || n > 31 {
+		return nil, Warning{}, fmt.Errorf("invalid number of days: value %d out of range [1, 31]", n)
+	}
+	if n == 1 {
+		c, err := atTimeOn(hour, minute, "*", "*")
+		return c, Warning{}, err
+	}
+	c, err := atTimeOn(hour, minute, fmt.Sprintf("*/%d", n), "*")
+	if err != nil {
+		return nil, Warning{}, err
+	}
+
+	var days []string
+	for d := 1; d <= 31; d += n {
+		days = append(days, strconv.Itoa(d))
+	}
+	// The gap from the last firing of a month to the 1st of the next,
+	// over the lengths a month can have
+	shortest, longest := 31, 0
+	for length := minMonthDays; length <= 31; length++ {
+		gap := length - (length-1)/n*n
+		shortest, longest = min(shortest, gap), max(longest, gap)
+	}
+	unit := "days"
+	if len(days) == 1 {
+		unit = "day"
+	}
+	return c, Warning{
+		Kind:     UnevenStep,
+		Severity: SeverityWarning,
+		Field:    dayOfMonthField.name,
+		Message: fmt.Sprintf("day of month */%d fires on %s %s of each month and again on the 1st: the gap across the end of a month varies from %d to %d days",
+			n, unit, strings.Join(days, ", "), shortest, longest),
+	}, nil
+}
+
+// atTimeOn returns an expression firing at hour:minute on the given days
+// of every month
+func atTimeOn(hour, minute int, dom, dow string) (*CronTime, error) {
+	if _, err := hourField.parseValue(strconv.Itoa(hour)); err != nil {
+		return nil, fmt.Errorf("invalid hour: %v", err)
+	}
+	if _, err := minuteField.parseValue(strconv.Itoa(minute)); err != nil {
+		return nil, fmt.Errorf("invalid minute: %v", err)
+	}
+	return &CronTime{
+		Minute:     strconv.Itoa(minute),
+		Hour:       strconv.Itoa(hour),
+		DayOfMonth: dom,
+		Month:      "*",
+		DayOfWeek:  dow,
+	}, nil
+}
+
+// DaySpec is a set of days of the week, such as Weekdays or any other
+// []time.Weekday
+type DaySpec []time.Weekday
+
+var (
+	// Weekdays is Monday to Friday
+	Weekdays = DaySpec{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	// Weekends is Saturday and Sunday
+	Weekends = DaySpec{time.Saturday, time.Sunday}
+)
+
+// set returns the days of the spec as a day-of-week set
+func (d DaySpec) set() (valueSet, error) {
+	if len(d) == 0 {
+		return 0, fmt.Errorf("no days of the week given")
+	}
+	var s valueSet
+	for _, day := range d {
+		if day < time.Sunday || day > time.Saturday {
+			return 0, fmt.Errorf("invalid day of week: %d", day)
+		}
+		s |= 1 << uint(day)
+	}
+	return s, nil
+}
+
+// Daily returns a chain starting from "0 0 * * *", to build on as in
+// Daily().OnDays(Weekdays).At(9, 0), which is "0 9 * * 1-5"
+func Daily() *CronMath {
+	return New("0 0 * * *")
+}
+
+// RestrictDays limits the expression to the days of spec. A day of week
+// of every day is replaced by them, and any other keeps only the days it
+// has in common with them, so "0 9 * * 0,1,6" or "0 9 * * */2" restricted
+// to Weekdays becomes "0 9 * * 1" or "0 9 * * 2,4". As cron fires on days matching either day
+// field, expressions restricted by day of month are refused unless parsed
+// with WithDOMDOWIntersection.
+func (c *CronTime) RestrictDays(spec DaySpec) error {
+	if c.IsReboot() {
+		return ErrNotShiftable
+	}
+	days, err := spec.set()
+	if err != nil {
+		return err
+	}
+	if isRestricted(c.DayOfMonth) && !c.cfg.intersectDays {
+		return fmt.Errorf("cannot restrict the days of an expression restricted by day of month: %s", c.DayOfMonth)
+	}
+
+	if limitsDays(c.DayOfWeek, dayOfWeekField) {
+		current, err := parseSet(c.DayOfWeek, dayOfWeekField)
+		if err != nil {
+			return c.fieldError(dayOfWeekIndex, err)
+		}
+		if current&days == 0 {
+			return fmt.Errorf("day of week %s has no day in common with %s", c.DayOfWeek, formatSet(days, dayOfWeekField))
+		}
+		days &= current
+	}
+	c.DayOfWeek = formatDayOfWeek(days, c.cfg.sundaySeven)
+	return nil
+}
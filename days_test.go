@@ -0,0 +1,336 @@
+package cronmath
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCronTime_AdjustDayOfMonth(t *testing.T) {
+	tests := []struct {
+		name     string
+		cronStr  string
+		duration time.Duration
+		opts     []Option
+		want     string
+		wantErr  bool
+	}{
+		{"back into previous month", "0 2 1 * *", -Hours(3), nil, "0 23 L * *", false},
+		{"forward out of last day", "0 23 L * *", Hours(3), nil, "0 2 1 * *", false},
+		{"last days back", "0 1 L,L-1 * *", -Hours(2), nil, "0 23 L-2,L-1 * *", false},
+		{"within the month", "30 23 14,15 * *", Minutes(60), nil, "30 0 15,16 * *", false},
+		{"several days", "0 0 10 * *", -Hours(72), nil, "0 0 7 * *", false},
+		{"month follows", "0 2 1 3 *", -Hours(3), nil, "0 23 L 2 *", false},
+		{"month wraps the year", "0 2 1 1 *", -Hours(3), nil, "0 23 L 12 *", false},
+		{"no midnight crossed", "0 2 1 * *", Hours(1), nil, "0 3 1 * *", false},
+		{"depends on month length", "0 23 28 * *", Hours(2), nil, "", true},
+		{"stepped back", "0 0 1-27/2 * *", -Hours(1), nil, "0 23 2-26/2,L * *", false},
+		{"stepped from any day", "0 0 */2 * *", -Hours(1), nil, "", true},
+		{"stepped with stepped weekdays", "0 0 */2 * */2", -Hours(1), nil, "", true},
+		{"anchor in february", "0 2 1 * *", -Hours(3), []Option{WithAnchorMonth(2025, time.March)}, "0 23 28 * *", false},
+		{"anchor in leap february", "0 2 1 3 *", -Hours(3), []Option{WithAnchorMonth(2024, time.March)}, "0 23 29 2 *", false},
+		{"anchor forward", "0 23 28 2 *", Hours(2), []Option{WithAnchorMonth(2025, time.February)}, "0 1 1 3 *", false},
+		{"anchor resolves L", "0 23 L * *", -Hours(24), []Option{WithAnchorMonth(2025, time.April)}, "0 23 29 * *", false},
+		{"days split across months", "0 2 1,15 6 *", -Hours(3), nil, "", true},
+		{"both day fields", "0 2 1 * MON", -Hours(3), nil, "", true},
+		{"first of the month back", "0 0 1 * *", -Minutes(15), nil, "45 23 L * *", false},
+		{"last day forward", "59 23 L * *", Minutes(2), nil, "1 0 1 * *", false},
+		{"vixie has no L", "0 0 1 * *", -Minutes(15), []Option{WithDialect(Vixie)}, "", true},
+		{"vixie within the month", "0 0 2 * *", -Minutes(15), []Option{WithDialect(Vixie)}, "45 23 1 * *", false},
+		{"vixie with anchor", "0 0 1 * *", -Minutes(15), []Option{WithDialect(Vixie), WithAnchorMonth(2025, time.May)}, "45 23 30 * *", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+
+			before := cron.String()
+			err = cron.Add(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if got := cron.String(); got != before {
+					t.Errorf("Add() changed the expression to %q on error", got)
+				}
+				return
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("Add() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDayOfMonth_FromEnd(t *testing.T) {
+	tests := []struct {
+		field   string
+		want    string
+		wantErr bool
+	}{
+		{"L", "L", false},
+		{"L-3,L", "L-3,L", false},
+		{"1,L", "1,L", false},
+		{"LW,L-1", "L-1,LW", false},
+		{"L-28", "", true},
+		{"L3", "", true},
+		{"L-x", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			m, err := parseDayOfMonth(tt.field)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDayOfMonth(%q) error = %v, wantErr %v", tt.field, err, tt.wantErr)
+			}
+			if !tt.wantErr && m.format() != tt.want {
+				t.Errorf("parseDayOfMonth(%q) = %q, want %q", tt.field, m.format(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_MatchesLastDay(t *testing.T) {
+	cron, _ := ParseCron("0 0 L-1 * *")
+	s, err := cron.schedule()
+	if err != nil {
+		t.Fatalf("schedule() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		d    date
+		want bool
+	}{
+		{date{year: 2024, month: time.February, day: 28}, true},
+		{date{year: 2025, month: time.February, day: 27}, true},
+		{date{year: 2025, month: time.February, day: 28}, false},
+		{date{year: 2025, month: time.April, day: 29}, true},
+	} {
+		if got := s.matchesDate(tt.d); got != tt.want {
+			t.Errorf("matchesDate(%d-%02d-%02d) = %v, want %v", tt.d.year, tt.d.month, tt.d.day, got, tt.want)
+		}
+	}
+}
+
+func TestCronTime_AddBusiness(t *testing.T) {
+	tests := []struct {
+		name     string
+		cronStr  string
+		duration time.Duration
+		want     string
+		wantErr  bool
+	}{
+		{"friday evening to monday", "0 18 * * FRI", Hours(6), "0 0 * * 1", false},
+		{"thursday stays a weekday", "0 18 * * THU", Hours(6), "0 0 * * 5", false},
+		{"weekdays forward", "0 22 * * 1-5", Hours(3), "0 1 * * 1-5", false},
+		{"every day becomes weekdays", "0 22 * * *", Hours(3), "0 1 * * 1-5", false},
+		{"monday back to friday", "0 1 * * MON", -Hours(2), "0 23 * * 5", false},
+		{"no midnight crossed", "0 9 * * 6", Hours(1), "0 10 * * 6", false},
+		{"day of month", "0 22 1 * *", Hours(3), "", true},
+		{"wildcard time", "* 22 * * *", Hours(3), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+
+			err = cron.AddBusiness(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddBusiness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("AddBusiness() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_SubBusiness(t *testing.T) {
+	if got, want := New("30 2 * * 1").SubBusiness(Hours(5)).String(), "30 21 * * 5"; got != want {
+		t.Errorf("SubBusiness() = %q, want %q", got, want)
+	}
+}
+
+func TestMonthPositionConstructors(t *testing.T) {
+	tests := []struct {
+		name    string
+		make    func() (*CronTime, error)
+		want    string
+		wantErr bool
+	}{
+		{"last day", func() (*CronTime, error) { return LastDayOfMonth(23, 30) }, "30 23 L * *", false},
+		{"last weekday", func() (*CronTime, error) { return LastWeekdayOfMonth(18, 0) }, "0 18 LW * *", false},
+		{"second monday", func() (*CronTime, error) { return NthWeekdayOfMonth(2, time.Monday, 9, 0) }, "0 9 * * 1#2", false},
+		{"sixth monday", func() (*CronTime, error) { return NthWeekdayOfMonth(6, time.Monday, 9, 0) }, "", true},
+		{"hour out of range", func() (*CronTime, error) { return LastDayOfMonth(24, 0) }, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := tt.make()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("String() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFrom_LastDayOfMonth(t *testing.T) {
+	if got, want := From(LastDayOfMonth(23, 0)).Add(Hours(2)).String(), "0 1 1 * *"; got != want {
+		t.Errorf("From().Add() = %q, want %q", got, want)
+	}
+}
+
+func TestSchedule_MatchesMonthPositions(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		d       date
+		want    bool
+	}{
+		// 2025-05-31 is a Saturday, so the last weekday is Friday the 30th
+		{"0 0 LW * *", date{year: 2025, month: time.May, day: 30, weekday: time.Friday}, true},
+		{"0 0 LW * *", date{year: 2025, month: time.May, day: 31, weekday: time.Saturday}, false},
+		{"0 0 LW * *", date{year: 2025, month: time.June, day: 30, weekday: time.Monday}, true},
+		{"0 0 * * 1#2", date{year: 2025, month: time.June, day: 9, weekday: time.Monday}, true},
+		{"0 0 * * 1#2", date{year: 2025, month: time.June, day: 2, weekday: time.Monday}, false},
+		{"0 0 * * 1#5", date{year: 2025, month: time.June, day: 30, weekday: time.Monday}, true},
+	}
+
+	for _, tt := range tests {
+		cron, _ := ParseCron(tt.cronStr)
+		s, err := cron.schedule()
+		if err != nil {
+			t.Fatalf("schedule(%q) error = %v", tt.cronStr, err)
+		}
+		if got := s.matchesDate(tt.d); got != tt.want {
+			t.Errorf("%q matchesDate(%d-%02d-%02d) = %v, want %v", tt.cronStr, tt.d.year, tt.d.month, tt.d.day, got, tt.want)
+		}
+	}
+}
+
+func TestCronTime_RestrictDays(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		spec    DaySpec
+		want    string
+		wantErr bool
+	}{
+		{"weekdays", "0 9 * * *", nil, Weekdays, "0 9 * * 1-5", false},
+		{"weekends", "0 9 * * *", nil, Weekends, "0 9 * * 0,6", false},
+		{"weekends with seven", "0 9 * * *", []Option{WithSundayAsSeven()}, Weekends, "0 9 * * 6,7", false},
+		{"any days", "0 9 * * ?", nil, DaySpec{time.Thursday, time.Tuesday}, "0 9 * * 2,4", false},
+		{"intersects", "0 9 * * 0,1,6", nil, Weekdays, "0 9 * * 1", false},
+		{"intersects names", "0 9 * * FRI-SUN", nil, Weekends, "0 9 * * 0,6", false},
+		{"intersects a step", "0 9 * * */2", nil, Weekdays, "0 9 * * 2,4", false},
+		{"nothing in common", "0 9 * * 1-5", nil, Weekends, "", true},
+		{"restricted day of month", "0 9 13 * *", nil, Weekdays, "", true},
+		{"day intersection", "0 9 13 * *", []Option{WithDOMDOWIntersection()}, []time.Weekday{time.Friday}, "0 9 13 * 5", false},
+		{"no days", "0 9 * * *", nil, nil, "", true},
+		{"invalid day", "0 9 * * *", nil, DaySpec{7}, "", true},
+		{"nth weekday", "0 9 * * 1#2", nil, Weekdays, "", true},
+		{"reboot", "@reboot", nil, Weekdays, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			err = cron.RestrictDays(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RestrictDays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if got := cron.String(); got != tt.cronStr {
+					t.Errorf("expression changed to %q on error", got)
+				}
+				return
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("RestrictDays() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaily(t *testing.T) {
+	tests := []struct {
+		name string
+		cm   *CronMath
+		want string
+	}{
+		{"every day", Daily(), "0 0 * * *"},
+		{"weekdays", Daily().OnDays(Weekdays).At(9, 0), "0 9 * * 1-5"},
+		{"weekends", Daily().At(10, 30).OnDays(Weekends), "30 10 * * 0,6"},
+		{"narrowed twice", Daily().OnDays(Weekdays).OnDays([]time.Weekday{time.Monday, time.Sunday}).At(8, 15), "15 8 * * 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cm.Error(); err != nil {
+				t.Fatalf("Error() = %v", err)
+			}
+			if got := tt.cm.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := Daily().OnDays(Weekdays).OnDays(Weekends).Error(); err == nil {
+		t.Error("OnDays(Weekends) after OnDays(Weekdays) succeeded, want an error")
+	}
+}
+
+func TestEveryNDays(t *testing.T) {
+	tests := []struct {
+		n           int
+		want        string
+		wantMessage string
+		wantErr     bool
+	}{
+		{1, "30 4 * * *", "", false},
+		{2, "30 4 */2 * *", "day of month */2 fires on days 1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31 of each month and again on the 1st: the gap across the end of a month varies from 1 to 2 days", false},
+		{3, "30 4 */3 * *", "day of month */3 fires on days 1, 4, 7, 10, 13, 16, 19, 22, 25, 28, 31 of each month and again on the 1st: the gap across the end of a month varies from 1 to 3 days", false},
+		{15, "30 4 */15 * *", "day of month */15 fires on days 1, 16, 31 of each month and again on the 1st: the gap across the end of a month varies from 1 to 15 days", false},
+		{31, "30 4 */31 * *", "day of month */31 fires on day 1 of each month and again on the 1st: the gap across the end of a month varies from 28 to 31 days", false},
+		{0, "", "", true},
+		{32, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.n), func(t *testing.T) {
+			cron, w, err := EveryNDays(tt.n, 4, 30)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EveryNDays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cron.String() != tt.want {
+				t.Errorf("EveryNDays() = %q, want %q", cron.String(), tt.want)
+			}
+			if w.Message != tt.wantMessage {
+				t.Errorf("Warning.Message = %q, want %q", w.Message, tt.wantMessage)
+			}
+			if tt.wantMessage != "" && (w.Kind != UnevenStep || w.Field != "day of month") {
+				t.Errorf("Warning = %+v, want an uneven day-of-month step", w)
+			}
+		})
+	}
+
+	if _, _, err := EveryNDays(3, 24, 0); err == nil {
+		t.Error("EveryNDays(3, 24, 0) error = nil")
+	}
+}
@@ -0,0 +1,157 @@
+package cronmath
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxDescribedTimes is the largest number of times of day Describe lists
+// one by one
+const maxDescribedTimes = 4
+
+// Describe returns an English description of when the expression fires,
+// e.g. "at 23:00 on the last day of the month" for "0 23 L * *"
+func (c *CronTime) Describe() (string, error) {
+	return c.DescribeIn("en")
+}
+
+// DescribeIn is Describe in the language given by a BCP 47 tag such as
+// "ja" or "ja-JP", so "30 9 * * 1-5" is 「平日 9時30分」 in Japanese.
+// Languages without a catalog fall back to English.
+func (c *CronTime) DescribeIn(lang string) (string, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return "", err
+	}
+
+	cat := catalogFor(lang)
+	clock, daily := c.describeTime(cat, s)
+	days, monthly := c.describeDays(cat, s)
+	var months string
+	if s.month != monthField.fullSet() {
+		months = describeRuns(cat, s.month, func(m int) string { return cat.month(time.Month(m)) })
+	}
+	return cat.sentence(phrase{clock: clock, daily: daily, days: days, monthly: monthly, months: months}), nil
+}
+
+// describeTime describes the times of day the schedule fires at, and
+// reports whether they are a few fixed times of day
+func (c *CronTime) describeTime(cat *catalog, s *schedule) (string, bool) {
+	everyMinute, everyHour := s.minute == minuteField.fullSet(), s.hour == hourField.fullSet()
+	switch {
+	case everyMinute && everyHour:
+		return cat.everyMinute, false
+	case everyHour && s.minute.len() == 1:
+		return cat.minuteOfHour(s.minute.values()[0]), false
+	case everyHour && strings.HasPrefix(c.Minute, "*/"):
+		return cat.everyMinutes(strings.TrimPrefix(c.Minute, "*/")), false
+	}
+
+	if times := expandDay(s.hour, s.minute); len(times) <= maxDescribedTimes {
+		clock := make([]string, len(times))
+		for i, t := range times {
+			clock[i] = cat.clock(t/60, t%60)
+		}
+		return cat.atTimes(cat.list(clock)), true
+	}
+	return cat.minutePastHour(c.Minute, c.Hour), false
+}
+
+// describeDays describes the days the schedule fires on, or returns ""
+// when it fires every day. It reports whether the days are positions in
+// the month, such as the 15th or the second Monday.
+func (c *CronTime) describeDays(cat *catalog, s *schedule) (string, bool) {
+	var dom, dow string
+	monthly := false
+	if isRestricted(c.DayOfMonth) {
+		dom, monthly = describeMonthDays(cat, s), true
+	}
+	if isRestricted(c.DayOfWeek) {
+		dow = describeWeekdays(cat, s)
+		monthly = monthly || s.dowNth != 0
+	}
+
+	switch {
+	case dom != "" && dow != "" && !s.unionDays:
+		return cat.both(dom, dow), monthly
+	case dom != "" && dow != "":
+		return cat.either(dom, dow), monthly
+	case dom != "":
+		return dom, monthly
+	}
+	return dow, monthly
+}
+
+// describeMonthDays describes the day-of-month field
+func describeMonthDays(cat *catalog, s *schedule) string {
+	var days []string
+	if n := s.dom.len(); n > 0 {
+		days = append(days, cat.monthDays(describeRuns(cat, s.dom, cat.dayNumber), n))
+	}
+
+	offsets := s.domFromEnd.values()
+	for i := len(offsets) - 1; i >= 0; i-- {
+		days = append(days, cat.fromEnd(offsets[i]))
+	}
+	if s.domLastWeekday {
+		days = append(days, cat.lastWeekday)
+	}
+	return cat.ofMonth(cat.list(days))
+}
+
+// describeWeekdays describes the day-of-week field
+func describeWeekdays(cat *catalog, s *schedule) string {
+	var days []string
+	switch {
+	case s.dow == workweek && cat.workweek != "":
+		days = append(days, cat.workweek)
+	case s.dow != 0:
+		days = append(days, describeRuns(cat, s.dow, func(d int) string { return cat.weekday(time.Weekday(d)) }))
+	}
+	for _, bit := range s.dowNth.values() {
+		days = append(days, cat.nthWeekday(bit%8, time.Weekday(bit/8)))
+	}
+	return cat.weekdays(cat.list(days))
+}
+
+// workweek is the day-of-week set of Monday through Friday
+const workweek valueSet = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+
+// describeRuns lists the values of a set in words, writing runs of three
+// or more as a range. name renders a value; nil writes numbers.
+func describeRuns(cat *catalog, s valueSet, name func(int) string) string {
+	if name == nil {
+		name = func(v int) string { return fmt.Sprint(v) }
+	}
+
+	vals := s.values()
+	var items []string
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, cat.through(name(vals[i]), name(vals[j])))
+			i = j + 1
+			continue
+		}
+		for ; i <= j; i++ {
+			items = append(items, name(vals[i]))
+		}
+	}
+	return cat.list(items)
+}
+
+// joinList joins items as a list, "a, b and c", with the given separator
+// and final conjunction
+func joinList(items []string, sep, conj string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], sep) + conj + items[len(items)-1]
+}
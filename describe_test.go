@@ -0,0 +1,104 @@
+package cronmath
+
+import "testing"
+
+func TestCronTime_Describe(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		want    string
+	}{
+		{"* * * * *", "every minute"},
+		{"*/15 * * * *", "every 15 minutes"},
+		{"5 * * * *", "at minute 5 of every hour"},
+		{"30 9 * * *", "at 09:30"},
+		{"0 9,17 * * 1-5", "at 09:00 and 17:00 on Monday through Friday"},
+		{"0 23 L * *", "at 23:00 on the last day of the month"},
+		{"0 23 L-2 * *", "at 23:00 on 2 days before the last day of the month"},
+		{"0 18 LW * *", "at 18:00 on the last weekday of the month"},
+		{"0 9 * * 1#2", "at 09:00 on the second Monday of the month"},
+		{"0 0 1,15 1,7 *", "at 00:00 on days 1 and 15 of the month in January and July"},
+		{"0 9 13 * FRI", "at 09:00 on day 13 of the month or on Friday"},
+		{"0-30 * * * *", "at minute 0-30 past hour *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			got, err := cron.Describe()
+			if err != nil {
+				t.Fatalf("Describe() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_DescribeIntersection(t *testing.T) {
+	cron, _ := ParseCronWith("0 9 13 * 5", WithDOMDOWIntersection())
+	tests := map[string]string{
+		"en": "at 09:00 on day 13 of the month if it falls on Friday",
+		"ja": "毎月13日かつ金曜日 9時00分",
+	}
+	for lang, want := range tests {
+		if got, err := cron.DescribeIn(lang); err != nil || got != want {
+			t.Errorf("DescribeIn(%q) = %q, %v, want %q", lang, got, err, want)
+		}
+	}
+}
+
+func TestCronTime_DescribeInvalid(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * 9")
+	if _, err := cron.Describe(); err == nil {
+		t.Error("Describe() expected error for out-of-range day of week, got nil")
+	}
+}
+
+func TestCronTime_DescribeIn(t *testing.T) {
+	tests := []struct {
+		lang    string
+		cronStr string
+		want    string
+	}{
+		{"ja", "30 9 * * 1-5", "平日 9時30分"},
+		{"ja", "0 9 * * *", "毎日 9時00分"},
+		{"ja", "* * * * *", "毎分"},
+		{"ja", "*/15 * * * *", "15分ごと"},
+		{"ja", "5 * * * *", "毎時5分"},
+		{"ja", "0 9,17 * * 0,6", "日曜日と土曜日 9時00分と17時00分"},
+		{"ja", "0 23 L * *", "毎月末日 23時00分"},
+		{"ja", "0 23 L-2 * *", "毎月末日の2日前 23時00分"},
+		{"ja", "0 18 LW * *", "毎月最終平日 18時00分"},
+		{"ja", "0 9 * * 1#2", "毎月第2月曜日 9時00分"},
+		{"ja", "0 0 1,15 1,7 *", "1月と7月の1日と15日 0時00分"},
+		{"ja", "0 0 1-5,L * *", "毎月1日から5日と末日 0時00分"},
+		{"ja", "0 9 13 * FRI", "毎月13日または金曜日 9時00分"},
+		{"ja", "0 6 * 3-5 *", "3月から5月の毎日 6時00分"},
+		{"ja-JP", "0 12 1 * *", "毎月1日 12時00分"},
+		{"en", "30 9 * * 1-5", "at 09:30 on Monday through Friday"},
+		{"en-GB", "0 9 1,2,15 * *", "at 09:00 on days 1, 2 and 15 of the month"},
+		{"en", "0 9 * * 1#1,5#3", "at 09:00 on the first Monday of the month and the third Friday of the month"},
+		{"fr", "0 23 L * *", "at 23:00 on the last day of the month"},
+		{"", "30 9 * * *", "at 09:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+" "+tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			got, err := cron.DescribeIn(tt.lang)
+			if err != nil {
+				t.Fatalf("DescribeIn() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DescribeIn(%q) = %q, want %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,455 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Dialect describes the cron syntax accepted by a particular cron
+// implementation. Fields are always in cron order, minute to day of week,
+// with the seconds and year fields of the layout around them.
+type Dialect struct {
+	// Name names the dialect in errors, e.g. "POSIX"
+	Name string
+	// Layouts lists the layouts accepted, LayoutStandard alone when
+	// empty. Expressions take the first layout with as many fields.
+	Layouts []Layout
+
+	Steps       bool
+	Names       bool
+	SundaySeven bool
+	// LastDay accepts "L" in the day of month and NthWeekday "#" in the
+	// day of week
+	LastDay    bool
+	NthWeekday bool
+	// NoSpecific accepts "?" in the day fields, and RequireNoSpecific
+	// requires it in exactly one of them, as Quartz does
+	NoSpecific        bool
+	RequireNoSpecific bool
+	// DayOfWeekBase is the number of Sunday, 0 or 1. Expressions parsed
+	// with a base of 1 hold their days of the week numbered from 0 and
+	// are written back from 1.
+	DayOfWeekBase int
+	// Macros lists the "@" macros accepted, such as "@daily"
+	Macros []string
+	// Ranges narrows the values accepted in a field, keyed by the name
+	// used in Field, e.g. {"hour": {0, 11}}
+	Ranges map[string][2]int
+	// IntersectDays fires only on days matching both day fields, as
+	// WithDOMDOWIntersection does
+	IntersectDays bool
+}
+
+// allMacros lists every macro the package understands
+var allMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly", reboot}
+
+var (
+	// POSIX is the syntax defined by POSIX crontab: numbers, ranges and
+	// lists only
+	POSIX = Dialect{Name: "POSIX"}
+
+	// Vixie is the syntax of Vixie/ISC cron, which adds step values,
+	// month and weekday names, "@" macros and 7 for Sunday
+	Vixie = Dialect{Name: "Vixie", Steps: true, Names: true, SundaySeven: true, Macros: allMacros}
+
+	// BusyBox is the syntax of busybox crond, which accepts steps and
+	// names but neither "@" macros nor 7 for Sunday
+	BusyBox = Dialect{Name: "BusyBox", Steps: true, Names: true}
+
+	// NCrontab is the syntax of the .NET NCrontab library, which accepts
+	// steps, names and an optional leading seconds field, but neither "@"
+	// macros nor 7 for Sunday. It fires only on days matching both the
+	// day of month and the day of week.
+	NCrontab = Dialect{
+		Name: "NCrontab", Layouts: []Layout{LayoutStandard, LayoutSeconds},
+		Steps: true, Names: true, IntersectDays: true,
+	}
+
+	// Quartz is the syntax of the Quartz scheduler and Spring, with a
+	// seconds field, an optional year, "?" in one of the day fields and
+	// days of the week numbered 1 to 7 from Sunday. Its "W" and "nL"
+	// tokens are rejected, having no equivalent here.
+	Quartz = Dialect{
+		Name: "Quartz", Layouts: []Layout{LayoutSeconds, LayoutSecondsYear},
+		Steps: true, Names: true, LastDay: true, NthWeekday: true,
+		NoSpecific: true, RequireNoSpecific: true, DayOfWeekBase: 1,
+	}
+
+	// EventBridge is the syntax of Amazon EventBridge cron(...) schedules,
+	// Quartz's without the seconds field and with a required year
+	EventBridge = Dialect{
+		Name: "EventBridge", Layouts: []Layout{LayoutYear},
+		Steps: true, Names: true, LastDay: true, NthWeekday: true,
+		NoSpecific: true, RequireNoSpecific: true, DayOfWeekBase: 1,
+	}
+
+	// Kubernetes is the syntax of Kubernetes CronJob schedules, which
+	// accepts steps, names, "?" and every macro but "@reboot"
+	Kubernetes = Dialect{
+		Name: "Kubernetes", Steps: true, Names: true, NoSpecific: true,
+		Macros: []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"},
+	}
+)
+
+// String returns the name of the dialect
+func (d Dialect) String() string {
+	return d.Name
+}
+
+// hasMacro reports whether d accepts the macro name
+func (d Dialect) hasMacro(name string) bool {
+	return slices.Contains(d.Macros, name)
+}
+
+// layouts returns the layouts d accepts
+func (d Dialect) layouts() []Layout {
+	if len(d.Layouts) == 0 {
+		return []Layout{LayoutStandard}
+	}
+	return d.Layouts
+}
+
+// layoutFor returns the layout d reads n fields as
+func (d Dialect) layoutFor(n int) (Layout, bool) {
+	for _, l := range d.layouts() {
+		if l.fields() == n {
+			return l, true
+		}
+	}
+	return 0, false
+}
+
+// fieldCounts describes the numbers of fields d accepts, e.g. "5 or 6"
+func (d Dialect) fieldCounts() string {
+	var counts []string
+	for _, l := range d.layouts() {
+		if n := strconv.Itoa(l.fields()); !slices.Contains(counts, n) {
+			counts = append(counts, n)
+		}
+	}
+	if len(counts) == 1 {
+		return counts[0]
+	}
+	return strings.Join(counts[:len(counts)-1], ", ") + " or " + counts[len(counts)-1]
+}
+
+// validate checks the description of d for RegisterDialect
+func (d Dialect) validate() error {
+	if d.DayOfWeekBase != 0 && d.DayOfWeekBase != 1 {
+		return fmt.Errorf("day of week base %d, want 0 or 1", d.DayOfWeekBase)
+	}
+	for _, l := range d.Layouts {
+		if l < LayoutStandard || l > LayoutYear {
+			return fmt.Errorf("invalid layout %d", l)
+		}
+	}
+	for _, m := range d.Macros {
+		if _, ok := macros[m]; !ok && m != reboot {
+			return fmt.Errorf("unsupported macro %s", m)
+		}
+	}
+	for name, r := range d.Ranges {
+		spec, ok := fieldSpecNamed(name)
+		if !ok {
+			return fmt.Errorf("range for unknown field %q", name)
+		}
+		if r[0] > r[1] || r[0] < spec.min || r[1] > spec.max {
+			return fmt.Errorf("range %d-%d for field %s outside %d-%d", r[0], r[1], name, spec.min, spec.max)
+		}
+	}
+	return nil
+}
+
+// fieldSpecNamed returns the spec of the field called name in Field
+func fieldSpecNamed(name string) (fieldSpec, bool) {
+	if name == secondField.name {
+		return secondField, true
+	}
+	for _, spec := range standardFields {
+		if spec.name == name {
+			return spec, true
+		}
+	}
+	return fieldSpec{}, false
+}
+
+// dialects holds the dialects registered by name, in lower case
+var dialects = struct {
+	sync.RWMutex
+	byName map[string]Dialect
+}{byName: map[string]Dialect{}}
+
+func init() {
+	for name, d := range map[string]Dialect{
+		"posix":       POSIX,
+		"vixie":       Vixie,
+		"unix":        Vixie,
+		"busybox":     BusyBox,
+		"ncrontab":    NCrontab,
+		"quartz":      Quartz,
+		"eventbridge": EventBridge,
+		"kubernetes":  Kubernetes,
+	} {
+		if err := RegisterDialect(name, d); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterDialect makes d available to WithDialectName and LookupDialect
+// under name, which is matched regardless of case. The built-in dialects
+// are registered as "posix", "vixie" (also "unix"), "busybox",
+// "ncrontab", "quartz", "eventbridge" and "kubernetes". A name can be
+// registered once; d is named after it when it has no Name.
+func RegisterDialect(name string, d Dialect) error {
+	if name == "" {
+		return errors.New("dialect name is empty")
+	}
+	if err := d.validate(); err != nil {
+		return fmt.Errorf("invalid dialect %s: %v", name, err)
+	}
+	if d.Name == "" {
+		d.Name = name
+	}
+	d.Layouts, d.Macros, d.Ranges = slices.Clone(d.Layouts), slices.Clone(d.Macros), maps.Clone(d.Ranges)
+
+	key := strings.ToLower(name)
+	dialects.Lock()
+	defer dialects.Unlock()
+	if _, ok := dialects.byName[key]; ok {
+		return fmt.Errorf("dialect %s already registered", name)
+	}
+	dialects.byName[key] = d
+	return nil
+}
+
+// LookupDialect returns the dialect registered under name, matched
+// regardless of case
+func LookupDialect(name string) (Dialect, bool) {
+	dialects.RLock()
+	defer dialects.RUnlock()
+	d, ok := dialects.byName[strings.ToLower(name)]
+	return d, ok
+}
+
+// macros maps the supported "@" macros to their five-field expansion
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// outputMacros lists the macros String() may write, in the order they are
+// tried
+var outputMacros = []string{"@hourly", "@daily", "@weekly", "@monthly", "@yearly"}
+
+// equivalentMacro returns the macro firing exactly when c does, if any
+func (c *CronTime) equivalentMacro() (string, bool) {
+	if c.layout != LayoutStandard {
+		return "", false
+	}
+	s, err := c.schedule()
+	if err != nil {
+		return "", false
+	}
+
+	for _, name := range outputMacros {
+		m, _ := ParseCron(macros[name])
+		if ms, _ := m.schedule(); *ms == *s {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// ValidateFor reports every construct of the expression that d does not
+// accept, e.g. "step values not supported in POSIX cron, field 1 (minute):
+// */5". All problems are joined into the returned error.
+func (c *CronTime) ValidateFor(d Dialect) error {
+	if c.IsReboot() {
+		if !d.hasMacro(reboot) {
+			return fmt.Errorf("macros not supported in %s cron: %s", d.Name, reboot)
+		}
+		return nil
+	}
+
+	var errs []error
+
+	if err := d.validateLayout(c); err != nil {
+		errs = append(errs, err)
+	}
+	if c.layout.hasSeconds() && !d.Steps && strings.Contains(c.Second, "/") {
+		errs = append(errs, fmt.Errorf("step values not supported in %s cron, seconds field: %s", d.Name, c.Second))
+	}
+	if c.layout.hasYear() {
+		if _, err := parseYears(c.Year); err != nil {
+			errs = append(errs, c.fieldError(yearIndex, err))
+		}
+	}
+
+	if c.macro != "" && !d.hasMacro(c.macro) && c.fieldString() == macros[c.macro] {
+		errs = append(errs, fmt.Errorf("macros not supported in %s cron: %s", d.Name, c.macro))
+	}
+
+	valid := true
+	for i, field := range c.fieldPtrs() {
+		spec := standardFields[i]
+		if err := parseStandardField(i, *field); err != nil {
+			errs = append(errs, c.fieldError(i, err))
+			valid = false
+			continue
+		}
+
+		if !d.Steps && strings.Contains(*field, "/") {
+			errs = append(errs, fmt.Errorf("step values not supported in %s cron, field %d (%s): %s", d.Name, i+1, spec.name, *field))
+		}
+		if !d.Names && hasNames(*field) {
+			errs = append(errs, fmt.Errorf("named values not supported in %s cron, field %d (%s): %s", d.Name, i+1, spec.name, *field))
+		}
+	}
+
+	if m, err := parseDayOfMonth(c.DayOfMonth); err == nil && m.countsFromEnd() && !d.LastDay {
+		errs = append(errs, fmt.Errorf("last-day values not supported in %s cron, field 3 (day of month): %s", d.Name, c.DayOfMonth))
+	}
+
+	if _, nth, err := parseDayOfWeek(c.DayOfWeek); err == nil && nth != 0 && !d.NthWeekday {
+		errs = append(errs, fmt.Errorf("nth weekday values not supported in %s cron, field 5 (day of week): %s", d.Name, c.DayOfWeek))
+	}
+
+	dom, dow := c.DayOfMonth == "?", c.DayOfWeek == "?"
+	for _, i := range []int{dayOfMonthIndex, dayOfWeekIndex} {
+		if field := *c.fieldPtrs()[i]; field == "?" && !d.NoSpecific {
+			errs = append(errs, fmt.Errorf("\"?\" not supported in %s cron, field %d (%s): %s", d.Name, i+1, standardFields[i].name, field))
+		}
+	}
+	if d.RequireNoSpecific && dom == dow {
+		errs = append(errs, fmt.Errorf("exactly one of the day fields must be \"?\" in %s cron: %s %s", d.Name, c.DayOfMonth, c.DayOfWeek))
+	}
+
+	// With days numbered from 1, 7 is Saturday and the expression is
+	// written with it wherever Sunday was 7
+	if !d.SundaySeven && d.DayOfWeekBase == 0 && hasSundaySeven(c.DayOfWeek) {
+		errs = append(errs, fmt.Errorf("7 for Sunday not supported in %s cron, field 5 (day of week): %s", d.Name, c.DayOfWeek))
+	}
+
+	if len(d.Ranges) > 0 && valid {
+		errs = append(errs, d.validateRanges(c)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateLayout reports a layout d does not accept, naming the field it
+// has no room for where there is one
+func (d Dialect) validateLayout(c *CronTime) error {
+	layouts := d.layouts()
+	if slices.Contains(layouts, c.layout) {
+		return nil
+	}
+	switch {
+	case c.layout.hasSeconds() && !slices.ContainsFunc(layouts, Layout.hasSeconds):
+		return fmt.Errorf("seconds field not supported in %s cron: %s", d.Name, c.Second)
+	case c.layout.hasYear() && !slices.ContainsFunc(layouts, Layout.hasYear):
+		return fmt.Errorf("year field not supported in %s cron: %s", d.Name, c.Year)
+	}
+	return fmt.Errorf("%s layout not supported in %s cron: %s", c.layout, d.Name, c.rawString())
+}
+
+// validateRanges reports the fields of c with values outside the ranges
+// of d
+func (d Dialect) validateRanges(c *CronTime) []error {
+	fields, err := c.Fields()
+	if err != nil {
+		return nil
+	}
+	var errs []error
+	for _, f := range fields {
+		r, ok := d.Ranges[f.Name]
+		if ok && len(f.Values) > 0 && (f.Min < r[0] || f.Max > r[1]) {
+			errs = append(errs, fmt.Errorf("values outside %d-%d not supported in %s cron, %s: %s", r[0], r[1], d.Name, f.Name, f.Raw))
+		}
+	}
+	return errs
+}
+
+// formatFor renders the fields using only syntax d accepts. Fields that
+// cannot be rewritten are rendered as written.
+func (c *CronTime) formatFor(d Dialect) [5]string {
+	fields := c.fieldPtrs()
+
+	var out [5]string
+	for i, field := range fields {
+		out[i] = *field
+		if *field == "?" && !d.NoSpecific {
+			out[i] = "*"
+			continue
+		}
+		if (d.Steps || !strings.Contains(*field, "/")) && (d.Names || !hasNames(*field)) {
+			continue
+		}
+
+		s, err := parseSet(*field, standardFields[i])
+		if err != nil {
+			continue
+		}
+		rewritten := formatRuns(s)
+		if s == standardFields[i].fullSet() {
+			rewritten = "*"
+		}
+		out[i] = rewritten
+	}
+
+	// Keep the day fields combining the same way they did
+	for _, i := range []int{dayOfMonthIndex, dayOfWeekIndex} {
+		other := dayOfMonthIndex + dayOfWeekIndex - i
+		if isRestricted(*fields[other]) && isRestricted(*fields[i]) != isRestricted(out[i]) {
+			out[i] = *fields[i]
+		}
+	}
+
+	dom, dow := &out[dayOfMonthIndex], &out[dayOfWeekIndex]
+	if d.RequireNoSpecific && (*dom == "?") == (*dow == "?") {
+		switch {
+		case *dom == "?":
+			*dom = "*"
+		case *dow == "*":
+			*dow = "?"
+		case *dom == "*":
+			*dom = "?"
+		}
+	}
+
+	return out
+}
+
+// hasSundaySeven reports whether a day-of-week field uses 7 for Sunday
+func hasSundaySeven(field string) bool {
+	for _, part := range strings.Split(field, ",") {
+		base, _, _ := strings.Cut(part, "/")
+		lo, hi, _ := strings.Cut(base, "-")
+		if isSeven(lo) || isSeven(hi) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSeven(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n == 7
+}
+
+// hasNames reports whether a field uses symbolic names
+func hasNames(field string) bool {
+	return strings.ContainsFunc(field, unicode.IsLetter)
+}
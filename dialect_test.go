@@ -0,0 +1,432 @@
+package cronmath
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCron_Macros(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"@yearly", "0 0 1 1 *"},
+		{"@annually", "0 0 1 1 *"},
+		{"@monthly", "0 0 1 * *"},
+		{"@weekly", "0 0 * * 0"},
+		{"@daily", "0 0 * * *"},
+		{"@midnight", "0 0 * * *"},
+		{"@hourly", "0 * * * *"},
+		{"  @daily\n", "0 0 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cron, err := ParseCron(tt.input)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.input, err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("ParseCron(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseCron("@fortnightly"); err == nil {
+		t.Error("ParseCron(@fortnightly) expected error, got nil")
+	}
+}
+
+func TestParseSet_Names(t *testing.T) {
+	dow, err := parseSet("MON-FRI,SUN", dayOfWeekField)
+	if err != nil {
+		t.Fatalf("parseSet() error = %v", err)
+	}
+	if got := formatSet(dow, dayOfWeekField); got != "0-5" {
+		t.Errorf("parseSet(MON-FRI,SUN) = %q, want %q", got, "0-5")
+	}
+
+	month, err := parseSet("JAN,JUN-AUG,DEC", monthField)
+	if err != nil {
+		t.Fatalf("parseSet() error = %v", err)
+	}
+	if got := formatSet(month, monthField); got != "1,6-8,12" {
+		t.Errorf("parseSet(JAN,JUN-AUG,DEC) = %q, want %q", got, "1,6-8,12")
+	}
+
+	if _, err := parseSet("MON", monthField); err == nil {
+		t.Error("parseSet(MON) in month field expected error, got nil")
+	}
+}
+
+func TestCronTime_ValidateFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		dialect Dialect
+		wantErr []string
+	}{
+		{"plain values in POSIX", "5 9 1 1 1", POSIX, nil},
+		{"ranges and lists in POSIX", "0,30 9-17 * * 1-5", POSIX, nil},
+		{"step in POSIX", "*/5 * * * *", POSIX, []string{"step values not supported in POSIX cron, field 1 (minute): */5"}},
+		{"names in POSIX", "0 9 * JAN MON-FRI", POSIX, []string{
+			"named values not supported in POSIX cron, field 4 (month): JAN",
+			"named values not supported in POSIX cron, field 5 (day of week): MON-FRI",
+		}},
+		{"macro in POSIX", "@daily", POSIX, []string{"macros not supported in POSIX cron: @daily"}},
+		{"macro in BusyBox", "@hourly", BusyBox, []string{"macros not supported in BusyBox cron: @hourly"}},
+		{"steps and names in BusyBox", "*/5 9 * * MON", BusyBox, nil},
+		{"everything in Vixie", "*/5 9 * JAN MON", Vixie, nil},
+		{"macro in Vixie", "@weekly", Vixie, nil},
+		{"invalid value", "0 25 * * *", Vixie, []string{"invalid hour, field 2"}},
+		{"steps and names in NCrontab", "*/5 9 * JAN MON-FRI", NCrontab, nil},
+		{"macro in NCrontab", "@daily", NCrontab, []string{"macros not supported in NCrontab cron: @daily"}},
+		{"last day in NCrontab", "0 0 L * *", NCrontab, []string{"last-day values not supported in NCrontab cron, field 3 (day of month): L"}},
+		{"nth weekday in NCrontab", "0 0 * * 1#2", NCrontab, []string{"nth weekday values not supported in NCrontab cron, field 5 (day of week): 1#2"}},
+		{"question mark in NCrontab", "0 0 ? * 1", NCrontab, []string{`"?" not supported in NCrontab cron, field 3 (day of month): ?`}},
+		{"Sunday as seven in NCrontab", "0 0 * * 7", NCrontab, []string{"7 for Sunday not supported in NCrontab cron"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+
+			err = cron.ValidateFor(tt.dialect)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("ValidateFor(%s) error = %v, want nil", tt.dialect, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateFor(%s) error = nil, want %q", tt.dialect, tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateFor(%s) error = %q, want it to contain %q", tt.dialect, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCronTime_ValidateForShiftedMacro(t *testing.T) {
+	cron, _ := ParseCron("@daily")
+	if err := cron.Add(Hours(1)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := cron.ValidateFor(POSIX); err != nil {
+		t.Errorf("ValidateFor(POSIX) error = %v, want nil once the macro no longer applies", err)
+	}
+}
+
+func TestParseCronWith_Dialect(t *testing.T) {
+	if _, err := ParseCronWith("*/5 * * * *", WithDialect(POSIX)); err == nil {
+		t.Error("ParseCronWith(POSIX) expected error for step value, got nil")
+	}
+	if _, err := ParseCronWith("@daily", WithDialect(BusyBox)); err == nil {
+		t.Error("ParseCronWith(BusyBox) expected error for macro, got nil")
+	}
+	if _, err := ParseCronWith("*/5 * * * MON", WithDialect(Vixie)); err != nil {
+		t.Errorf("ParseCronWith(Vixie) error = %v", err)
+	}
+}
+
+func TestParseCronWith_NCrontab(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		want    string
+		layout  Layout
+		wantErr string
+	}{
+		{"*/5 9 * * MON-FRI", "*/5 9 * * MON-FRI", LayoutStandard, ""},
+		{"30 */5 9 * * MON-FRI", "30 */5 9 * * MON-FRI", LayoutSeconds, ""},
+		{"0 0 9 ? * MON", "", LayoutSeconds, `"?" not supported`},
+		{"0 0 9 * * MON 2030", "", LayoutStandard, "expected 5 or 6 fields, got 7"},
+		{"0 9 * *", "", LayoutStandard, "expected 5 or 6 fields, got 4"},
+		{"60 0 9 * * *", "", LayoutStandard, "invalid second"},
+		{"@weekly", "", LayoutStandard, "macros not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithDialect(NCrontab))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseCronWith() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := cron.Layout(); got != tt.layout {
+				t.Errorf("Layout() = %v, want %v", got, tt.layout)
+			}
+		})
+	}
+}
+
+func TestCronTime_ValidateForSeconds(t *testing.T) {
+	seconds, _ := ParseCronWith("30 0 9 * * *", WithAutoFields())
+	if err := seconds.ValidateFor(NCrontab); err != nil {
+		t.Errorf("ValidateFor(NCrontab) error = %v", err)
+	}
+	if err := seconds.ValidateFor(Vixie); err == nil || !strings.Contains(err.Error(), "seconds field not supported in Vixie cron: 30") {
+		t.Errorf("ValidateFor(Vixie) error = %v, want seconds field", err)
+	}
+
+	year, _ := ParseCronWith("0 0 9 * * * 2030", WithAutoFields())
+	if err := year.ValidateFor(NCrontab); err == nil || !strings.Contains(err.Error(), "year field not supported in NCrontab cron: 2030") {
+		t.Errorf("ValidateFor(NCrontab) error = %v, want year field", err)
+	}
+}
+
+func TestCronTime_NCrontabDays(t *testing.T) {
+	// NCrontab fires on Friday the 13th only, where Vixie fires on every
+	// 13th and every Friday
+	cron, err := ParseCronWith("0 9 13 * 5", WithDialect(NCrontab))
+	if err != nil {
+		t.Fatalf("ParseCronWith() error = %v", err)
+	}
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if got, err := cron.Next(from); err != nil || !got.Equal(time.Date(2025, time.June, 13, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next() = %v, %v, want 2025-06-13 09:00", got, err)
+	}
+
+	seven, _ := ParseCronWith("0 9 * * 0", WithDialect(NCrontab), WithSundayAsSeven())
+	if got, want := seven.String(), "0 9 * * 0"; got != want {
+		t.Errorf("String() with Sunday as seven = %q, want %q", got, want)
+	}
+}
+
+func TestCronTime_StringForDialect(t *testing.T) {
+	cron, err := ParseCronWith("0,30 9 * * 1-5", WithDialect(POSIX))
+	if err != nil {
+		t.Fatalf("ParseCronWith() error = %v", err)
+	}
+
+	cron.Minute = "*/15"
+	cron.DayOfWeek = "MON-FRI"
+	if got, want := cron.String(), "0,15,30,45 9 * * 1-5"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	lenient, _ := ParseCron("*/15 9 * * MON-FRI")
+	if got, want := lenient.String(), "*/15 9 * * MON-FRI"; got != want {
+		t.Errorf("String() without dialect = %q, want %q", got, want)
+	}
+}
+
+func TestCronTime_ValidateForSundaySeven(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * 5-7")
+	if err := cron.ValidateFor(Vixie); err != nil {
+		t.Errorf("ValidateFor(Vixie) error = %v", err)
+	}
+	for _, d := range []Dialect{POSIX, BusyBox} {
+		if err := cron.ValidateFor(d); err == nil || !strings.Contains(err.Error(), "7 for Sunday") {
+			t.Errorf("ValidateFor(%s) error = %v, want 7 for Sunday", d, err)
+		}
+	}
+}
+
+func TestCronTime_MacroOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		want    string
+	}{
+		{"daily", "0 0 * * *", []Option{WithMacroOutput()}, "@daily"},
+		{"hourly", "0 * * * *", []Option{WithMacroOutput()}, "@hourly"},
+		{"weekly with seven", "0 0 * * 7", []Option{WithMacroOutput()}, "@weekly"},
+		{"monthly", "00 0 1 * *", []Option{WithMacroOutput()}, "@monthly"},
+		{"yearly by name", "0 0 1 JAN *", []Option{WithMacroOutput()}, "@yearly"},
+		{"not equivalent", "0 1 * * *", []Option{WithMacroOutput()}, "0 1 * * *"},
+		{"off by default", "0 0 * * *", nil, "0 0 * * *"},
+		{"macro input off by default", "@daily", nil, "0 0 * * *"},
+		{"dialect without macros", "0 0 * * *", []Option{WithMacroOutput(), WithDialect(BusyBox)}, "0 0 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_MacroOutputAfterArithmetic(t *testing.T) {
+	if got, want := New("0 23 * * *", WithMacroOutput()).Add(Hours(1)).String(), "@daily"; got != want {
+		t.Errorf("Add() = %q, want %q", got, want)
+	}
+}
+
+func TestParseCronWith_RegisteredDialects(t *testing.T) {
+	tests := []struct {
+		dialect string
+		cronStr string
+		want    string
+		wantErr string
+	}{
+		{"unix", "*/5 9 * JAN MON-FRI", "*/5 9 * JAN MON-FRI", ""},
+		{"UNIX", "0 9 * * 7", "0 9 * * 0", ""},
+		{"posix", "*/5 * * * *", "", "step values not supported in POSIX cron"},
+		{"quartz", "0 5 9 ? * 2-6", "0 5 9 ? * 2-6", ""},
+		{"quartz", "0 0 12 * * ? 2030", "0 0 12 * * ? 2030", ""},
+		{"quartz", "0 15 10 ? * 6#3", "0 15 10 ? * 6#3", ""},
+		{"quartz", "0 0 0 ? * 1,7", "0 0 0 ? * 1,7", ""},
+		{"quartz", "0 0 0 ? * MON", "0 0 0 ? * MON", ""},
+		{"quartz", "0 0 0 * * 1", "", `exactly one of the day fields must be "?"`},
+		{"quartz", "0 0 * * *", "", "expected 6 or 7 fields, got 5"},
+		{"quartz", "0 0 0 ? * 0", "", "invalid day of week 0"},
+		{"eventbridge", "0 12 * * ? *", "0 12 * * ? *", ""},
+		{"eventbridge", "15 10 ? * 6L 2030", "", "last weekday of the month"},
+		{"eventbridge", "0/15 * ? * MON-FRI 2030", "0/15 * ? * MON-FRI 2030", ""},
+		{"eventbridge", "0 12 * * ?", "", "expected 6 fields, got 5"},
+		{"kubernetes", "0 0 ? * 1", "0 0 ? * 1", ""},
+		{"kubernetes", "@hourly", "0 * * * *", ""},
+		{"kubernetes", "@reboot", "", "macros not supported in Kubernetes cron: @reboot"},
+		{"kubernetes", "0 0 * * 7", "", "7 for Sunday not supported in Kubernetes cron"},
+		{"systemd", "0 0 * * *", "", `unknown dialect "systemd"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect+" "+tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithDialectName(tt.dialect))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseCronWith() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_QuartzDialectArithmetic(t *testing.T) {
+	cron, err := ParseCronWith("0 30 23 ? * 2-6", WithDialect(Quartz))
+	if err != nil {
+		t.Fatalf("ParseCronWith() error = %v", err)
+	}
+	if cron.DayOfWeek != "1-5" {
+		t.Errorf("DayOfWeek = %q, want %q numbered from 0", cron.DayOfWeek, "1-5")
+	}
+	if err := cron.Add(Hours(1)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if got, want := cron.String(), "0 30 0 ? * 3-7"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCronTime_StringForNoSpecific(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		dialect Dialect
+		want    string
+	}{
+		{"0 0 0 * * *", Quartz, "0 0 0 * * ?"},
+		{"0 0 0 15 * *", Quartz, "0 0 0 15 * ?"},
+		{"0 0 0 * * 1", Quartz, "0 0 0 ? * 2"},
+		{"0 0 ? * ?", Kubernetes, "0 0 ? * ?"},
+		{"0 0 ? * 1", Vixie, "0 0 * * 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect.String()+" "+tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithAutoFields())
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			cron.cfg.dialect = &tt.dialect
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterDialect(t *testing.T) {
+	mine := Dialect{
+		Steps:  true,
+		Macros: []string{"@daily"},
+		Ranges: map[string][2]int{"hour": {6, 22}, "minute": {0, 30}},
+	}
+	if err := RegisterDialect("In-House", mine); err != nil {
+		t.Fatalf("RegisterDialect() error = %v", err)
+	}
+	t.Cleanup(func() {
+		dialects.Lock()
+		delete(dialects.byName, "in-house")
+		dialects.Unlock()
+	})
+	if err := RegisterDialect("in-house", mine); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("RegisterDialect() again error = %v, want already registered", err)
+	}
+
+	d, ok := LookupDialect("IN-HOUSE")
+	if !ok || d.Name != "In-House" {
+		t.Fatalf("LookupDialect() = %v, %v, want In-House", d, ok)
+	}
+
+	if _, err := ParseCronWith("0-30/15 6-22 * * 1-5", WithDialectName("in-house")); err != nil {
+		t.Errorf("ParseCronWith() error = %v", err)
+	}
+	if _, err := ParseCronWith("@weekly", WithDialectName("in-house")); err == nil {
+		t.Error("ParseCronWith() expected error for a macro not in the dialect, got nil")
+	}
+	_, err := ParseCronWith("45 5 * JAN *", WithDialectName("in-house"))
+	for _, want := range []string{
+		"values outside 0-30 not supported in In-House cron, minute: 45",
+		"values outside 6-22 not supported in In-House cron, hour: 5",
+		"named values not supported in In-House cron, field 4 (month): JAN",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseCronWith() error = %v, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestRegisterDialectInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		wantErr string
+	}{
+		{"", Dialect{}, "dialect name is empty"},
+		{"base", Dialect{DayOfWeekBase: 2}, "day of week base 2"},
+		{"layout", Dialect{Layouts: []Layout{Layout(9)}}, "invalid layout 9"},
+		{"macro", Dialect{Macros: []string{"@fortnightly"}}, "unsupported macro @fortnightly"},
+		{"range field", Dialect{Ranges: map[string][2]int{"week": {1, 2}}}, `unknown field "week"`},
+		{"range bounds", Dialect{Ranges: map[string][2]int{"hour": {0, 24}}}, "range 0-24 for field hour outside 0-23"},
+		{"posix", Dialect{}, "dialect posix already registered"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterDialect(tt.name, tt.dialect)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RegisterDialect() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package cronmath
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Report describes how one expression differs from another. A Report
+// without any differences is empty; see Empty.
+type Report struct {
+	// Changes lists the fields that changed, in expression order
+	Changes []FieldChange
+
+	// Shift is the net change in time of day, taking the shorter way
+	// around midnight. It is set only when HasShift is, which requires
+	// both expressions to have a single fixed time.
+	Shift    Duration
+	HasShift bool
+
+	// DaysChanged is set when the expressions fire on different days
+	DaysChanged bool
+}
+
+// FieldChange is a field that changed between two expressions
+type FieldChange struct {
+	Field    string
+	Old, New string
+}
+
+// DiffReport compares two expressions field by field. Fields that mean
+// the same after normalization, such as "*/15" and "0,15,30,45", are not
+// reported as changed. The seconds and year fields are compared too, a
+// missing one counting as "0" and "*".
+func DiffReport(old, new *CronTime) Report {
+	var r Report
+
+	oldSched, oldErr := old.schedule()
+	newSched, newErr := new.schedule()
+	comparable := oldErr == nil && newErr == nil
+
+	if o, n := secondOf(old), secondOf(new); o != n && !(comparable && oldSched.second == newSched.second) {
+		r.Changes = append(r.Changes, FieldChange{Field: secondField.name, Old: o, New: n})
+	}
+
+	oldNorm, newNorm := *old, *new
+	oldNorm.Normalize()
+	newNorm.Normalize()
+	oldFields, newFields := oldNorm.fieldPtrs(), newNorm.fieldPtrs()
+	for i, spec := range standardFields {
+		o, n := *old.fieldPtrs()[i], *new.fieldPtrs()[i]
+		if *oldFields[i] == *newFields[i] {
+			continue
+		}
+		// A day field starting with "*" changes how the day fields combine
+		isDayField := i == dayOfMonthIndex || i == dayOfWeekIndex
+		if comparable && sameField(i, oldSched, newSched) && (!isDayField || isRestricted(o) == isRestricted(n)) {
+			continue
+		}
+		r.Changes = append(r.Changes, FieldChange{Field: spec.name, Old: o, New: n})
+	}
+	if o, n := yearOf(old), yearOf(new); o != n && !(comparable && oldSched.year == newSched.year) {
+		r.Changes = append(r.Changes, FieldChange{Field: yearField.name, Old: o, New: n})
+	}
+
+	if from, err := old.MinuteOfDay(); err == nil {
+		if to, err := new.MinuteOfDay(); err == nil {
+			r.Shift, _ = old.Until(to/60, to%60)
+			r.HasShift = from != to
+		}
+	}
+
+	if comparable {
+		r.DaysChanged = dayPattern(oldSched) != dayPattern(newSched)
+	} else {
+		r.DaysChanged = old.DayOfMonth != new.DayOfMonth || old.Month != new.Month || old.DayOfWeek != new.DayOfWeek || yearOf(old) != yearOf(new)
+	}
+	return r
+}
+
+// Equivalent reports whether two expressions fire at exactly the same
+// times however they are written, as "0 9 1-31 * 1" and "0 9 * * *" do
+// under the day-of-month/day-of-week OR rule. Year fields are compared
+// along with the days, so the same date in different years differs. Two
+// "@reboot" expressions are equivalent, and expressions that do not parse
+// are an error.
+func Equivalent(a, b *CronTime) (bool, error) {
+	if a.IsReboot() || b.IsReboot() {
+		return a.IsReboot() && b.IsReboot(), nil
+	}
+	as, err := a.schedule()
+	if err != nil {
+		return false, err
+	}
+	bs, err := b.schedule()
+	if err != nil {
+		return false, err
+	}
+	return as.equivalent(bs), nil
+}
+
+// sameField reports whether two schedules match the same values in the
+// field at index i
+func sameField(i int, a, b *schedule) bool {
+	switch i {
+	case minuteIndex:
+		return a.minute == b.minute
+	case hourIndex:
+		return a.hour == b.hour
+	case dayOfMonthIndex:
+		return a.dom == b.dom && a.domFromEnd == b.domFromEnd && a.domLastWeekday == b.domLastWeekday
+	case monthIndex:
+		return a.month == b.month
+	default:
+		return a.dow == b.dow && a.dowNth == b.dowNth
+	}
+}
+
+// dayPattern returns the schedule without its times of day
+func dayPattern(s *schedule) schedule {
+	days := *s
+	days.second, days.minute, days.hour = 0, 0, 0
+	return days
+}
+
+// Empty reports whether the expressions are equivalent
+func (r Report) Empty() bool {
+	return len(r.Changes) == 0 && !r.HasShift && !r.DaysChanged
+}
+
+// String renders the report one difference per line, or "no changes"
+func (r Report) String() string {
+	if r.Empty() {
+		return "no changes"
+	}
+
+	var lines []string
+	for _, c := range r.Changes {
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", c.Field, c.Old, c.New))
+	}
+	if r.HasShift {
+		direction := "later"
+		d := r.Shift
+		if d < 0 {
+			direction, d = "earlier", -d
+		}
+		lines = append(lines, fmt.Sprintf("moved %s %s", describeDuration(d), direction))
+	}
+	if r.DaysChanged {
+		lines = append(lines, "day pattern changed")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// describeDuration writes a whole number of minutes in words, such as
+// "1 hour 30 minutes"
+func describeDuration(d time.Duration) string {
+	unit := func(n int, name string) string {
+		if n == 1 {
+			return "1 " + name
+		}
+		return fmt.Sprintf("%d %ss", n, name)
+	}
+
+	hours, minutes := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case hours == 0:
+		return unit(minutes, "minute")
+	case minutes == 0:
+		return unit(hours, "hour")
+	}
+	return unit(hours, "hour") + " " + unit(minutes, "minute")
+}
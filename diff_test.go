@@ -0,0 +1,148 @@
+package cronmath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     Report
+		wantText string
+	}{
+		{
+			name:     "identical",
+			old:      "30 9 * * 1-5",
+			new:      "30 9 * * 1-5",
+			want:     Report{},
+			wantText: "no changes",
+		},
+		{
+			name:     "identical after normalization",
+			old:      "*/15 09 * jan MON-FRI",
+			new:      "0,15,30,45 9 * 1 1-5",
+			want:     Report{},
+			wantText: "no changes",
+		},
+		{
+			name: "earlier",
+			old:  "30 9 * * *",
+			new:  "45 8 * * *",
+			want: Report{
+				Changes: []FieldChange{{"minute", "30", "45"}, {"hour", "9", "8"}},
+				Shift:   -Minutes(45), HasShift: true,
+			},
+			wantText: "minute: 30 -> 45\nhour: 9 -> 8\nmoved 45 minutes earlier",
+		},
+		{
+			name: "later across midnight",
+			old:  "0 23 * * *",
+			new:  "30 0 * * *",
+			want: Report{
+				Changes: []FieldChange{{"minute", "0", "30"}, {"hour", "23", "0"}},
+				Shift:   Minutes(90), HasShift: true,
+			},
+			wantText: "minute: 0 -> 30\nhour: 23 -> 0\nmoved 1 hour 30 minutes later",
+		},
+		{
+			name: "days changed",
+			old:  "0 9 * * 1-5",
+			new:  "0 9 * * 1-6",
+			want: Report{
+				Changes:     []FieldChange{{"day of week", "1-5", "1-6"}},
+				DaysChanged: true,
+			},
+			wantText: "day of week: 1-5 -> 1-6\nday pattern changed",
+		},
+		{
+			name: "day of month restriction changes the day rule",
+			old:  "0 9 * * 1",
+			new:  "0 9 1-31 * 1",
+			want: Report{
+				Changes:     []FieldChange{{"day of month", "*", "1-31"}},
+				DaysChanged: true,
+			},
+			wantText: "day of month: * -> 1-31\nday pattern changed",
+		},
+		{
+			name: "seconds changed",
+			old:  "0 0 9 * * *",
+			new:  "30 0 9 * * *",
+			want: Report{
+				Changes: []FieldChange{{"second", "0", "30"}},
+			},
+			wantText: "second: 0 -> 30",
+		},
+		{
+			name:     "seconds written out",
+			old:      "0 9 * * *",
+			new:      "0 0 9 * * *",
+			want:     Report{},
+			wantText: "no changes",
+		},
+		{
+			name: "year changed",
+			old:  "0 0 9 1 1 ? 2025",
+			new:  "0 0 9 1 1 ? 2026",
+			want: Report{
+				Changes:     []FieldChange{{"year", "2025", "2026"}},
+				DaysChanged: true,
+			},
+			wantText: "year: 2025 -> 2026\nday pattern changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, _ := ParseCronWith(tt.old, WithAutoFields())
+			new, _ := ParseCronWith(tt.new, WithAutoFields())
+			got := DiffReport(old, new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffReport() = %+v, want %+v", got, tt.want)
+			}
+			if got.Empty() != tt.want.Empty() {
+				t.Errorf("Empty() = %v, want %v", got.Empty(), tt.want.Empty())
+			}
+			if text := got.String(); text != tt.wantText {
+				t.Errorf("String() = %q, want %q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"*/15 * * * *", "0,15,30,45 * * * *", true},
+		{"0 9 1-31 * 1", "0 9 * * *", true},
+		{"0 9 * * MON-FRI", "0 9 * * 1-5", true},
+		{"@daily", "0 0 * * *", true},
+		{"@reboot", "@reboot", true},
+		{"0 9 13 * 5", "0 9 13 * *", false},
+		{"0 9 * * *", "0 10 * * *", false},
+		{"@reboot", "0 0 * * *", false},
+		{"0 0 9 1 1 ? 2025-2026", "0 0 9 1 1 ? 2025,2026", true},
+		{"0 0 9 * * ? *", "0 0 9 * * ?", true},
+		{"0 0 9 1 1 ? 2025", "0 0 9 1 1 ? 2026", false},
+		{"0 0 9 1 1 ? 1990", "0 0 9 1 1 ? 1991", false},
+		{"0 0 9 1 1 ? 2025", "0 0 9 1 1 ?", false},
+	}
+
+	for _, tt := range tests {
+		a, _ := ParseCronWith(tt.a, WithAutoFields())
+		b, _ := ParseCronWith(tt.b, WithAutoFields())
+		if got, err := Equivalent(a, b); err != nil || got != tt.want {
+			t.Errorf("Equivalent(%q, %q) = %v, %v, want %v", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+
+	invalid, _ := ParseCron("0 25 * * *")
+	valid, _ := ParseCron("0 9 * * *")
+	if _, err := Equivalent(valid, invalid); err == nil {
+		t.Error("Equivalent() with an invalid expression returned no error")
+	}
+}
@@ -0,0 +1,159 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidExpression is returned, wrapped, when ParseCron and friends
+// reject an expression
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// ErrorKind classifies the errors of the package, as reported by KindOf
+type ErrorKind string
+
+const (
+	// KindParse is an expression or field that does not parse
+	KindParse ErrorKind = "parse"
+	// KindOverflow is a shift too large to compute, see OverflowError
+	KindOverflow ErrorKind = "overflow"
+	// KindWildcard is a shift of a wildcard minute, or one carrying into
+	// a wildcard hour, see ErrWildcardMinute and ErrWildcardCarry
+	KindWildcard ErrorKind = "wildcard"
+	// KindNotShiftable is a shift of "@reboot", see ErrNotShiftable
+	KindNotShiftable ErrorKind = "not-shiftable"
+	// KindNeverFires is an expression that matches no date, see
+	// ErrNeverFires
+	KindNeverFires ErrorKind = "never-fires"
+	// KindDayBoundary is a shift across midnight refused under
+	// WithNoWrap, see ErrDayBoundary
+	KindDayBoundary ErrorKind = "day-boundary"
+	// KindPinned is an operation refused for changing a field pinned
+	// with CronMath.Pin, see ErrPinnedFieldWouldChange
+	KindPinned ErrorKind = "pinned"
+	// KindNotRepresentable is a result that cron syntax cannot express,
+	// see ErrNotRepresentable, ErrNoRRule, ErrNoQuartz and ErrNoFiveField
+	KindNotRepresentable ErrorKind = "not-representable"
+	// KindOther is any other error, such as a shift the fields cannot
+	// follow
+	KindOther ErrorKind = "other"
+)
+
+// KindOf classifies err, looking through wrapped errors, or returns ""
+// for a nil error
+func KindOf(err error) ErrorKind {
+	var (
+		pe *ParseError
+		oe *OverflowError
+	)
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotShiftable):
+		return KindNotShiftable
+	case errors.Is(err, ErrWildcardCarry), errors.Is(err, ErrWildcardMinute):
+		return KindWildcard
+	case errors.As(err, &oe):
+		return KindOverflow
+	case errors.Is(err, ErrDayBoundary):
+		return KindDayBoundary
+	case errors.Is(err, ErrPinnedFieldWouldChange):
+		return KindPinned
+	case errors.Is(err, ErrNeverFires):
+		return KindNeverFires
+	case errors.Is(err, ErrNotRepresentable), errors.Is(err, ErrNoRRule), errors.Is(err, ErrNoQuartz), errors.Is(err, ErrNoFiveField):
+		return KindNotRepresentable
+	case errors.As(err, &pe), errors.Is(err, ErrInvalidExpression):
+		return KindParse
+	}
+	return KindOther
+}
+
+// ParseError reports a field of an expression that does not parse. It is
+// returned, possibly wrapped, wherever a field is parsed, so errors.As
+// extracts it to point at the offending token.
+type ParseError struct {
+	// Expr is the expression the field belongs to
+	Expr string
+	// Index is the zero-based position of the field in Expr, counting a
+	// leading seconds field
+	Index int
+	// Field names the field, e.g. "hour"
+	Field string
+	// Value is the field as written
+	Value string
+	Err   error
+}
+
+// Error renders the error as "invalid hour, field 2: cause", numbering
+// fields from 1
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid %s, field %d: %v", e.Field, e.Index+1, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError returns a *ParseError for err, from parsing the field at
+// index i, one of the standard field indexes, secondIndex or yearIndex
+func (c *CronTime) fieldError(i int, err error) *ParseError {
+	e := &ParseError{Expr: c.rawString(), Index: i, Err: err}
+	switch i {
+	case secondIndex:
+		e.Index, e.Field, e.Value = 0, secondField.name, c.Second
+	case yearIndex:
+		e.Index, e.Field, e.Value = 5, yearField.name, c.Year
+		if c.layout.hasSeconds() {
+			e.Index++
+		}
+	default:
+		e.Field, e.Value = standardFields[i].name, *c.fieldPtrs()[i]
+		if c.layout.hasSeconds() {
+			e.Index++
+		}
+	}
+	return e
+}
+
+// parseError is fieldError for the expression expr was parsed into,
+// reporting expr as written
+func (c *CronTime) parseError(expr string, i int, err error) *ParseError {
+	e := c.fieldError(i, err)
+	e.Expr = expr
+	return e
+}
+
+// rawString returns every field of c as written, without the formatting
+// applied by String
+func (c *CronTime) rawString() string {
+	switch c.layout {
+	case LayoutSeconds:
+		return c.Second + " " + c.fieldString()
+	case LayoutSecondsYear:
+		return c.Second + " " + c.fieldString() + " " + c.Year
+	case LayoutYear:
+		return c.fieldString() + " " + c.Year
+	}
+	return c.fieldString()
+}
+
+// OpError reports a CronMath operation that failed, and the expression it
+// was applied to
+type OpError struct {
+	// Op is the operation as a call, e.g. "Add(15m0s)"
+	Op   string
+	Expr string
+	Err  error
+}
+
+// Error renders the error as `Add(15m0s) on "*/5 9 * * *": cause`
+func (e *OpError) Error() string {
+	return fmt.Sprintf("%s on %q: %v", e.Op, e.Expr, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
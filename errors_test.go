@@ -0,0 +1,190 @@
+package cronmath
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name      string
+		cronStr   string
+		opts      []Option
+		op        func(*CronTime) error
+		wantExpr  string
+		wantIndex int
+		wantField string
+		wantValue string
+	}{
+		{
+			name:      "strict hour",
+			cronStr:   "0 9-25 * * *",
+			opts:      []Option{WithStrict()},
+			wantExpr:  "0 9-25 * * *",
+			wantIndex: 1,
+			wantField: "hour",
+			wantValue: "9-25",
+		},
+		{
+			name:      "seconds",
+			cronStr:   "61 0 9 * * *",
+			opts:      []Option{WithAutoFields()},
+			wantExpr:  "61 0 9 * * *",
+			wantIndex: 0,
+			wantField: "second",
+			wantValue: "61",
+		},
+		{
+			name:      "strict day of week after seconds",
+			cronStr:   "0 0 9 * * MON-XYZ",
+			opts:      []Option{WithAutoFields(), WithStrict()},
+			wantExpr:  "0 0 9 * * MON-XYZ",
+			wantIndex: 5,
+			wantField: "day of week",
+			wantValue: "MON-XYZ",
+		},
+		{
+			name:      "minute on add",
+			cronStr:   "a 9 * * *",
+			op:        func(c *CronTime) error { return c.Add(Minutes(5)) },
+			wantExpr:  "a 9 * * *",
+			wantIndex: 0,
+			wantField: "minute",
+			wantValue: "a",
+		},
+		{
+			name:      "day of month on schedule",
+			cronStr:   "0 9 32 * *",
+			op:        func(c *CronTime) error { _, err := c.RunsPerDay(); return err },
+			wantExpr:  "0 9 32 * *",
+			wantIndex: 2,
+			wantField: "day of month",
+			wantValue: "32",
+		},
+		{
+			name:      "dialect",
+			cronStr:   "0 9 * 13 *",
+			op:        func(c *CronTime) error { return c.ValidateFor(Vixie) },
+			wantExpr:  "0 9 * 13 *",
+			wantIndex: 3,
+			wantField: "month",
+			wantValue: "13",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if tt.op != nil {
+				if err != nil {
+					t.Fatalf("ParseCronWith() error = %v", err)
+				}
+				err = tt.op(c)
+			}
+
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error = %v, want a *ParseError", err)
+			}
+			if pe.Expr != tt.wantExpr || pe.Index != tt.wantIndex || pe.Field != tt.wantField || pe.Value != tt.wantValue {
+				t.Errorf("ParseError = {%q %d %q %q}, want {%q %d %q %q}",
+					pe.Expr, pe.Index, pe.Field, pe.Value, tt.wantExpr, tt.wantIndex, tt.wantField, tt.wantValue)
+			}
+			if pe.Err == nil || errors.Unwrap(pe) != pe.Err {
+				t.Errorf("ParseError.Err = %v, want the cause", pe.Err)
+			}
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	err := &ParseError{Expr: "0 25 * * *", Index: 1, Field: "hour", Value: "25", Err: errors.New("value 25 out of range [0, 23]")}
+	if got, want := err.Error(), "invalid hour, field 2: value 25 out of range [0, 23]"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestOpError(t *testing.T) {
+	cm := New("30 * * * *").Sub(Minutes(5)).Add(Minutes(45)).Add(Hours(1))
+
+	err := cm.Error()
+	if !errors.Is(err, ErrWildcardCarry) {
+		t.Fatalf("Error() = %v, want ErrWildcardCarry", err)
+	}
+	var opErr *OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Error() = %v, want an *OpError", err)
+	}
+	if opErr.Op != "Add(45m0s)" || opErr.Expr != "25 * * * *" {
+		t.Errorf("OpError = {%q %q}, want {%q %q}", opErr.Op, opErr.Expr, "Add(45m0s)", "25 * * * *")
+	}
+	if want := "error: " + err.Error(); cm.String() != want {
+		t.Errorf("String() = %q, want %q", cm.String(), want)
+	}
+}
+
+func TestOpError_WrapsParseError(t *testing.T) {
+	err := New("a 9 * * *").Add(Minutes(5)).Error()
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Field != "minute" {
+		t.Fatalf("Error() = %v, want a *ParseError for the minute", err)
+	}
+	if want := `Add(5m0s) on "a 9 * * *": invalid minute, field 1: `; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("Error() = %q, want prefix %q", err.Error(), want)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	never, _ := ParseCron("0 0 30 2 *")
+	_, neverErr := never.Next(time.Now())
+	_, rruleErr := mustParse(t, "0 9 1 * MON").ToRRule()
+	_, quartzErr := mustParse(t, "0 9 1 * MON").ToQuartz()
+	_, fiveFieldErr := FromQuartz("30 0 9 * * ?")
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"nil", nil, ""},
+		{"field count", New("0 9 * *").Error(), KindParse},
+		{"bad field", New("a 9 * * *").Add(Minutes(5)).Error(), KindParse},
+		{"strict", func() error { _, err := ParseCronWith("0 25 * * *", WithStrict()); return err }(), KindParse},
+		{"wildcard", New("30 * * * *").Add(Minutes(45)).Error(), KindWildcard},
+		{"wildcard minute", New("* 9 * * *").Add(Minutes(1)).Error(), KindWildcard},
+		{"overflow", New("0 9 * * *").Add(time.Duration(math.MaxInt64)).Error(), KindOverflow},
+		{"reboot", New("@reboot").Sub(Hours(1)).Error(), KindNotShiftable},
+		{"day boundary", New("30 23 * * *", WithNoWrap()).Add(Hours(1)).Error(), KindDayBoundary},
+		{"pinned", New("50 9 * * *").Pin(Hour).Add(Minutes(15)).Error(), KindPinned},
+		{"never fires", neverErr, KindNeverFires},
+		{"no rrule", rruleErr, KindNotRepresentable},
+		{"no quartz", quartzErr, KindNotRepresentable},
+		{"no five fields", fiveFieldErr, KindNotRepresentable},
+		{"bad quartz", func() error { _, err := FromQuartz("0 0 9 * * 1"); return err }(), KindParse},
+		{"other", New("0,30 9 * * *").Add(Minutes(45)).Error(), KindOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want != "" && tt.err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if got := KindOf(tt.err); got != tt.want {
+				t.Errorf("KindOf(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrInvalidExpression(t *testing.T) {
+	_, err := ParseCron("0 9 * *")
+	if !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("ParseCron() error = %v, want ErrInvalidExpression", err)
+	}
+	if want := "invalid cron expression: expected 5 fields, got 4"; err.Error() != want {
+		t.Errorf("ParseCron() error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,522 @@
+package cronmath
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fieldSpec describes the legal values of a cron field
+type fieldSpec struct {
+	name     string
+	min, max int
+
+	// names optionally lists the symbolic names of the values, starting
+	// with the name of min
+	names []string
+
+	// sundaySeven accepts max+1 as an alias for min, i.e. 7 for Sunday
+	sundaySeven bool
+
+	// noSpecific accepts Quartz's "?", meaning no specific value, as an
+	// alias for "*"
+	noSpecific bool
+}
+
+var (
+	secondField     = fieldSpec{name: "second", min: 0, max: 59}
+	minuteField     = fieldSpec{name: "minute", min: 0, max: 59}
+	hourField       = fieldSpec{name: "hour", min: 0, max: 23}
+	dayOfMonthField = fieldSpec{name: "day of month", min: 1, max: 31, noSpecific: true}
+	monthField      = fieldSpec{name: "month", min: 1, max: 12, names: monthNames}
+	dayOfWeekField  = fieldSpec{name: "day of week", min: 0, max: 6, names: dayOfWeekNames, sundaySeven: true, noSpecific: true}
+	yearField       = fieldSpec{name: "year", min: minYear, max: maxYear}
+)
+
+var (
+	monthNames     = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	dayOfWeekNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// Indexes of the standard fields in expression order
+const (
+	minuteIndex = iota
+	hourIndex
+	dayOfMonthIndex
+	monthIndex
+	dayOfWeekIndex
+
+	// secondIndex numbers the optional seconds field after the standard
+	// ones, although it comes first in an expression
+	secondIndex
+
+	// yearIndex numbers the optional year field
+	yearIndex
+)
+
+// standardFields lists the five standard cron fields in expression order
+var standardFields = [...]fieldSpec{minuteField, hourField, dayOfMonthField, monthField, dayOfWeekField}
+
+// fieldPtrs returns pointers to the five standard fields of c in
+// expression order
+func (c *CronTime) fieldPtrs() [5]*string {
+	return [5]*string{&c.Minute, &c.Hour, &c.DayOfMonth, &c.Month, &c.DayOfWeek}
+}
+
+// valueSet is a bitmap of the values a cron field matches. Bit n is set
+// when the field matches value n; every field fits into 64 bits.
+type valueSet uint64
+
+// fullSet returns the set containing every legal value of the field
+func (f fieldSpec) fullSet() valueSet {
+	return rangeSet(f.min, f.max, 1)
+}
+
+// rangeSet returns the set lo, lo+step, ... up to and including hi
+func rangeSet(lo, hi, step int) valueSet {
+	var s valueSet
+	for v := lo; v <= hi; v += step {
+		s |= 1 << uint(v)
+	}
+	return s
+}
+
+// has reports whether v is in the set
+func (s valueSet) has(v int) bool {
+	return v >= 0 && v < 64 && s&(1<<uint(v)) != 0
+}
+
+// len returns the number of values in the set
+func (s valueSet) len() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// values returns the members of the set in ascending order
+func (s valueSet) values() []int {
+	vals := make([]int, 0, s.len())
+	for rest := uint64(s); rest != 0; rest &= rest - 1 {
+		vals = append(vals, bits.TrailingZeros64(rest))
+	}
+	return vals
+}
+
+// rotateSet moves every value of the set n places forward within the
+// field's range, wrapping around at the ends
+func rotateSet(s valueSet, n int, f fieldSpec) valueSet {
+	size := f.max - f.min + 1
+	n = (n%size + size) % size
+
+	var rotated valueSet
+	for _, v := range s.values() {
+		rotated |= 1 << uint(f.min+(v-f.min+n)%size)
+	}
+	return rotated
+}
+
+// parseValue parses a single numeric or named field value and checks its
+// range
+func (f fieldSpec) parseValue(s string) (int, error) {
+	if i := f.nameIndex(s); i >= 0 {
+		return f.min + i, nil
+	}
+
+	val, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported field format: %s", s)
+	}
+
+	if val < f.min || val > f.max && !(f.sundaySeven && val == f.max+1) {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", val, f.min, f.max)
+	}
+
+	return val, nil
+}
+
+// nameIndex returns the index of s among the names of the field, in any
+// case, or -1
+func (f fieldSpec) nameIndex(s string) int {
+	return slices.IndexFunc(f.names, func(name string) bool { return strings.EqualFold(name, s) })
+}
+
+// parseSet expands a cron field (values, ranges, steps and lists of
+// those) into the set of values it matches
+func parseSet(field string, f fieldSpec) (valueSet, error) {
+	var set valueSet
+	for _, part := range strings.Split(field, ",") {
+		s, err := parseSetPart(part, f)
+		if err != nil {
+			return 0, err
+		}
+		set |= s
+	}
+	return set, nil
+}
+
+// monthDays is an expanded day-of-month field
+type monthDays struct {
+	// days holds the days counted from the start of the month
+	days valueSet
+
+	// fromEnd holds the days counted back from the end of the month,
+	// where bit n stands for "L-n"
+	fromEnd valueSet
+
+	// lastWeekday is set by "LW", the last Monday to Friday of the month
+	lastWeekday bool
+}
+
+// countsFromEnd reports whether any of the days depend on the length of
+// the month
+func (m monthDays) countsFromEnd() bool {
+	return m.fromEnd != 0 || m.lastWeekday
+}
+
+// parseDayOfMonth expands a day-of-month field. Besides the usual syntax
+// it may count back from the end of the month: "L" is the last day of the
+// month, "L-n" the day n days before it and "LW" the last weekday.
+func parseDayOfMonth(field string) (monthDays, error) {
+	var m monthDays
+	for _, part := range strings.Split(field, ",") {
+		if part == "LW" {
+			m.lastWeekday = true
+			continue
+		}
+		if n, ok, err := parseFromEnd(part); ok {
+			if err != nil {
+				return monthDays{}, err
+			}
+			m.fromEnd |= 1 << uint(n)
+			continue
+		}
+
+		s, err := parseSetPart(part, dayOfMonthField)
+		if err != nil {
+			return monthDays{}, err
+		}
+		m.days |= s
+	}
+	return m, nil
+}
+
+// maxFromEnd is the largest n allowed in "L-n", keeping it within even
+// the shortest month
+const maxFromEnd = 27
+
+// parseFromEnd parses an "L" or "L-n" element, reporting whether part is
+// one at all
+func parseFromEnd(part string) (int, bool, error) {
+	rest, ok := strings.CutPrefix(part, "L")
+	if !ok {
+		return 0, false, nil
+	}
+	if rest == "" {
+		return 0, true, nil
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(rest, "-"))
+	if err != nil || !strings.HasPrefix(rest, "-") || n < 0 || n > maxFromEnd {
+		return 0, true, fmt.Errorf("invalid last-day offset in day of month field: %s", part)
+	}
+	return n, true, nil
+}
+
+// format renders the days, listing those counted from the end of the
+// month after the others
+func (m monthDays) format() string {
+	var parts []string
+	if m.days != 0 {
+		parts = append(parts, formatRestricted(m.days, dayOfMonthField))
+	}
+
+	offsets := m.fromEnd.values()
+	for i := len(offsets) - 1; i >= 0; i-- {
+		if offsets[i] == 0 {
+			parts = append(parts, "L")
+		} else {
+			parts = append(parts, fmt.Sprintf("L-%d", offsets[i]))
+		}
+	}
+	if m.lastWeekday {
+		parts = append(parts, "LW")
+	}
+	return strings.Join(parts, ",")
+}
+
+// maxNth is the largest n allowed in "dow#n"; no weekday occurs a sixth
+// time in a month
+const maxNth = 5
+
+// nthBit returns the bit standing for "weekday#n" in a set of nth
+// weekdays
+func nthBit(weekday, n int) valueSet {
+	return 1 << uint(weekday*8+n)
+}
+
+// parseDayOfWeek expands a day-of-week field. Besides the usual syntax it
+// may name the nth occurrence of a weekday in the month, as in "1#2" for
+// the second Monday. Those elements are returned separately in nth, with
+// one bit per weekday and n as given by nthBit.
+func parseDayOfWeek(field string) (days, nth valueSet, err error) {
+	for _, part := range strings.Split(field, ",") {
+		if dow, nStr, ok := strings.Cut(part, "#"); ok {
+			weekday, err := dayOfWeekField.parseValue(dow)
+			if err != nil {
+				return 0, 0, err
+			}
+			n, err := strconv.Atoi(nStr)
+			if err != nil || n < 1 || n > maxNth {
+				return 0, 0, fmt.Errorf("invalid occurrence in day of week field: %s", part)
+			}
+			nth |= nthBit(weekday%7, n)
+			continue
+		}
+
+		s, err := parseSetPart(part, dayOfWeekField)
+		if err != nil {
+			return 0, 0, err
+		}
+		days |= s
+	}
+	return days, nth, nil
+}
+
+// formatNth renders a set of nth weekdays as "dow#n" elements, in order
+// of weekday
+func formatNth(nth valueSet) []string {
+	var parts []string
+	for _, bit := range nth.values() {
+		parts = append(parts, fmt.Sprintf("%d#%d", bit/8, bit%8))
+	}
+	return parts
+}
+
+// parseStandardField parses the standard field at index i, returning an
+// error if it is invalid
+func parseStandardField(i int, field string) error {
+	switch i {
+	case dayOfMonthIndex:
+		_, err := parseDayOfMonth(field)
+		return err
+	case dayOfWeekIndex:
+		_, _, err := parseDayOfWeek(field)
+		return err
+	}
+	_, err := parseSet(field, standardFields[i])
+	return err
+}
+
+// parseSetPart expands one comma-separated element of a cron field
+func parseSetPart(part string, f fieldSpec) (valueSet, error) {
+	lo, hi, step, err := parseRange(part, f)
+	if err != nil {
+		return 0, err
+	}
+
+	set := rangeSet(lo, hi, step)
+	if f.sundaySeven && set.has(f.max+1) {
+		set = set&^(1<<uint(f.max+1)) | 1<<uint(f.min)
+	}
+	return set, nil
+}
+
+// parseRange parses one comma-separated element of a cron field into the
+// bounds and step it covers, so "*/15" in the minute field gives 0, 59
+// and 15, and a single value v gives v, v and 1. A range ending on Sunday
+// may end at 7.
+func parseRange(part string, f fieldSpec) (lo, hi, step int, err error) {
+	base, stepStr, hasStep := strings.Cut(part, "/")
+
+	step = 1
+	if hasStep {
+		n, err := strconv.ParseInt(stepStr, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, 0, fmt.Errorf("invalid step in %s field: %s", f.name, part)
+		}
+		// Any step past the width of the field picks only the first value,
+		// and clamping it keeps 32-bit platforms from overflowing
+		step = int(min(n, int64(f.max-f.min+2)))
+	}
+
+	if base == "?" && f.noSpecific && !hasStep {
+		base = "*"
+	}
+
+	lo, hi = f.min, f.max
+	if base != "*" {
+		loStr, hiStr, isRange := strings.Cut(base, "-")
+
+		if lo, err = f.parseValue(loStr); err != nil {
+			return 0, 0, 0, err
+		}
+
+		switch {
+		case isRange:
+			if hi, err = f.parseValue(hiStr); err != nil {
+				return 0, 0, 0, err
+			}
+			if f.sundaySeven && hi == f.min && lo > hi {
+				// A range ending on Sunday, such as FRI-SUN
+				hi = f.max + 1
+			}
+			if lo > hi {
+				return 0, 0, 0, fmt.Errorf("invalid range in %s field: %s", f.name, part)
+			}
+		case !hasStep:
+			hi = lo
+		}
+	}
+
+	// Keep huge steps from overflowing the loop in rangeSet
+	if step > hi-lo {
+		step = hi - lo + 1
+	}
+
+	return lo, hi, step, nil
+}
+
+// formatSet renders a non-empty set in the most compact cron syntax.
+// A list of values and ranges is preferred over step syntax unless the
+// step form is strictly shorter.
+func formatSet(s valueSet, f fieldSpec) string {
+	if s == f.fullSet() {
+		return "*"
+	}
+
+	best := formatRuns(s)
+	if step, ok := formatStep(s, f); ok && len(step) < len(best) {
+		best = step
+	}
+	return best
+}
+
+// formatRestricted renders a set like formatSet but never with a leading
+// "*", so a day field keeps counting as restricted under cron's
+// day-of-month/day-of-week OR rule
+func formatRestricted(s valueSet, f fieldSpec) string {
+	if str := formatSet(s, f); isRestricted(str) {
+		return str
+	}
+
+	best := formatRuns(s)
+	if vals := s.values(); len(vals) >= 2 {
+		if _, ok := formatStep(s, f); ok {
+			first, last := vals[0], vals[len(vals)-1]
+			if step := fmt.Sprintf("%d-%d/%d", first, last, vals[1]-first); len(step) < len(best) {
+				best = step
+			}
+		}
+	}
+	return best
+}
+
+// formatNamedSet renders a set like formatRuns, using the field's names
+// instead of numbers
+func formatNamedSet(s valueSet, f fieldSpec) string {
+	var b strings.Builder
+	number := 0
+	flush := func() {
+		b.WriteString(f.names[number-f.min])
+		number = 0
+	}
+
+	started := false
+	for _, r := range formatRuns(s) {
+		if isDigit(byte(r)) {
+			number = number*10 + int(r-'0')
+			started = true
+			continue
+		}
+		if started {
+			flush()
+			started = false
+		}
+		b.WriteRune(r)
+	}
+	if started {
+		flush()
+	}
+	return b.String()
+}
+
+// formatRuns renders a set as a list, collapsing runs of three or more
+// consecutive values into ranges
+func formatRuns(s valueSet) string {
+	vals := s.values()
+
+	var b strings.Builder
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		switch {
+		case j-i >= 2:
+			fmt.Fprintf(&b, "%d-%d", vals[i], vals[j])
+		case j-i == 1:
+			fmt.Fprintf(&b, "%d,%d", vals[i], vals[j])
+		default:
+			b.WriteString(strconv.Itoa(vals[i]))
+		}
+		i = j + 1
+	}
+	return b.String()
+}
+
+// formatStep renders a set as "*/n" or "a-b/n" when its values form an
+// arithmetic progression of at least two values
+func formatStep(s valueSet, f fieldSpec) (string, bool) {
+	vals := s.values()
+	if len(vals) < 2 {
+		return "", false
+	}
+
+	step := vals[1] - vals[0]
+	for i := 2; i < len(vals); i++ {
+		if vals[i]-vals[i-1] != step {
+			return "", false
+		}
+	}
+
+	first, last := vals[0], vals[len(vals)-1]
+	if first == f.min && last+step > f.max {
+		return fmt.Sprintf("*/%d", step), true
+	}
+	return fmt.Sprintf("%d-%d/%d", first, last, step), true
+}
+
+// formatDayOfWeek renders a day-of-week set like formatSet, writing
+// Sunday as 7 instead of 0 when seven is set
+func formatDayOfWeek(s valueSet, seven bool) string {
+	if !seven || !s.has(0) || s == dayOfWeekField.fullSet() {
+		return formatSet(s, dayOfWeekField)
+	}
+
+	// Render over 1-7 so Sunday sorts last; step syntax anchored at "*"
+	// would mean something else there, so it is never used
+	sundayLast := fieldSpec{name: dayOfWeekField.name, min: 1, max: 7}
+	return formatRestricted(s&^1|1<<7, sundayLast)
+}
+
+// isRestricted reports whether a day field restricts the days a schedule
+// fires on. Following Vixie cron, a field is unrestricted only when it
+// starts with "*", or is Quartz's "?".
+func isRestricted(field string) bool {
+	return !strings.HasPrefix(field, "*") && field != "?"
+}
+
+// limitsDays reports whether a day field of spec f leaves out any day.
+// Unlike isRestricted, which reads the text as Vixie cron does, it counts
+// a step such as "*/2" as leaving days out.
+func limitsDays(field string, f fieldSpec) bool {
+	if field == "?" {
+		return false
+	}
+	s, err := parseSet(field, f)
+	return err != nil || s != f.fullSet()
+}
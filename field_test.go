@@ -0,0 +1,153 @@
+package cronmath
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		spec    fieldSpec
+		want    []int
+		wantErr bool
+	}{
+		{"single value", "5", minuteField, []int{5}, false},
+		{"wildcard", "*", dayOfWeekField, []int{0, 1, 2, 3, 4, 5, 6}, false},
+		{"range", "9-12", hourField, []int{9, 10, 11, 12}, false},
+		{"list", "0,30,15", minuteField, []int{0, 15, 30}, false},
+		{"step", "*/6", hourField, []int{0, 6, 12, 18}, false},
+		{"range with step", "10-40/10", minuteField, []int{10, 20, 30, 40}, false},
+		{"value with step", "50/5", minuteField, []int{50, 55}, false},
+		{"mixed list", "1-3,10,20-22", dayOfMonthField, []int{1, 2, 3, 10, 20, 21, 22}, false},
+		{"huge step", "*/9223372036854775807", minuteField, []int{0}, false},
+		{"out of range", "60", minuteField, nil, true},
+		{"below range", "0", dayOfMonthField, nil, true},
+		{"reversed range", "17-9", hourField, nil, true},
+		{"zero step", "*/0", minuteField, nil, true},
+		{"empty element", "1,,2", minuteField, nil, true},
+		{"garbage", "abc", minuteField, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSet(tt.field, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSet(%q) error = %v, wantErr %v", tt.field, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.values(), tt.want) {
+				t.Errorf("parseSet(%q) = %v, want %v", tt.field, got.values(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSet(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+		spec fieldSpec
+		want string
+	}{
+		{"single value", []int{5}, minuteField, "5"},
+		{"full set", []int{0, 1, 2, 3, 4, 5, 6}, dayOfWeekField, "*"},
+		{"two values", []int{9, 10}, hourField, "9,10"},
+		{"contiguous run", []int{9, 10, 11, 12, 13, 14, 15, 16, 17}, hourField, "9-17"},
+		{"step from minimum", []int{0, 15, 30, 45}, minuteField, "*/15"},
+		{"tie prefers list", []int{0, 30}, minuteField, "0,30"},
+		{"offset step", []int{5, 20, 35, 50}, minuteField, "5-50/15"},
+		{"runs and values", []int{1, 2, 3, 10, 20, 21, 22}, dayOfMonthField, "1-3,10,20-22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s valueSet
+			for _, v := range tt.vals {
+				s |= 1 << uint(v)
+			}
+			if got := formatSet(s, tt.spec); got != tt.want {
+				t.Errorf("formatSet(%v) = %q, want %q", tt.vals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSet_NameCasing(t *testing.T) {
+	casings := []struct {
+		name  string
+		apply func(string) string
+	}{
+		{"upper", strings.ToUpper},
+		{"lower", strings.ToLower},
+		{"title", func(s string) string { return s[:1] + strings.ToLower(s[1:]) }},
+	}
+
+	fields := []struct {
+		spec  fieldSpec
+		names []string
+	}{
+		{monthField, []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+		{dayOfWeekField, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+	}
+
+	for _, field := range fields {
+		for i, name := range field.names {
+			for _, casing := range casings {
+				token := casing.apply(name)
+				t.Run(field.spec.name+"/"+token, func(t *testing.T) {
+					got, err := parseSet(token, field.spec)
+					if err != nil {
+						t.Fatalf("parseSet(%q) error = %v", token, err)
+					}
+					if want := []int{field.spec.min + i}; !reflect.DeepEqual(got.values(), want) {
+						t.Errorf("parseSet(%q) = %v, want %v", token, got.values(), want)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestParseSet_MixedCaseRangesAndLists(t *testing.T) {
+	tests := []struct {
+		field string
+		spec  fieldSpec
+		want  []int
+	}{
+		{"Mon-fri,SAT", dayOfWeekField, []int{1, 2, 3, 4, 5, 6}},
+		{"sun,Wed", dayOfWeekField, []int{0, 3}},
+		{"fri-Sun", dayOfWeekField, []int{0, 5, 6}},
+		{"jan-Mar,oct", monthField, []int{1, 2, 3, 10}},
+		{"Jun-aug/2", monthField, []int{6, 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, err := parseSet(tt.field, tt.spec)
+			if err != nil {
+				t.Fatalf("parseSet(%q) error = %v", tt.field, err)
+			}
+			if !reflect.DeepEqual(got.values(), tt.want) {
+				t.Errorf("parseSet(%q) = %v, want %v", tt.field, got.values(), tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRestricted_QuartzNoSpecific(t *testing.T) {
+	if isRestricted("?") {
+		t.Error(`isRestricted("?") = true, want false`)
+	}
+	s, err := parseSet("?", dayOfWeekField)
+	if err != nil || s != dayOfWeekField.fullSet() {
+		t.Errorf(`parseSet("?") = %v, %v, want every day`, s, err)
+	}
+	if _, err := parseSet("?", hourField); err == nil {
+		t.Error(`parseSet("?") expected error for the hour field, got nil`)
+	}
+}
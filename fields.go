@@ -0,0 +1,126 @@
+package cronmath
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FieldKind is the syntax a field is written in
+type FieldKind int
+
+const (
+	// FieldWildcard is "*", or "?" in the day fields
+	FieldWildcard FieldKind = iota
+	// FieldValue is a single value, such as "9" or "MON"
+	FieldValue
+	// FieldList is a comma-separated list, such as "0,30"
+	FieldList
+	// FieldRange is a range, such as "9-17"
+	FieldRange
+	// FieldStep is a range or wildcard with a step, such as "*/15"
+	FieldStep
+	// FieldSpecial uses "L", "W" or "#", counting days from the end of
+	// the month or weekdays within it
+	FieldSpecial
+)
+
+// String returns the name of the kind, e.g. "range"
+func (k FieldKind) String() string {
+	switch k {
+	case FieldWildcard:
+		return "wildcard"
+	case FieldValue:
+		return "value"
+	case FieldList:
+		return "list"
+	case FieldRange:
+		return "range"
+	case FieldStep:
+		return "step"
+	}
+	return "special"
+}
+
+// Field describes one field of an expression
+type Field struct {
+	// Name is the name of the field, e.g. "minute"
+	Name string
+	Kind FieldKind
+	// Raw is the field as written
+	Raw string
+	// Values are the values the field matches, in order. For the day
+	// fields they leave out the days given by "L", "W" and "#".
+	Values []int
+	// Min and Max are the lowest and highest of Values
+	Min, Max int
+	// Step is the step of a FieldStep, or 1
+	Step int
+}
+
+// Fields describes every field of the expression in the order they are
+// written, including the seconds field of six- and seven-field layouts.
+// The values come from the same parse used for arithmetic and matching,
+// so "*/20 9-17 * * MON-FRI" describes the minute as a FieldStep matching
+// 0, 20 and 40. The year field is not expanded into values.
+func (c *CronTime) Fields() ([]Field, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []Field
+	if c.layout.hasSeconds() {
+		fields = append(fields, newField(secondField.name, c.Second, s.second, false))
+	}
+	sets := [5]valueSet{s.minute, s.hour, s.dom, s.month, s.dow}
+	special := [5]bool{
+		dayOfMonthIndex: s.domFromEnd != 0 || s.domLastWeekday,
+		dayOfWeekIndex:  s.dowNth != 0,
+	}
+	for i, field := range c.fieldPtrs() {
+		fields = append(fields, newField(standardFields[i].name, *field, sets[i], special[i]))
+	}
+	if c.layout.hasYear() {
+		fields = append(fields, Field{Name: "year", Kind: fieldKind(c.Year, false), Raw: c.Year, Step: fieldStep(c.Year)})
+	}
+	return fields, nil
+}
+
+// newField describes the field raw matching set
+func newField(name, raw string, set valueSet, special bool) Field {
+	f := Field{Name: name, Kind: fieldKind(raw, special), Raw: raw, Values: set.values(), Step: fieldStep(raw)}
+	if len(f.Values) > 0 {
+		f.Min, f.Max = f.Values[0], f.Values[len(f.Values)-1]
+	}
+	return f
+}
+
+// fieldKind classifies the syntax of raw
+func fieldKind(raw string, special bool) FieldKind {
+	switch {
+	case raw == "*" || raw == "?":
+		return FieldWildcard
+	case special:
+		return FieldSpecial
+	case strings.Contains(raw, ","):
+		return FieldList
+	case strings.Contains(raw, "/"):
+		return FieldStep
+	case strings.Contains(raw, "-"):
+		return FieldRange
+	}
+	return FieldValue
+}
+
+// fieldStep returns the step of a single stepped element, or 1
+func fieldStep(raw string) int {
+	if strings.Contains(raw, ",") {
+		return 1
+	}
+	if _, step, ok := strings.Cut(raw, "/"); ok {
+		if n, err := strconv.Atoi(step); err == nil {
+			return n
+		}
+	}
+	return 1
+}
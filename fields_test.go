@@ -0,0 +1,70 @@
+package cronmath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCronTime_Fields(t *testing.T) {
+	cron, err := ParseCron("*/20 9-17 1,15,L * MON-FRI")
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	got, err := cron.Fields()
+	if err != nil {
+		t.Fatalf("Fields() error = %v", err)
+	}
+
+	want := []Field{
+		{Name: "minute", Kind: FieldStep, Raw: "*/20", Values: []int{0, 20, 40}, Min: 0, Max: 40, Step: 20},
+		{Name: "hour", Kind: FieldRange, Raw: "9-17", Values: []int{9, 10, 11, 12, 13, 14, 15, 16, 17}, Min: 9, Max: 17, Step: 1},
+		{Name: "day of month", Kind: FieldSpecial, Raw: "1,15,L", Values: []int{1, 15}, Min: 1, Max: 15, Step: 1},
+		{Name: "month", Kind: FieldWildcard, Raw: "*", Values: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Min: 1, Max: 12, Step: 1},
+		{Name: "day of week", Kind: FieldRange, Raw: "MON-FRI", Values: []int{1, 2, 3, 4, 5}, Min: 1, Max: 5, Step: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCronTime_FieldsKinds(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		opts    []Option
+		want    []FieldKind
+	}{
+		{"0 9 * * *", nil, []FieldKind{FieldValue, FieldValue, FieldWildcard, FieldWildcard, FieldWildcard}},
+		{"0,30 9 ? JUL 1#2", nil, []FieldKind{FieldList, FieldValue, FieldWildcard, FieldValue, FieldSpecial}},
+		{"0-30/5 */2 LW 1-6/2 0,6", nil, []FieldKind{FieldStep, FieldStep, FieldSpecial, FieldStep, FieldList}},
+		{"15 0 9 * * * 2030-2032", []Option{WithAutoFields()}, []FieldKind{FieldValue, FieldValue, FieldValue, FieldWildcard, FieldWildcard, FieldWildcard, FieldRange}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			fields, err := cron.Fields()
+			if err != nil {
+				t.Fatalf("Fields() error = %v", err)
+			}
+			got := make([]FieldKind, len(fields))
+			for i, f := range fields {
+				got[i] = f.Kind
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields() kinds = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_FieldsInvalid(t *testing.T) {
+	for _, cronStr := range []string{"0 25 * * *", "@reboot"} {
+		cron, _ := ParseCron(cronStr)
+		if _, err := cron.Fields(); err == nil {
+			t.Errorf("Fields(%q) expected error, got nil", cronStr)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cronmath
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// fingerprintVersion prefixes the hashed canonical form. It must change
+// whenever the canonical form does, as stored fingerprints depend on it.
+const fingerprintVersion = "v1"
+
+// Fingerprint returns a 16-digit hex digest identifying when the
+// expression fires, for deduplicating schedules. Expressions that fire
+// at the same times share a fingerprint however they are written:
+// "0 9 * * MON" and "00 09 * * 1" hash alike. The digest is stable across
+// versions of the package and independent of the Options the expression
+// was parsed with.
+//
+// The digest is the first 8 bytes of the SHA-256 of "v1:" followed by the
+// canonical form, which is the expression after Compress, with
+//   - names written as numbers and Sunday as 0,
+//   - the seconds field compressed likewise, and dropped along with the
+//     year when they are "0" and "*",
+//   - the year written as given but without leading zeros.
+//
+// Expressions that do not parse are hashed as written.
+func (c *CronTime) Fingerprint() string {
+	sum := sha256.Sum256([]byte(fingerprintVersion + ":" + c.canonical()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// canonical returns the canonical form Fingerprint hashes
+func (c *CronTime) canonical() string {
+	if c.IsReboot() {
+		return reboot
+	}
+	canon := *c
+	canon.cfg, canon.macro = config{}, ""
+	if err := canon.Compress(); err != nil {
+		return c.fieldString()
+	}
+	fields := canon.fieldString()
+	if c.layout == LayoutStandard {
+		return fields
+	}
+
+	second, year := "0", "*"
+	if c.layout.hasSeconds() {
+		seconds, err := parseSet(c.Second, secondField)
+		if err != nil {
+			return c.fieldString()
+		}
+		second = formatSet(seconds, secondField)
+	}
+	if c.layout.hasYear() {
+		year = canonicalNumbers(c.Year)
+	}
+	if second == "0" && year == "*" {
+		return fields
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%s %s %s", second, fields, year), " *")
+}
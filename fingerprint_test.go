@@ -0,0 +1,76 @@
+package cronmath
+
+import "testing"
+
+func TestCronTime_Fingerprint(t *testing.T) {
+	same := [][]string{
+		{"0 9 * * MON", "00 09 * * 1", "0 9 * * mon", "0 9 ? * 1"},
+		{"0,15,30,45 * * * *", "*/15 * * * *", "0-59/15 * * * *"},
+		{"0 9 * * 0", "0 9 * * 7", "0 9 * * SUN"},
+		{"0 9 * * *", "0 0 9 * * *", "0 0 9 * * * *"},
+		{"@daily", "0 0 * * *"},
+	}
+	for _, group := range same {
+		want := mustParse(t, group[0]).Fingerprint()
+		for _, s := range group[1:] {
+			if got := mustParse(t, s).Fingerprint(); got != want {
+				t.Errorf("Fingerprint(%q) = %s, want %s as for %q", s, got, want, group[0])
+			}
+		}
+	}
+
+	different := []string{"0 9 * * 1", "0 9 * * 2", "0 9 1 * 1", "30 0 9 * * *", "0 0 9 * * * 2030"}
+	seen := map[string]string{}
+	for _, s := range different {
+		fp := mustParse(t, s).Fingerprint()
+		if other, ok := seen[fp]; ok {
+			t.Errorf("Fingerprint(%q) = Fingerprint(%q) = %s", s, other, fp)
+		}
+		seen[fp] = s
+	}
+}
+
+// Fingerprints are persisted, so they must never change for a given
+// expression. Update these only together with fingerprintVersion.
+func TestCronTime_FingerprintStable(t *testing.T) {
+	tests := []struct {
+		cronStr   string
+		canonical string
+		want      string
+	}{
+		{"00 09 * * MON", "0 9 * * 1", "f1d06c06d8e7d723"},
+		{"*/15 * * * *", "*/15 * * * *", "0e221ac0255194b7"},
+		{"30 0 9 * * *", "30 0 9 * * *", "a02c0f1d6a5081c1"},
+		{"0 0 9 L * ? 2030", "0 0 9 L * * 2030", "9a753c77377b8b83"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron := mustParse(t, tt.cronStr)
+			if got := cron.canonical(); got != tt.canonical {
+				t.Errorf("canonical() = %q, want %q", got, tt.canonical)
+			}
+			if got := cron.Fingerprint(); got != tt.want {
+				t.Errorf("Fingerprint() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, s string) *CronTime {
+	t.Helper()
+	cron, err := ParseCronWith(s, WithAutoFields())
+	if err != nil {
+		t.Fatalf("ParseCronWith(%q) error = %v", s, err)
+	}
+	return cron
+}
+
+func mustParseWith(t *testing.T, s string, opts ...Option) *CronTime {
+	t.Helper()
+	cron, err := ParseCronWith(s, opts...)
+	if err != nil {
+		t.Fatalf("ParseCronWith(%q) error = %v", s, err)
+	}
+	return cron
+}
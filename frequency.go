@@ -0,0 +1,93 @@
+package cronmath
+
+import "time"
+
+// RunsPerDay returns how many times the expression fires on a day it is
+// active, so "*/15 * * * *" gives 96. Expressions restricted by day or
+// month do not fire every day; FiresEveryDay tells them apart.
+func (c *CronTime) RunsPerDay() (int, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return 0, err
+	}
+	return s.runsPerDay(), nil
+}
+
+// Cardinality returns how many times the expression fires in the given
+// year, as many as Occurrences yields over it. The year is taken in loc,
+// or in the location given by WithLocation or else UTC when loc is nil.
+// Each active day counts RunsPerDay firings, except days on which loc
+// changes its offset, whose firings are counted one by one as the clock
+// skips or repeats times.
+func (c *CronTime) Cardinality(year int, loc *time.Location) (int, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return 0, err
+	}
+	loc = c.locationOr(loc)
+
+	count := 0
+	perDay := s.runsPerDay()
+	for d := dateOf(time.Date(year, time.January, 1, 0, 0, 0, 0, loc)); d.year == year; d = d.next() {
+		if !s.matchesDate(d) {
+			continue
+		}
+		start, end := d.at(0, 0, loc), d.next().at(0, 0, loc)
+		if offsetAt(start, loc) == offsetAt(end, loc) {
+			count += perDay
+			continue
+		}
+		for t, ok := s.next(start); ok && t.Before(end); t, ok = s.next(t.Add(time.Second)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FiresEveryDay reports whether the expression is active on every day of
+// the year, whatever its times of day
+func (c *CronTime) FiresEveryDay() bool {
+	s, err := c.schedule()
+	return err == nil && s.everyDay()
+}
+
+// IsDaily reports whether the expression fires exactly once every day,
+// like "5 9 * * *"
+func (c *CronTime) IsDaily() bool {
+	s, err := c.schedule()
+	return err == nil && s.everyDay() && s.runsPerDay() == 1
+}
+
+// IsHourly reports whether the expression fires exactly once every hour
+// of every day, like "5 * * * *"
+func (c *CronTime) IsHourly() bool {
+	s, err := c.schedule()
+	return err == nil && s.everyDay() && s.hour == hourField.fullSet() &&
+		s.minute.len() == 1 && s.second.len() == 1
+}
+
+// IsWeekly reports whether the expression fires exactly once a week, on a
+// single day of the week, like "0 9 * * MON"
+func (c *CronTime) IsWeekly() bool {
+	s, err := c.schedule()
+	return err == nil && s.runsPerDay() == 1 && s.month == monthField.fullSet() &&
+		!isRestricted(c.DayOfMonth) && s.dowNth == 0 && s.dow.len() == 1
+}
+
+// runsPerDay returns the number of firings on an active day
+func (s *schedule) runsPerDay() int {
+	return s.second.len() * s.minute.len() * s.hour.len()
+}
+
+// everyDay reports whether the schedule is active on every date
+func (s *schedule) everyDay() bool {
+	if s.month != monthField.fullSet() {
+		return false
+	}
+	allDom := s.dom == dayOfMonthField.fullSet()
+	allDow := s.dow == dayOfWeekField.fullSet()
+	if s.unionDays {
+		return allDom || allDow
+	}
+	return allDom && allDow
+}
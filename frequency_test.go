@@ -0,0 +1,155 @@
+package cronmath
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronTime_Frequency(t *testing.T) {
+	tests := []struct {
+		cronStr  string
+		runs     int
+		everyDay bool
+		daily    bool
+		hourly   bool
+		weekly   bool
+	}{
+		{"5 9 * * *", 1, true, true, false, false},
+		{"*/15 * * * *", 96, true, false, false, false},
+		{"5 * * * *", 24, true, false, true, false},
+		{"0 9,17 * * *", 2, true, false, false, false},
+		{"0 9 * * MON", 1, false, false, false, true},
+		{"0 9 * * 1-5", 1, false, false, false, false},
+		{"0 9 1 * *", 1, false, false, false, false},
+		{"0 9 * 1-6 *", 1, false, false, false, false},
+		{"0 9 * * 1#2", 1, false, false, false, false},
+		{"0 9 1-31 * 0-6", 1, true, true, false, false},
+		{"0 9 1 * 0-6", 1, true, true, false, false},
+		{"0 9 ? * *", 1, true, true, false, false},
+		{"5 * * * MON", 24, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			runs, err := cron.RunsPerDay()
+			if err != nil {
+				t.Fatalf("RunsPerDay() error = %v", err)
+			}
+			if runs != tt.runs {
+				t.Errorf("RunsPerDay() = %d, want %d", runs, tt.runs)
+			}
+			if got := cron.FiresEveryDay(); got != tt.everyDay {
+				t.Errorf("FiresEveryDay() = %v, want %v", got, tt.everyDay)
+			}
+			if got := cron.IsDaily(); got != tt.daily {
+				t.Errorf("IsDaily() = %v, want %v", got, tt.daily)
+			}
+			if got := cron.IsHourly(); got != tt.hourly {
+				t.Errorf("IsHourly() = %v, want %v", got, tt.hourly)
+			}
+			if got := cron.IsWeekly(); got != tt.weekly {
+				t.Errorf("IsWeekly() = %v, want %v", got, tt.weekly)
+			}
+		})
+	}
+}
+
+func TestCronTime_RunsPerDaySeconds(t *testing.T) {
+	cron, _ := ParseCronWith("*/10 0 9 * * *", WithAutoFields())
+	if runs, err := cron.RunsPerDay(); err != nil || runs != 6 {
+		t.Errorf("RunsPerDay() = %d, %v, want 6", runs, err)
+	}
+	if cron.IsDaily() {
+		t.Error("IsDaily() = true for an expression firing six times a day")
+	}
+}
+
+func TestCronTime_RunsPerDayInvalid(t *testing.T) {
+	cron, _ := ParseCron("0 25 * * *")
+	if _, err := cron.RunsPerDay(); err == nil {
+		t.Error("RunsPerDay() expected error for out-of-range hour, got nil")
+	}
+}
+
+func TestCronTime_Cardinality(t *testing.T) {
+	tests := []struct {
+		expr string
+		year int
+		want int
+	}{
+		{"0 9 * * *", 2025, 365},
+		{"0 9 * * *", 2024, 366},
+		{"*/15 * * * *", 2025, 365 * 96},
+		{"0 9 * * 1-5", 2025, 261},
+		{"0 0 29 2 *", 2024, 1},
+		{"0 0 29 2 *", 2025, 0},
+		{"0 0 L * *", 2025, 12},
+		{"0 0 LW * *", 2025, 12},
+		{"0 9 * * 1#2", 2025, 12},
+		{"0 9 * * 5#5", 2025, 4},
+		{"0 9 13 * 5", 2025, 52 + 12 - 1},
+		{"0 0 31 * *", 2025, 7},
+		{"30 0 0 1 1 *", 2025, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.expr, WithAutoFields())
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			got, err := cron.Cardinality(tt.year, nil)
+			if err != nil || got != tt.want {
+				t.Errorf("Cardinality() = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCronTime_CardinalityOccurrences checks Cardinality against counting
+// Occurrences across the year, daylight saving changes included
+func TestCronTime_CardinalityOccurrences(t *testing.T) {
+	locs := []*time.Location{time.UTC}
+	if ny, err := time.LoadLocation("America/New_York"); err == nil {
+		locs = append(locs, ny)
+	}
+
+	exprs := []string{
+		"0 9 * * *",
+		"*/20 1-3 * * *",
+		"30 2 * * 0",
+		"0,30 0-3 * 3,11 *",
+		"0 0 L-1 * *",
+		"0 12 1,15 * MON",
+		"0 6 * * 0#2",
+		"15 4 29 2 *",
+	}
+	for _, loc := range locs {
+		for _, year := range []int{2024, 2025} {
+			for _, expr := range exprs {
+				cron, _ := ParseCron(expr)
+				got, err := cron.Cardinality(year, loc)
+				if err != nil {
+					t.Fatalf("Cardinality(%q) error = %v", expr, err)
+				}
+
+				want := 0
+				start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+				end := start.AddDate(1, 0, 0)
+				for occ := range cron.Occurrences(start.Add(-time.Second)) {
+					if !occ.Before(end) {
+						break
+					}
+					want++
+				}
+				if got != want {
+					t.Errorf("Cardinality(%q, %d, %v) = %d, Occurrences yields %d", expr, year, loc, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cronmath
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var fuzzSeedExpressions = []string{
+	"5 9 * * *",
+	"* * * * *",
+	"0 0 1 1 *",
+	"0,15,30,45 9 * * *",
+	"0-30 9-17 * * 1-5",
+	"*/5 */2 * * *",
+	"10-40/5 9 * * *",
+	"0 9 * JAN MON-FRI",
+	"30 14 * * mon,wed,fri",
+	"@daily",
+	"\t5\t9  *\n* *",
+	"5 9 * * * extra",
+	"５ ９ * * *",
+	"0 9 * *\u00a0*",
+	"\u200b5 9 * * *",
+	"日 本 語 * *",
+	"-1 24 * * *",
+	"+5 09 * * *",
+	"0 0 0\r0\r#",
+	"",
+}
+
+func FuzzParseCron(f *testing.F) {
+	for _, s := range fuzzSeedExpressions {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		cron, err := ParseCron(s)
+		if err != nil {
+			return
+		}
+
+		out := cron.String()
+		reparsed, err := ParseCron(out)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) succeeded but its String() %q does not re-parse: %v", s, out, err)
+		}
+		if got := reparsed.String(); got != out {
+			t.Fatalf("ParseCron(%q).String() = %q, re-parsed String() = %q", s, out, got)
+		}
+	})
+}
+
+func FuzzAdjustTime(f *testing.F) {
+	durations := []time.Duration{
+		0,
+		Minutes(5),
+		Minutes(-5),
+		Hours(25),
+		Hours(-49),
+		90 * time.Second,
+		math.MaxInt64,
+		math.MinInt64,
+	}
+	for i, s := range fuzzSeedExpressions {
+		f.Add(s, int64(durations[i%len(durations)]))
+	}
+
+	f.Fuzz(func(t *testing.T, s string, n int64) {
+		cron, err := ParseCron(s)
+		if err != nil {
+			return
+		}
+		before, ok := fuzzMinuteOfDay(cron)
+		if !ok {
+			return
+		}
+
+		d := time.Duration(n)
+		if err := cron.Add(d); err != nil {
+			return
+		}
+		if err := cron.Sub(d); err != nil {
+			return
+		}
+
+		after, ok := fuzzMinuteOfDay(cron)
+		if !ok {
+			t.Fatalf("%q no longer has a fixed time after Add(%v).Sub(%v): %q", s, d, d, cron.String())
+		}
+		if after != before {
+			t.Fatalf("%q Add(%v).Sub(%v) moved minute-of-day from %d to %d", s, d, d, before, after)
+		}
+	})
+}
+
+// fuzzMinuteOfDay returns the fixed minute-of-day of c, if it has one.
+func fuzzMinuteOfDay(c *CronTime) (int, bool) {
+	minute, err := c.parseField(c.Minute, 0, 59)
+	if err != nil || minute == -1 {
+		return 0, false
+	}
+	hour, err := c.parseField(c.Hour, 0, 23)
+	if err != nil || hour == -1 {
+		return 0, false
+	}
+	return hour*60 + minute, true
+}
@@ -1,3 +1,3 @@
 module github.com/ryutaro-asada/cronmath
 
-go 1.21.1
+go 1.23
@@ -0,0 +1,259 @@
+package cronmath
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Step is one operation applied through CronMath
+type Step struct {
+	// Op is the name of the CronMath method, such as "Add"
+	Op   string
+	Args []any
+	// Result is the expression after the operation, or as it was left by
+	// an error
+	Result string
+	Err    error
+	// Days is how many days the operation carried the first firing of the
+	// day across midnight, negative when it moved back
+	Days int
+	// Skipped is set when an earlier error kept the operation from running
+	Skipped bool
+}
+
+// String renders the step as a call, e.g. "Add(15m0s)"
+func (s Step) String() string {
+	args := make([]string, len(s.Args))
+	for i, a := range s.Args {
+		args[i] = fmt.Sprint(a)
+	}
+	return s.Op + "(" + strings.Join(args, ", ") + ")"
+}
+
+// state is what Undo restores of a CronMath
+type state struct {
+	fields   exprFields
+	err      error
+	warnings int
+	pinned   int
+}
+
+// exprFields are the parts of a CronTime that operations change. The
+// options, layout and cache are shared by every state of a chain, which
+// keeps saving one cheap.
+type exprFields struct {
+	second, minute, hour, dayOfMonth, month, dayOfWeek, year string
+	// wallTime is the instant of NewAnchored, moved by shifts
+	wallTime time.Time
+}
+
+// exprFields returns the fields of c that operations change
+func (c *CronTime) exprFields() exprFields {
+	return exprFields{c.Second, c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek, c.Year, c.cfg.wallTime}
+}
+
+// setExprFields puts back fields taken by exprFields
+func (c *CronTime) setExprFields(f exprFields) {
+	c.Second, c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek, c.Year = f.second, f.minute, f.hour, f.dayOfMonth, f.month, f.dayOfWeek, f.year
+	c.cfg.wallTime = f.wallTime
+}
+
+// save returns the current state
+func (cm *CronMath) save() state {
+	s := state{err: cm.err, warnings: len(cm.warnings), pinned: len(cm.pinned)}
+	if cm.cron != nil {
+		s.fields = cm.cron.exprFields()
+	}
+	return s
+}
+
+// restore goes back to s, keeping the CronTime pointer so that one passed
+// to From is restored as well
+func (cm *CronMath) restore(s state) {
+	if cm.cron != nil {
+		cm.cron.setExprFields(s.fields)
+	}
+	cm.err = s.err
+	cm.warnings = cm.warnings[:s.warnings]
+	cm.pinned = cm.pinned[:s.pinned]
+}
+
+// exprAt returns the expression as it was with fields
+func (cm *CronMath) exprAt(f exprFields) *CronTime {
+	c := *cm.cron
+	c.setExprFields(f)
+	return &c
+}
+
+// apply runs op unless an earlier operation failed, recording it in the
+// history either way. The expression a step leaves behind is kept in the
+// saved states and only rendered by History and Explain.
+func (cm *CronMath) apply(name string, args []any, op func() error) *CronMath {
+	cm.saved = append(cm.saved, cm.save())
+	step := Step{Op: name, Args: args}
+	if cm.err != nil {
+		step.Skipped = true
+		cm.history = append(cm.history, step)
+		return cm
+	}
+
+	before := cm.saved[len(cm.saved)-1].fields
+	err := op()
+	if err == nil {
+		if err = cm.checkPins(before); err != nil {
+			cm.cron.setExprFields(before)
+		}
+	}
+	if err != nil {
+		cm.err = &OpError{Op: step.String(), Expr: cm.exprAt(before).String(), Err: err}
+	}
+	step.Err = cm.err
+	cm.history = append(cm.history, step)
+	return cm
+}
+
+// applyShift runs op as apply does, recording the days it reports
+// carrying into on the step when it succeeds
+func (cm *CronMath) applyShift(name string, args []any, op func() (int, error)) *CronMath {
+	var days int
+	cm.apply(name, args, func() error {
+		var err error
+		days, err = op()
+		return err
+	})
+	if step := &cm.history[len(cm.history)-1]; !step.Skipped && step.Err == nil {
+		step.Days = days
+	}
+	return cm
+}
+
+// carriedDays returns how many days a shift of n minutes carries the
+// first firing of the day of c, or 0 when it fires every hour
+func carriedDays(c *CronTime, n int64) int {
+	if c.Hour == "*" {
+		return 0
+	}
+	// A single fixed time is read from the cache, saving a parse per step
+	var first int
+	if minute, hour := c.clockValues(); minute.err == nil && hour.err == nil && minute.n >= 0 {
+		first = hour.n*60 + minute.n
+	} else {
+		day, err := c.ExpandDay()
+		if err != nil || len(day) == 0 {
+			return 0
+		}
+		first = day[0]
+	}
+	m := int64(first) + n
+	days := m / minutesPerDay
+	if m < 0 && m%minutesPerDay != 0 {
+		days--
+	}
+	return int(days)
+}
+
+// DayShift returns the days the operations so far carried the expression
+// across midnight, so that New("30 23 * * *").Add(Hours(1)) is 1 and
+// adding Sub(Hours(1)) brings it back to 0. Operations that failed or
+// were skipped add nothing.
+func (cm *CronMath) DayShift() int {
+	days := 0
+	for _, s := range cm.history {
+		days += s.Days
+	}
+	return days
+}
+
+// steps returns the history with the result of each step rendered
+func (cm *CronMath) steps() []Step {
+	steps := append([]Step(nil), cm.history...)
+	if cm.cron == nil {
+		return steps
+	}
+	for i := range steps {
+		after := cm.cron
+		if i+1 < len(cm.saved) {
+			after = cm.exprAt(cm.saved[i+1].fields)
+		}
+		steps[i].Result = after.String()
+	}
+	return steps
+}
+
+// Undo reverts the last operation and removes it from the history. When
+// that operation failed, the error goes with it, so
+// New("*/5 9 * * *").Round(Hours(1)).Undo() is "*/5 9 * * *" without an
+// error. Operations skipped after an error are undone one at a time, as
+// they were recorded. Undo does nothing when the history is empty.
+func (cm *CronMath) Undo() *CronMath {
+	n := len(cm.history)
+	if n == 0 {
+		return cm
+	}
+
+	cm.restore(cm.saved[n-1])
+	cm.history, cm.saved = cm.history[:n-1], cm.saved[:n-1]
+	return cm
+}
+
+// Reset reverts every operation, going back to the expression the chain
+// started from with an empty history
+func (cm *CronMath) Reset() *CronMath {
+	if len(cm.history) == 0 {
+		return cm
+	}
+
+	cm.restore(cm.saved[0])
+	cm.history, cm.saved = nil, nil
+	return cm
+}
+
+// History returns the operations applied so far, including those skipped
+// after an error
+func (cm *CronMath) History() []Step {
+	return cm.steps()
+}
+
+// Explain renders the chain of operations as a ledger, one line per step
+// with the expression it left behind and the days it carried into, if
+// any:
+//
+//	start            5 9 * * *
+//	Add(15m0s)       20 9 * * *
+//	Round(1h0m0s)    0 9 * * *
+//	Add(15h0m0s)     0 0 * * *    (+1 day)
+func (cm *CronMath) Explain() string {
+	lines := [][2]string{{"start", cm.start}}
+	for _, s := range cm.steps() {
+		var result string
+		switch {
+		case s.Skipped:
+			result = "skipped"
+		case s.Err != nil:
+			// The line already names the operation
+			err := s.Err
+			if opErr, ok := err.(*OpError); ok {
+				err = opErr.Err
+			}
+			result = "error: " + err.Error()
+		case s.Days == 1 || s.Days == -1:
+			result = fmt.Sprintf("%s    (%+d day)", s.Result, s.Days)
+		case s.Days != 0:
+			result = fmt.Sprintf("%s    (%+d days)", s.Result, s.Days)
+		default:
+			result = s.Result
+		}
+		lines = append(lines, [2]string{s.String(), result})
+	}
+
+	width := 0
+	for _, l := range lines {
+		width = max(width, len(l[0]))
+	}
+	var b strings.Builder
+	for _, l := range lines {
+		fmt.Fprintf(&b, "%-*s    %s\n", width, l[0], l[1])
+	}
+	return b.String()
+}
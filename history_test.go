@@ -0,0 +1,225 @@
+package cronmath
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronMath_History(t *testing.T) {
+	cm := New("5 9 * * *").Add(Minutes(15)).Round(Hours(1)).Sub(Minutes(30))
+	want := []Step{
+		{Op: "Add", Args: []any{Minutes(15)}, Result: "20 9 * * *"},
+		{Op: "Round", Args: []any{Hours(1)}, Result: "0 9 * * *"},
+		{Op: "Sub", Args: []any{Minutes(30)}, Result: "30 8 * * *"},
+	}
+	if got := cm.History(); !reflect.DeepEqual(got, want) {
+		t.Errorf("History() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCronMath_HistorySkipped(t *testing.T) {
+	cm := New("*/5 9 * * *").Round(Minutes(15)).Add(Hours(1))
+	h := cm.History()
+	if len(h) != 2 {
+		t.Fatalf("History() has %d steps, want 2", len(h))
+	}
+	if h[0].Err == nil || h[0].Err != cm.Error() || h[0].Skipped {
+		t.Errorf("History()[0] = %+v, want the failing Round", h[0])
+	}
+	if !h[1].Skipped || h[1].Err != nil || h[1].Result != "*/5 9 * * *" {
+		t.Errorf("History()[1] = %+v, want a skipped Add", h[1])
+	}
+}
+
+func TestCronMath_HistoryCopy(t *testing.T) {
+	cm := New("0 9 * * *").Add(Hours(1))
+	cm.History()[0].Op = "changed"
+	if got := cm.History()[0].Op; got != "Add" {
+		t.Errorf("History() shares its slice, Op = %q", got)
+	}
+}
+
+func TestCronMath_Explain(t *testing.T) {
+	got := New("5 9 * * *").Add(Minutes(15)).Round(Hours(1)).Explain()
+	want := "start            5 9 * * *\n" +
+		"Add(15m0s)       20 9 * * *\n" +
+		"Round(1h0m0s)    0 9 * * *\n"
+	if got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+
+	got = New("*/5 9 * * *").Round(Minutes(15)).Add(Hours(1)).Explain()
+	for _, line := range []string{"Round(15m0s)    error: ", "Add(1h0m0s)     skipped\n"} {
+		if !strings.Contains(got, line) {
+			t.Errorf("Explain() =\n%s\nwant a line with %q", got, line)
+		}
+	}
+}
+
+func TestCronMath_DayShift(t *testing.T) {
+	tests := []struct {
+		name string
+		cm   *CronMath
+		want int
+	}{
+		{"none", New("0 9 * * *").Add(Hours(2)), 0},
+		{"forward", New("30 23 * * 1").Add(Hours(1)), 1},
+		{"back", New("30 0 * * 1").Sub(Hours(1)), -1},
+		{"forward then back", New("30 23 * * 1").Add(Hours(1)).Sub(Hours(1)), 0},
+		{"several days", New("0 12 * * *").Add(Hours(60)), 3},
+		{"clock", New("0 1 * * *").SubClock(2, 0), -1},
+		{"round", New("53 23 * * *").Round(Hours(1)), 1},
+		{"business", New("0 18 * * FRI").AddBusiness(Hours(6)), 1},
+		{"failure", New("30 23 * * *").Add(Hours(1)).Round(Hours(25)).Add(Hours(24)), 1},
+		{"undo", New("30 23 * * *").Add(Hours(1)).Undo(), 0},
+		{"every hour", New("30 * * * *").Add(Minutes(15)), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cm.DayShift(); got != tt.want {
+				t.Errorf("DayShift() = %d, want %d (%s)", got, tt.want, tt.cm)
+			}
+		})
+	}
+}
+
+func TestCronMath_ExplainDays(t *testing.T) {
+	got := New("30 23 * * 1").Add(Hours(1)).Sub(Hours(49)).Explain()
+	want := "start           30 23 * * 1\n" +
+		"Add(1h0m0s)     30 0 * * 2    (+1 day)\n" +
+		"Sub(49h0m0s)    30 23 * * 6    (-3 days)\n"
+	if got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCronMath_Undo(t *testing.T) {
+	tests := []struct {
+		name       string
+		cronStr    string
+		operations func(*CronMath) *CronMath
+		want       string
+		wantSteps  int
+	}{
+		{
+			name:    "undo last",
+			cronStr: "0 9 * * *",
+			operations: func(cm *CronMath) *CronMath {
+				return cm.Add(Hours(1)).Sub(Minutes(15)).Undo()
+			},
+			want:      "0 10 * * *",
+			wantSteps: 1,
+		},
+		{
+			name:    "undo then continue",
+			cronStr: "0 9 * * *",
+			operations: func(cm *CronMath) *CronMath {
+				return cm.Add(Hours(1)).Undo().Sub(Minutes(30)).Add(Minutes(5))
+			},
+			want:      "35 8 * * *",
+			wantSteps: 2,
+		},
+		{
+			name:    "undo everything",
+			cronStr: "0 23 * * *",
+			operations: func(cm *CronMath) *CronMath {
+				return cm.Add(Hours(2)).Sub(Minutes(5)).Undo().Undo()
+			},
+			want:      "0 23 * * *",
+			wantSteps: 0,
+		},
+		{
+			name:    "undo on empty history",
+			cronStr: "0 9 * * *",
+			operations: func(cm *CronMath) *CronMath {
+				return cm.Undo().Undo().Add(Minutes(1))
+			},
+			want:      "1 9 * * *",
+			wantSteps: 1,
+		},
+		{
+			name:    "undo failure clears error",
+			cronStr: "*/5 9 * * *",
+			operations: func(cm *CronMath) *CronMath {
+				return cm.Add(Hours(1)).Round(Minutes(15)).Undo().Sub(Hours(2))
+			},
+			want:      "*/5 8 * * *",
+			wantSteps: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := tt.operations(New(tt.cronStr))
+			if err := cm.Error(); err != nil {
+				t.Fatalf("operations error = %v", err)
+			}
+			if got := cm.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := len(cm.History()); got != tt.wantSteps {
+				t.Errorf("History() has %d steps, want %d", got, tt.wantSteps)
+			}
+		})
+	}
+}
+
+func TestCronMath_UndoSkipped(t *testing.T) {
+	cm := New("*/5 9 * * *").Round(Minutes(15)).Add(Hours(1))
+	if cm.Undo().Error() == nil {
+		t.Error("Undo() of a skipped step cleared the error")
+	}
+	if err := cm.Undo().Error(); err != nil {
+		t.Errorf("Undo() of the failed step left error = %v", err)
+	}
+}
+
+func TestCronMath_UndoWarnings(t *testing.T) {
+	cm := New("0 9 * * *").AddYears(1)
+	if len(cm.Warnings()) != 1 {
+		t.Fatalf("Warnings() = %v, want one", cm.Warnings())
+	}
+	if w := cm.Undo().Warnings(); len(w) != 0 {
+		t.Errorf("Warnings() after Undo() = %v, want none", w)
+	}
+}
+
+func TestCronMath_UndoAnchored(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	cm := NewAnchored("30 2 * * *", ny, time.Date(2025, time.March, 8, 0, 0, 0, 0, ny))
+	want, _ := cm.cron.Anchor()
+	cm.Add(Hours(24)).Undo()
+	if got, _ := cm.cron.Anchor(); !got.Equal(want) || cm.String() != "30 2 * * *" {
+		t.Errorf("Undo() = %q anchored at %v, want %q at %v", cm.String(), got, "30 2 * * *", want)
+	}
+	// The anchor is back before the spring change, so it is crossed again
+	if got := cm.Add(Hours(24)).String(); got != "30 3 * * *" {
+		t.Errorf("Add() after Undo() = %q, want %q", got, "30 3 * * *")
+	}
+}
+
+func TestCronMath_Reset(t *testing.T) {
+	cm := New("0 9 * * 1-5").Add(Hours(20)).Sub(Minutes(30)).Round(0)
+	if cm.Error() == nil {
+		t.Fatal("Round(0) expected error, got nil")
+	}
+	cm.Reset()
+	if cm.Error() != nil || cm.String() != "0 9 * * 1-5" || len(cm.History()) != 0 {
+		t.Errorf("Reset() = %q, error %v, %d steps", cm.String(), cm.Error(), len(cm.History()))
+	}
+	if got := cm.Add(Minutes(10)).String(); got != "10 9 * * 1-5" {
+		t.Errorf("Add() after Reset() = %q, want %q", got, "10 9 * * 1-5")
+	}
+
+	c, _ := ParseCron("0 12 * * *")
+	From(c, nil).Add(Hours(1)).Reset()
+	if got := c.String(); got != "0 12 * * *" {
+		t.Errorf("Reset() left the wrapped CronTime at %q", got)
+	}
+}
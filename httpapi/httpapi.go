@@ -0,0 +1,203 @@
+// Package httpapi serves cronmath over HTTP as JSON, for callers outside
+// Go. Mount it under a prefix of an existing mux:
+//
+//	mux.Handle("/cron/", http.StripPrefix("/cron", httpapi.NewHandler()))
+//
+// Every endpoint takes a JSON body with POST:
+//
+//	POST /shift    {"expr": "0 9 * * *", "by": "-30m"}  → {"expr": "30 8 * * *", "dayShift": 0}
+//	POST /describe {"expr": "0 9 * * 1-5", "lang": "en"} → {"text": "at 09:00 on Monday through Friday"}
+//	POST /validate {"exprs": ["0 9 * * *", "0 25 * * *"]} → {"results": [...]}
+//
+// Failures are answered with {"error": {"kind": ..., "message": ...}},
+// where kind is a cronmath.ErrorKind or "request" for a malformed request.
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ryutaro-asada/cronmath"
+)
+
+// maxBodyBytes bounds the size of a request body
+const maxBodyBytes = 1 << 20
+
+// kindRequest is the error kind of a request that is not valid JSON or
+// lacks a field
+const kindRequest cronmath.ErrorKind = "request"
+
+// NewHandler returns a handler serving the endpoints, parsing expressions
+// with opts
+func NewHandler(opts ...cronmath.Option) http.Handler {
+	h := &handler{opts: opts}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /shift", h.shift)
+	mux.HandleFunc("POST /describe", h.describe)
+	mux.HandleFunc("POST /validate", h.validate)
+	return mux
+}
+
+type handler struct {
+	opts []cronmath.Option
+}
+
+// Error is the body of a failed request, and of an invalid expression in
+// a validate response
+type Error struct {
+	Kind    cronmath.ErrorKind `json:"kind"`
+	Message string             `json:"message"`
+	// Field, Index and Value locate the token of a cronmath.ParseError
+	Field string `json:"field,omitempty"`
+	Index *int   `json:"index,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// newError describes err
+func newError(err error) *Error {
+	e := &Error{Kind: cronmath.KindOf(err), Message: err.Error()}
+	var pe *cronmath.ParseError
+	if errors.As(err, &pe) {
+		e.Field, e.Index, e.Value = pe.Field, &pe.Index, pe.Value
+	}
+	return e
+}
+
+// ShiftRequest is the body of POST /shift. By is a Go duration such as
+// "1h30m" or "-15m".
+type ShiftRequest struct {
+	Expr string `json:"expr"`
+	By   string `json:"by"`
+}
+
+// ShiftResponse is the answer to POST /shift. DayShift is the number of
+// days the shift carried the first firing of the day into.
+type ShiftResponse struct {
+	Expr     string `json:"expr"`
+	DayShift int    `json:"dayShift"`
+}
+
+func (h *handler) shift(w http.ResponseWriter, r *http.Request) {
+	var req ShiftRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	by, err := time.ParseDuration(req.By)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, &Error{Kind: kindRequest, Message: "invalid by: " + err.Error()})
+		return
+	}
+
+	c, err := cronmath.ParseCronWith(req.Expr, h.opts...)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, newError(err))
+		return
+	}
+	cm := cronmath.From(c, nil).Add(by)
+	if err := cm.Error(); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, newError(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, ShiftResponse{Expr: cm.String(), DayShift: cm.DayShift()})
+}
+
+// DescribeRequest is the body of POST /describe. Lang selects the
+// language as for CronTime.DescribeIn, English by default.
+type DescribeRequest struct {
+	Expr string `json:"expr"`
+	Lang string `json:"lang,omitempty"`
+}
+
+// DescribeResponse is the answer to POST /describe
+type DescribeResponse struct {
+	Text string `json:"text"`
+}
+
+func (h *handler) describe(w http.ResponseWriter, r *http.Request) {
+	var req DescribeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	c, err := cronmath.ParseCronWith(req.Expr, h.opts...)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, newError(err))
+		return
+	}
+	text, err := c.DescribeIn(req.Lang)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, newError(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, DescribeResponse{Text: text})
+}
+
+// ValidateRequest is the body of POST /validate
+type ValidateRequest struct {
+	Exprs []string `json:"exprs"`
+}
+
+// ValidateResponse is the answer to POST /validate, with a result for
+// each expression in order
+type ValidateResponse struct {
+	Results []ValidateResult `json:"results"`
+}
+
+// ValidateResult reports whether one expression is valid, and every
+// problem found if not
+type ValidateResult struct {
+	Expr   string   `json:"expr"`
+	Valid  bool     `json:"valid"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+func (h *handler) validate(w http.ResponseWriter, r *http.Request) {
+	var req ValidateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	resp := ValidateResponse{Results: make([]ValidateResult, len(req.Exprs))}
+	for i, expr := range req.Exprs {
+		res := ValidateResult{Expr: expr, Valid: true}
+		if err := cronmath.ValidateString(expr, h.opts...); err != nil {
+			res.Valid = false
+			errs := []error{err}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				errs = joined.Unwrap()
+			}
+			for _, err := range errs {
+				res.Errors = append(res.Errors, newError(err))
+			}
+		}
+		resp.Results[i] = res
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// decode reads the JSON body of r into v, answering the request itself
+// and returning false when it cannot
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, &Error{Kind: kindRequest, Message: "invalid request body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// writeError answers with an error body
+func writeError(w http.ResponseWriter, status int, e *Error) {
+	writeJSON(w, status, struct {
+		Error *Error `json:"error"`
+	}{e})
+}
+
+// writeJSON answers with v as JSON
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
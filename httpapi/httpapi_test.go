@@ -0,0 +1,126 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "shift",
+			path:       "/shift",
+			body:       `{"expr": "0 9 * * *", "by": "-30m"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"expr":"30 8 * * *","dayShift":0}`,
+		},
+		{
+			name:       "shift past midnight",
+			path:       "/shift",
+			body:       `{"expr": "30 23 * * *", "by": "1h"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"expr":"30 0 * * *","dayShift":1}`,
+		},
+		{
+			name:       "shift before midnight",
+			path:       "/shift",
+			body:       `{"expr": "30 0 * * *", "by": "-1h"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"expr":"30 23 * * *","dayShift":-1}`,
+		},
+		{
+			name:       "shift by days",
+			path:       "/shift",
+			body:       `{"expr": "30 23 * * *", "by": "2401h"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"expr":"30 0 * * *","dayShift":101}`,
+		},
+		{
+			name:       "shift a wildcard",
+			path:       "/shift",
+			body:       `{"expr": "* 9 * * *", "by": "1m"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error":{"kind":"wildcard","message":`,
+		},
+		{
+			name:       "shift an invalid field",
+			path:       "/shift",
+			body:       `{"expr": "0 9-25 * * *", "by": "1h"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error":{"kind":"parse","message":`,
+		},
+		{
+			name:       "invalid duration",
+			path:       "/shift",
+			body:       `{"expr": "0 9 * * *", "by": "soon"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":{"kind":"request","message":"invalid by: `,
+		},
+		{
+			name:       "describe",
+			path:       "/describe",
+			body:       `{"expr": "30 9 * * 1-5"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"text":"`,
+		},
+		{
+			name:       "validate",
+			path:       "/validate",
+			body:       `{"exprs": ["0 9 * * *", "0 25 * * *"]}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"results":[{"expr":"0 9 * * *","valid":true},{"expr":"0 25 * * *","valid":false,"errors":[{"kind":"parse","message":`,
+		},
+		{
+			name:       "unknown field",
+			path:       "/validate",
+			body:       `{"expr": "0 9 * * *"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":{"kind":"request","message":"invalid request body: `,
+		},
+	}
+
+	h := NewHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Body.String(); !strings.HasPrefix(got, tt.wantBody) {
+				t.Errorf("body = %s, want prefix %s", got, tt.wantBody)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+		})
+	}
+}
+
+func TestHandlerMethod(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/shift", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandlerParseErrorLocation(t *testing.T) {
+	rec := httptest.NewRecorder()
+	body := `{"exprs": ["0 25 * * *"]}`
+	NewHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body)))
+
+	want := `"field":"hour","index":1,"value":"25"`
+	if got := rec.Body.String(); !strings.Contains(got, want) {
+		t.Errorf("body = %s, want it to contain %s", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package cronmath
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronTime_ToICS(t *testing.T) {
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		cronStr string
+		n       int
+		want    []string
+		events  int
+	}{
+		{"recurring", "30 9 * * 1-5", 10, []string{
+			"DTSTART:20250602T093000Z",
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=30;COUNT=10",
+		}, 1},
+		{"discrete", "0 9 1 * MON", 2, []string{
+			"DTSTART:20250602T090000Z",
+			"DTSTART:20250609T090000Z",
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, _ := ParseCron(tt.cronStr)
+			var b strings.Builder
+			if err := cron.ToICS(&b, start, tt.n, "Report"); err != nil {
+				t.Fatalf("ToICS() error = %v", err)
+			}
+
+			got := b.String()
+			if !strings.HasPrefix(got, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(got, "END:VCALENDAR\r\n") {
+				t.Errorf("ToICS() is not a calendar:\n%s", got)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(got, line+"\r\n") {
+					t.Errorf("ToICS() lacks %q:\n%s", line, got)
+				}
+			}
+			if n := strings.Count(got, "BEGIN:VEVENT"); n != tt.events {
+				t.Errorf("ToICS() wrote %d events, want %d", n, tt.events)
+			}
+		})
+	}
+}
+
+func TestCronTime_ToICS_Deterministic(t *testing.T) {
+	cron, _ := ParseCron("0 9 1 * MON")
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	var a, b strings.Builder
+	if err := cron.ToICS(&a, start, 3, "Close the books"); err != nil {
+		t.Fatalf("ToICS() error = %v", err)
+	}
+	cron.ToICS(&b, start, 3, "Close the books")
+	if a.String() != b.String() {
+		t.Errorf("ToICS() differs between runs:\n%s\n%s", a.String(), b.String())
+	}
+}
+
+func TestCronTime_ToICS_Location(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	cron, _ := ParseCronWith("0 9 * * *", WithLocation(tokyo))
+	var b strings.Builder
+	if err := cron.ToICS(&b, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), 1, "Standup"); err != nil {
+		t.Fatalf("ToICS() error = %v", err)
+	}
+	if want := "DTSTART:20250602T000000Z\r\n"; !strings.Contains(b.String(), want) {
+		t.Errorf("ToICS() lacks %q:\n%s", want, b.String())
+	}
+	if strings.Contains(b.String(), "RRULE:") {
+		t.Errorf("ToICS() wrote an RRULE read in UTC:\n%s", b.String())
+	}
+}
+
+func TestCronTime_ToICS_OffsetChange(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Daily at 9:00 in New York, across the spring change on March 9
+	cron, _ := ParseCronWith("0 9 * * *", WithLocation(ny))
+	var b strings.Builder
+	if err := cron.ToICS(&b, time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), 4, "Standup"); err != nil {
+		t.Fatalf("ToICS() error = %v", err)
+	}
+	out := b.String()
+	if strings.Contains(out, "RRULE:") {
+		t.Errorf("ToICS() wrote an RRULE across an offset change:\n%s", out)
+	}
+	for _, want := range []string{"DTSTART:20250308T140000Z\r\n", "DTSTART:20250309T130000Z\r\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("ToICS() lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	var b strings.Builder
+	cron, _ := ParseCron("0 0 1 * *")
+	cron.ToICS(&b, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 1, strings.Repeat("日本語", 20)+", done")
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	var summary string
+	for i, line := range lines {
+		if len(line) > icsLineLimit {
+			t.Errorf("line %d is %d octets: %q", i, len(line), line)
+		}
+		if strings.HasPrefix(line, "SUMMARY:") {
+			summary = line
+			for _, cont := range lines[i+1:] {
+				if !strings.HasPrefix(cont, " ") {
+					break
+				}
+				summary += cont[1:]
+			}
+		}
+	}
+	if want := "SUMMARY:" + strings.Repeat("日本語", 20) + `\, done`; summary != want {
+		t.Errorf("unfolded summary = %q, want %q", summary, want)
+	}
+}
@@ -0,0 +1,183 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// minInferSamples is the fewest distinct times Infer works from
+const minInferSamples = 3
+
+// ErrNoExactSchedule is matched by the *InferenceError returned when no
+// expression Infer tries fires exactly at the samples
+var ErrNoExactSchedule = errors.New("no schedule fires exactly at the samples")
+
+// InferenceError reports the best expression Infer found when none fit
+// the samples exactly: it fires Firings times between the first and last
+// sample, Matched of them at one of the Samples
+type InferenceError struct {
+	Best                      *CronTime
+	Samples, Firings, Matched int
+}
+
+func (e *InferenceError) Error() string {
+	return fmt.Sprintf("%v: best match %q fires %d times over the samples, %d of %d samples among them",
+		ErrNoExactSchedule, e.Best, e.Firings, e.Matched, e.Samples)
+}
+
+// Is reports whether target is ErrNoExactSchedule
+func (e *InferenceError) Is(target error) bool {
+	return target == ErrNoExactSchedule
+}
+
+// Infer proposes an expression firing at the given times, as read from
+// the run log of a job whose schedule is unknown. Times are truncated to
+// the minute and read in loc, or UTC when loc is nil, which the returned
+// expression is set to with WithLocation.
+//
+// Simpler expressions are tried first: a fixed time of day, then every N
+// minutes or hours, then a list of times; each every day, then on the
+// weekdays seen, when the others are passed over twice at least, then on
+// the days of the month seen, when each is seen in two months at least.
+// The first firing exactly at the samples between the first and the last
+// is returned with a score of 1, unless a simpler one fires at all of
+// them and misses a single run, as a list of times would fit an hourly
+// log with an hour left out. Otherwise the closest is returned along with
+// an *InferenceError, its score being the samples it fires at over all
+// the times in either, so that a log missing one run in ten scores 0.9.
+// At least 3 distinct times are needed.
+func Infer(times []time.Time, loc *time.Location) (*CronTime, float64, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	samples := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		samples = append(samples, t.Truncate(time.Minute).In(loc))
+	}
+	slices.SortFunc(samples, time.Time.Compare)
+	samples = slices.CompactFunc(samples, time.Time.Equal)
+	if len(samples) < minInferSamples {
+		return nil, 0, fmt.Errorf("cannot infer a schedule from %s: need at least %d", plural(len(samples), "distinct time"), minInferSamples)
+	}
+
+	var (
+		best    *CronTime
+		bestErr *InferenceError
+		score   = -1.0
+	)
+	for _, clock := range inferTimes(samples) {
+		for _, days := range inferDays(samples) {
+			c, err := ParseCronWith(clock+" "+days, WithLocation(loc))
+			if err != nil {
+				return nil, 0, err
+			}
+			r, err := c.VerifyFires(samples, loc)
+			if err != nil {
+				return nil, 0, err
+			}
+			if r.OK() {
+				if bestErr != nil && bestErr.Matched == bestErr.Samples && bestErr.Firings == bestErr.Samples+1 {
+					return best, score, bestErr
+				}
+				return c, 1, nil
+			}
+			if s := float64(r.Matched) / float64(r.Matched+r.OffSchedule+r.Missed); s > score {
+				best, score = c, s
+				bestErr = &InferenceError{Best: c, Samples: len(samples), Firings: r.Matched + r.Missed, Matched: r.Matched}
+			}
+		}
+	}
+	return best, score, bestErr
+}
+
+// inferTimes returns the minute and hour fields Infer tries for samples,
+// simplest first
+func inferTimes(samples []time.Time) []string {
+	var minutes, hours valueSet
+	gap := 0
+	prev := -1
+	for _, t := range samples {
+		minutes |= 1 << uint(t.Minute())
+		hours |= 1 << uint(t.Hour())
+		// Minutes counted on the wall clock, as the fields are
+		_, offset := t.Zone()
+		m := int((t.Unix() + int64(offset)) / 60)
+		if prev >= 0 && m != prev {
+			gap = gcd(gap, m-prev)
+		}
+		prev = m
+	}
+	if minutes.len() == 1 && hours.len() == 1 {
+		return []string{formatSet(minutes, minuteField) + " " + formatSet(hours, hourField)}
+	}
+
+	lists := formatSet(minutes, minuteField) + " " + formatSet(hours, hourField)
+	first := samples[0]
+	var step string
+	switch {
+	case gap > 0 && gap < 60 && 60%gap == 0:
+		step = formatSet(rangeSet(first.Minute()%gap, 59, gap), minuteField) + " " + formatSet(hours, hourField)
+	case gap >= 60 && gap%60 == 0 && 24*60%gap == 0 && gap < 24*60:
+		n := gap / 60
+		step = formatSet(minutes, minuteField) + " " + formatSet(rangeSet(first.Hour()%n, 23, n), hourField)
+	}
+	if step == "" || step == lists {
+		return []string{lists}
+	}
+	return []string{step, lists}
+}
+
+// inferDays returns the day of month, month and day of week fields Infer
+// tries for samples, simplest first
+func inferDays(samples []time.Time) []string {
+	var weekdays, monthDays valueSet
+	// The months each day of the month is seen in
+	months := map[int]map[int]bool{}
+	for _, t := range samples {
+		weekdays |= 1 << uint(t.Weekday())
+		monthDays |= 1 << uint(t.Day())
+		if months[t.Day()] == nil {
+			months[t.Day()] = map[int]bool{}
+		}
+		months[t.Day()][t.Year()*12+int(t.Month())] = true
+	}
+
+	// Weekdays left out count only once passed over twice
+	var skipped [7]int
+	for d, last := dateOf(samples[0]), dateOf(samples[len(samples)-1]); ; d = d.next() {
+		skipped[d.weekday]++
+		if d == last {
+			break
+		}
+	}
+	weekly := weekdays != dayOfWeekField.fullSet()
+	for wd, n := range skipped {
+		weekly = weekly && (weekdays.has(wd) || n >= 2)
+	}
+
+	fields := []string{"* * *"}
+	if weekly {
+		fields = append(fields, "* * "+formatSet(weekdays, dayOfWeekField))
+	}
+	monthly := monthDays != dayOfMonthField.fullSet()
+	for _, seen := range months {
+		monthly = monthly && len(seen) >= 2
+	}
+	if monthly {
+		fields = append(fields, formatSet(monthDays, dayOfMonthField)+" * *")
+	}
+	return fields
+}
+
+// gcd returns the greatest common divisor of a and b
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,121 @@
+package cronmath
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// everyStep returns n times from start, step apart, skipping the indexes
+// in skip
+func everyStep(start time.Time, step time.Duration, n int, skip ...int) []time.Time {
+	var times []time.Time
+	for i := 0; i < n; i++ {
+		if !slices.Contains(skip, i) {
+			times = append(times, start.Add(time.Duration(i)*step))
+		}
+	}
+	return times
+}
+
+// weekdaysAt returns the weekday times at hour:minute over the given days
+// from 2025-03-03, a Monday
+func weekdaysAt(hour, minute, days int) []time.Time {
+	var times []time.Time
+	for i := 0; i < days; i++ {
+		t := time.Date(2025, time.March, 3+i, hour, minute, 0, 0, time.UTC)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
+func TestInfer(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2025, time.March, 3, 9, 30, 0, 0, time.UTC)
+	monthly := []time.Time{
+		time.Date(2025, time.January, 15, 4, 0, 0, 0, time.UTC),
+		time.Date(2025, time.February, 15, 4, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 15, 4, 0, 0, 0, time.UTC),
+	}
+	twice := append(everyStep(start, day, 5), everyStep(start.Add(8*time.Hour), day, 5)...)
+
+	tests := []struct {
+		name      string
+		times     []time.Time
+		want      string
+		wantScore float64
+		wantErr   bool
+	}{
+		{"daily", everyStep(start, day, 10), "30 9 * * *", 1, false},
+		{"daily with seconds", everyStep(start.Add(17*time.Second), day, 4), "30 9 * * *", 1, false},
+		{"weekdays", weekdaysAt(9, 30, 21), "30 9 * * 1-5", 1, false},
+		{"monthly", monthly, "0 4 15 * *", 1, false},
+		{"every 15 minutes", everyStep(start, 15*time.Minute, 200), "*/15 * * * *", 1, false},
+		{"every 15 minutes, a run missed", everyStep(start, 15*time.Minute, 8, 3), "*/15 9-11 * * *", 0.875, true},
+		{"every 6 hours", everyStep(start, 6*time.Hour, 12), "30 3-21/6 * * *", 1, false},
+		{"twice a day", twice, "30 9,17 * * *", 1, false},
+		{"hourly, a run missed", everyStep(start.Add(-30*time.Minute), time.Hour, 10, 4), "0 * * * *", 0.9, true},
+		{"a run missed", everyStep(start, day, 10, 4), "30 9 * * *", 0.9, true},
+		{"too few", everyStep(start, day, 2), "", 0, true},
+		{"duplicates", []time.Time{start, start.Add(time.Second), start.Add(day)}, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, score, err := Infer(tt.times, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Infer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if score != tt.wantScore {
+				t.Errorf("score = %v, want %v", score, tt.wantScore)
+			}
+			if tt.want == "" {
+				if c != nil {
+					t.Errorf("Infer() = %q, want nil", c)
+				}
+				return
+			}
+			if c.String() != tt.want {
+				t.Errorf("Infer() = %q, want %q", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfer_InexactError(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 9, 30, 0, 0, time.UTC)
+	_, _, err := Infer(everyStep(start, 24*time.Hour, 10, 4), nil)
+	var ie *InferenceError
+	if !errors.As(err, &ie) || !errors.Is(err, ErrNoExactSchedule) {
+		t.Fatalf("error = %v, want an *InferenceError", err)
+	}
+	if ie.Best.String() != "30 9 * * *" || ie.Samples != 9 || ie.Firings != 10 || ie.Matched != 9 {
+		t.Errorf("InferenceError = %+v", *ie)
+	}
+	want := `no schedule fires exactly at the samples: best match "30 9 * * *" fires 10 times over the samples, 9 of 9 samples among them`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+}
+
+func TestInfer_Location(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	// 9:00 in New York on both sides of the spring change
+	var times []time.Time
+	for d := 5; d <= 12; d++ {
+		times = append(times, time.Date(2025, time.March, d, 9, 0, 0, 0, ny).UTC())
+	}
+	c, score, err := Infer(times, ny)
+	if err != nil || score != 1 || c.String() != "0 9 * * *" {
+		t.Errorf("Infer() = %q, %v, %v, want \"0 9 * * *\"", c, score, err)
+	}
+	if _, _, err := Infer(times, nil); err == nil {
+		t.Error("Infer() in UTC found an exact schedule across the change")
+	}
+}
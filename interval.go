@@ -0,0 +1,93 @@
+package cronmath
+
+import "time"
+
+// MinInterval returns the shortest time between consecutive firings, so
+// "0-5 9 * * *" gives a minute. Intervals are measured on a clock without
+// daylight saving changes. Expressions firing every day are worked out
+// from their times of day alone; others by scanning the days of a full
+// calendar cycle. Expressions that never fire return ErrNeverFires.
+func (c *CronTime) MinInterval() (time.Duration, error) {
+	shortest, _, err := c.intervals()
+	return shortest, err
+}
+
+// MaxInterval returns the longest time between consecutive firings, so
+// "0-5 9 * * *" gives 23h55m. See MinInterval.
+func (c *CronTime) MaxInterval() (time.Duration, error) {
+	_, longest, err := c.intervals()
+	return longest, err
+}
+
+// intervals returns the shortest and longest time between consecutive
+// firings
+func (c *CronTime) intervals() (shortest, longest time.Duration, err error) {
+	s, err := c.schedule()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The seconds of the day the schedule fires at, in order
+	var times []int
+	for _, h := range s.hour.values() {
+		for _, m := range s.minute.values() {
+			for _, sec := range s.second.values() {
+				times = append(times, h*3600+m*60+sec)
+			}
+		}
+	}
+	if len(times) == 0 {
+		return 0, 0, neverFires(c)
+	}
+
+	shortestDays, longestDays := 1, 1
+	if !s.everyDay() {
+		var ok bool
+		if shortestDays, longestDays, ok = s.dayGaps(); !ok {
+			return 0, 0, neverFires(c)
+		}
+	}
+
+	// Between days the gap runs from the last firing of one day to the
+	// first of the next
+	span := times[len(times)-1] - times[0]
+	lo, hi := shortestDays*24*3600-span, longestDays*24*3600-span
+	for i := 1; i < len(times); i++ {
+		gap := times[i] - times[i-1]
+		lo, hi = min(lo, gap), max(hi, gap)
+	}
+	return time.Duration(lo) * time.Second, time.Duration(hi) * time.Second, nil
+}
+
+// dayGaps returns the fewest and most days between consecutive days the
+// schedule fires on, over a full calendar cycle. It reports false when
+// the schedule fires on no day at all.
+func (s *schedule) dayGaps() (fewest, most int, ok bool) {
+	d := cycleStart
+	first, last := -1, -1
+	for i := 0; i < gregorianCycleDays; i, d = i+1, d.next() {
+		if !s.matchesDate(d) {
+			continue
+		}
+		if last == -1 {
+			first = i
+		} else {
+			gap := i - last
+			if fewest == 0 || gap < fewest {
+				fewest = gap
+			}
+			most = max(most, gap)
+		}
+		last = i
+	}
+	if first == -1 {
+		return 0, 0, false
+	}
+
+	// The cycle repeats, so the last day is followed by the first
+	wrap := first + gregorianCycleDays - last
+	if fewest == 0 || wrap < fewest {
+		fewest = wrap
+	}
+	return fewest, max(most, wrap), true
+}
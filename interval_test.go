@@ -0,0 +1,66 @@
+package cronmath
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIntervals(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{"0-5 9 * * *", time.Minute, 23*time.Hour + 55*time.Minute},
+		{"*/15 * * * *", 15 * time.Minute, 15 * time.Minute},
+		{"0 9,17 * * *", 8 * time.Hour, 16 * time.Hour},
+		{"0 9 * * *", 24 * time.Hour, 24 * time.Hour},
+		{"0 9 * * 1-5", 24 * time.Hour, 72 * time.Hour},
+		{"30 8 * * 1,4", 72 * time.Hour, 96 * time.Hour},
+		{"0 0 1 * *", 28 * 24 * time.Hour, 31 * 24 * time.Hour},
+		{"0 0 31 * *", 31 * 24 * time.Hour, 61 * 24 * time.Hour},
+		{"0 12 29 2 *", (4*365 + 1) * 24 * time.Hour, (8*365 + 1) * 24 * time.Hour},
+		{"0 0 1 * MON", 24 * time.Hour, 7 * 24 * time.Hour},
+		{"0 0-1 1 1 *", time.Hour, (366*24 - 1) * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			if got, err := c.MinInterval(); err != nil || got != tt.wantMin {
+				t.Errorf("MinInterval() = %v, %v, want %v", got, err, tt.wantMin)
+			}
+			if got, err := c.MaxInterval(); err != nil || got != tt.wantMax {
+				t.Errorf("MaxInterval() = %v, %v, want %v", got, err, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestIntervalsSeconds(t *testing.T) {
+	c, err := ParseCronWith("*/20 0 9 * * *", WithAutoFields())
+	if err != nil {
+		t.Fatalf("ParseCronWith: %v", err)
+	}
+	if got, _ := c.MinInterval(); got != 20*time.Second {
+		t.Errorf("MinInterval() = %v, want 20s", got)
+	}
+	if got, _ := c.MaxInterval(); got != 24*time.Hour-40*time.Second {
+		t.Errorf("MaxInterval() = %v, want 23h59m20s", got)
+	}
+}
+
+func TestIntervalsNeverFires(t *testing.T) {
+	c, _ := ParseCron("0 0 30 2 *")
+	if _, err := c.MinInterval(); !errors.Is(err, ErrNeverFires) {
+		t.Errorf("MinInterval() error = %v, want ErrNeverFires", err)
+	}
+	reboot, _ := ParseCron("@reboot")
+	if _, err := reboot.MaxInterval(); err == nil {
+		t.Error("MaxInterval() of @reboot succeeded, want an error")
+	}
+}
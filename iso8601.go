@@ -0,0 +1,220 @@
+package cronmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ToISO8601Repeat renders an expression firing at a fixed period as an
+// ISO 8601 repeating interval starting at its first firing at or after
+// anchor, in anchor's location: "0 9 * * *" becomes
+// "R/2024-01-01T09:00:00Z/P1D". Expressions firing every few minutes or
+// hours, daily, weekly on one day, monthly on one day or yearly on one
+// date are supported; others have no single period.
+func (c *CronTime) ToISO8601Repeat(anchor time.Time) (string, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return "", err
+	}
+
+	period, err := s.period()
+	if err != nil {
+		return "", fmt.Errorf("cannot express %q as a repeating interval: %v", c.String(), err)
+	}
+
+	start, ok := s.next(c.in(anchor))
+	if !ok {
+		return "", fmt.Errorf("cannot express %q as a repeating interval: it never fires", c.String())
+	}
+	return "R/" + start.Format(time.RFC3339) + "/" + period, nil
+}
+
+// period returns the ISO 8601 duration between consecutive firings of the
+// schedule, if it is fixed
+func (s *schedule) period() (string, error) {
+	everyMonth := s.month == monthField.fullSet()
+	anyDOM := s.dom == dayOfMonthField.fullSet() && s.domFromEnd == 0 && !s.domLastWeekday
+	anyDOW := s.dow == dayOfWeekField.fullSet() && s.dowNth == 0
+	times := expandDay(s.hour, s.minute)
+
+	switch {
+	case s.unionDays || s.domFromEnd != 0 || s.domLastWeekday || s.dowNth != 0:
+		return "", fmt.Errorf("its day fields have no fixed period")
+	case everyMonth && anyDOM && anyDOW:
+		if step, ok := evenSpacing(times); ok {
+			return formatPeriod(step), nil
+		}
+		return "", fmt.Errorf("its times of day are not evenly spaced around the clock")
+	case len(times) != 1:
+		return "", fmt.Errorf("it fires more than once on the days it is active")
+	case everyMonth && anyDOM && s.dow.len() == 1:
+		return "P7D", nil
+	case everyMonth && anyDOW && s.dom.len() == 1:
+		if day := s.dom.values()[0]; day > minMonthDays {
+			return "", fmt.Errorf("day %d does not occur in every month", day)
+		}
+		return "P1M", nil
+	case anyDOW && s.dom.len() == 1 && s.month.len() == 1:
+		if s.dom.values()[0] > daysIn(2001, time.Month(s.month.values()[0])) {
+			return "", fmt.Errorf("its date does not occur every year")
+		}
+		return "P1Y", nil
+	}
+	return "", fmt.Errorf("its day fields have no fixed period")
+}
+
+// evenSpacing returns the step between the given sorted minutes of the
+// day when they repeat evenly around the clock
+func evenSpacing(times []int) (int, bool) {
+	step := minutesPerDay / len(times)
+	if step*len(times) != minutesPerDay {
+		return 0, false
+	}
+	for i, t := range times {
+		if t != times[0]+i*step {
+			return 0, false
+		}
+	}
+	return step, true
+}
+
+// formatPeriod writes a number of minutes as an ISO 8601 duration
+func formatPeriod(minutes int) string {
+	switch {
+	case minutes%minutesPerDay == 0:
+		return fmt.Sprintf("P%dD", minutes/minutesPerDay)
+	case minutes%60 == 0:
+		return fmt.Sprintf("PT%dH", minutes/60)
+	}
+	return fmt.Sprintf("PT%dM", minutes)
+}
+
+// ParseISO8601Repeat converts an ISO 8601 repeating interval of the form
+// "R/start/period" into the expression firing at start and every period
+// after it, in start's own offset, which the expression is set to as with
+// WithLocation. Periods must divide an hour or a day
+// evenly, or be P7D, P1W, P1M or P1Y; "R/2024-01-01T09:00:00Z/P1D" becomes
+// "0 9 * * *". Repeat counts, seconds and periods cron cannot express,
+// such as PT90M, are rejected.
+func ParseISO8601Repeat(s string) (*CronTime, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "R") {
+		return nil, fmt.Errorf("invalid repeating interval %q: expected R/start/period", s)
+	}
+	if parts[0] != "R" {
+		return nil, fmt.Errorf("invalid repeating interval %q: repeat counts have no cron equivalent", s)
+	}
+
+	start, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid repeating interval %q: %v", s, err)
+	}
+	if start.Second() != 0 || start.Nanosecond() != 0 {
+		return nil, fmt.Errorf("invalid repeating interval %q: cron cannot start at seconds past the minute", s)
+	}
+
+	p, err := parsePeriod(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid repeating interval %q: %v", s, err)
+	}
+
+	c := &CronTime{
+		Minute:     strconv.Itoa(start.Minute()),
+		Hour:       strconv.Itoa(start.Hour()),
+		DayOfMonth: "*",
+		Month:      "*",
+		DayOfWeek:  "*",
+		cfg:        config{location: start.Location()},
+	}
+
+	calendar := p.years != 0 || p.months != 0
+	clock := p.weeks*7*minutesPerDay + p.days*minutesPerDay + p.hours*60 + p.minutes
+	switch {
+	case p.seconds != 0:
+		return nil, fmt.Errorf("period %s has seconds, which cron cannot express", parts[2])
+	case calendar && clock != 0:
+		return nil, fmt.Errorf("period %s mixes calendar and clock units", parts[2])
+	case p == isoPeriod{years: 1}:
+		if start.Month() == time.February && start.Day() == 29 {
+			return nil, fmt.Errorf("period %s from February 29 does not recur every year", parts[2])
+		}
+		c.DayOfMonth, c.Month = strconv.Itoa(start.Day()), strconv.Itoa(int(start.Month()))
+	case p == isoPeriod{months: 1}:
+		if start.Day() > minMonthDays {
+			return nil, fmt.Errorf("period %s from day %d does not recur every month", parts[2], start.Day())
+		}
+		c.DayOfMonth = strconv.Itoa(start.Day())
+	case calendar:
+		return nil, fmt.Errorf("period %s cannot be expressed in cron", parts[2])
+	case clock == 7*minutesPerDay:
+		c.DayOfWeek = strconv.Itoa(int(start.Weekday()))
+	case clock == minutesPerDay:
+	case clock > 0 && clock < 60 && 60%clock == 0:
+		c.Minute = formatSet(rangeSet(start.Minute()%clock, minuteField.max, clock), minuteField)
+		c.Hour = "*"
+	case clock > 0 && clock%60 == 0 && minutesPerDay%clock == 0:
+		n := clock / 60
+		c.Hour = formatSet(rangeSet(start.Hour()%n, hourField.max, n), hourField)
+	default:
+		return nil, fmt.Errorf("period %s does not divide an hour or a day evenly, so cron cannot express it", parts[2])
+	}
+	return c, nil
+}
+
+// isoPeriod is an ISO 8601 duration broken into its units
+type isoPeriod struct {
+	years, months, weeks, days, hours, minutes, seconds int
+}
+
+// parsePeriod parses an ISO 8601 duration such as "P1D" or "PT15M"
+func parsePeriod(s string) (isoPeriod, error) {
+	rest, ok := strings.CutPrefix(s, "P")
+	if !ok || rest == "" || rest == "T" {
+		return isoPeriod{}, fmt.Errorf("invalid period %q", s)
+	}
+
+	var p isoPeriod
+	inTime := false
+	for rest != "" {
+		if rest[0] == 'T' && !inTime {
+			inTime, rest = true, rest[1:]
+			continue
+		}
+
+		i := 0
+		for i < len(rest) && isDigit(rest[i]) {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return isoPeriod{}, fmt.Errorf("invalid period %q", s)
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return isoPeriod{}, fmt.Errorf("invalid period %q", s)
+		}
+
+		var unit *int
+		switch designator := rest[i]; {
+		case !inTime && designator == 'Y':
+			unit = &p.years
+		case !inTime && designator == 'M':
+			unit = &p.months
+		case !inTime && designator == 'W':
+			unit = &p.weeks
+		case !inTime && designator == 'D':
+			unit = &p.days
+		case inTime && designator == 'H':
+			unit = &p.hours
+		case inTime && designator == 'M':
+			unit = &p.minutes
+		case inTime && designator == 'S':
+			unit = &p.seconds
+		default:
+			return isoPeriod{}, fmt.Errorf("invalid period %q", s)
+		}
+		*unit, rest = n, rest[i+1:]
+	}
+	return p, nil
+}
@@ -0,0 +1,116 @@
+package cronmath
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronTime_ToISO8601Repeat(t *testing.T) {
+	// A Monday
+	anchor := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		cronStr string
+		want    string
+		wantErr bool
+	}{
+		{"0 9 * * *", "R/2024-01-01T09:00:00Z/P1D", false},
+		{"30 * * * *", "R/2024-01-01T00:30:00Z/PT1H", false},
+		{"*/15 * * * *", "R/2024-01-01T00:00:00Z/PT15M", false},
+		{"5 */6 * * *", "R/2024-01-01T00:05:00Z/PT6H", false},
+		{"0 9 * * WED", "R/2024-01-03T09:00:00Z/P7D", false},
+		{"0 9 15 * *", "R/2024-01-15T09:00:00Z/P1M", false},
+		{"0 9 1 7 *", "R/2024-07-01T09:00:00Z/P1Y", false},
+		{"0 9,17 * * *", "", true},
+		{"0 9 * * 1-5", "", true},
+		{"0 9 31 * *", "", true},
+		{"0 9 1 * MON", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, _ := ParseCron(tt.cronStr)
+			got, err := cron.ToISO8601Repeat(anchor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToISO8601Repeat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ToISO8601Repeat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseISO8601Repeat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"R/2024-01-01T09:00:00Z/P1D", "0 9 * * *", false},
+		{"R/2024-01-01T09:30:00Z/PT1H", "30 * * * *", false},
+		{"R/2024-01-01T09:05:00Z/PT15M", "5-50/15 * * * *", false},
+		{"R/2024-01-01T09:00:00Z/PT6H", "0 3-21/6 * * *", false},
+		{"R/2024-01-01T09:00:00+09:00/P7D", "0 9 * * 1", false},
+		{"R/2024-01-01T09:00:00Z/P1W", "0 9 * * 1", false},
+		{"R/2024-01-15T09:00:00Z/P1M", "0 9 15 * *", false},
+		{"R/2024-03-01T08:00:00Z/P1Y", "0 8 1 3 *", false},
+		{"R/2024-01-01T09:00:00Z/PT90M", "", true},
+		{"R/2024-01-31T09:00:00Z/P1M", "", true},
+		{"R/2024-02-29T09:00:00Z/P1Y", "", true},
+		{"R5/2024-01-01T09:00:00Z/P1D", "", true},
+		{"R/2024-01-01T09:00:30Z/P1D", "", true},
+		{"R/2024-01-01T09:00:00Z/PT30S", "", true},
+		{"R/2024-01-01T09:00:00Z/P1DT1H", "", true},
+		{"R/2024-01-01T09:00:00Z/P1M1D", "", true},
+		{"R/2024-01-01T09:00:00Z/P", "", true},
+		{"2024-01-01T09:00:00Z/P1D", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cron, err := ParseISO8601Repeat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseISO8601Repeat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("ParseISO8601Repeat() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseISO8601Repeat_Offset(t *testing.T) {
+	cron, err := ParseISO8601Repeat("R/2024-01-01T09:00:00+09:00/P1D")
+	if err != nil {
+		t.Fatalf("ParseISO8601Repeat() error = %v", err)
+	}
+	next, err := cron.Next(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if want := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Next() = %v, want %v", next, want)
+	}
+	if _, offset := next.Zone(); offset != 9*60*60 {
+		t.Errorf("Next() offset = %d, want %d", offset, 9*60*60)
+	}
+}
+
+func TestISO8601Repeat_RoundTrip(t *testing.T) {
+	anchor := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, cronStr := range []string{"0 9 * * *", "30 * * * *", "0 9 * * 3", "0 9 15 * *", "0 9 1 7 *"} {
+		cron, _ := ParseCron(cronStr)
+		iso, err := cron.ToISO8601Repeat(anchor)
+		if err != nil {
+			t.Fatalf("ToISO8601Repeat(%q) error = %v", cronStr, err)
+		}
+		back, err := ParseISO8601Repeat(iso)
+		if err != nil {
+			t.Fatalf("ParseISO8601Repeat(%q) error = %v", iso, err)
+		}
+		if back.String() != cronStr {
+			t.Errorf("round trip of %q through %q = %q", cronStr, iso, back.String())
+		}
+	}
+}
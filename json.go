@@ -0,0 +1,49 @@
+package cronmath
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// jsonCronMath is the JSON form of a CronMath, holding either the
+// expression or the error
+type jsonCronMath struct {
+	Expression *string    `json:"expression,omitempty"`
+	Error      *jsonError `json:"error,omitempty"`
+}
+
+type jsonError struct {
+	Kind    ErrorKind `json:"kind"`
+	Message string    `json:"message"`
+}
+
+// MarshalJSON encodes the result of the chain as {"expression": "0 9 * *
+// *"}, or as {"error": {"kind": "wildcard", "message": ...}} once it has
+// failed, the kind being that returned by KindOf
+func (cm *CronMath) MarshalJSON() ([]byte, error) {
+	if cm.err != nil {
+		return json.Marshal(jsonCronMath{Error: &jsonError{Kind: KindOf(cm.err), Message: cm.err.Error()}})
+	}
+	expr := cm.cron.String()
+	return json.Marshal(jsonCronMath{Expression: &expr})
+}
+
+// UnmarshalJSON replaces cm with New of the expression in
+// {"expression": ...}, read WithAutoFields so that the seconds and year
+// fields MarshalJSON writes out decode too. An expression that does not parse is reported by
+// Error, as with New. The error form cannot be decoded, as the error it
+// describes is lost.
+func (cm *CronMath) UnmarshalJSON(data []byte) error {
+	var v jsonCronMath
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch {
+	case v.Error != nil:
+		return errors.New("cannot decode a failed CronMath")
+	case v.Expression == nil:
+		return errors.New(`missing "expression" in CronMath JSON`)
+	}
+	*cm = *New(*v.Expression, WithAutoFields())
+	return nil
+}
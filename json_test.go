@@ -0,0 +1,81 @@
+package cronmath
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCronMath_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		cm   *CronMath
+		want string
+	}{
+		{"expression", New("0 8 * * *").Add(Hours(1)), `{"expression":"0 9 * * *"}`},
+		{"wildcard", New("* 9 * * *").Add(Minutes(5)), `{"error":{"kind":"wildcard","message":`},
+		{"parse", New("0 9 * *"), `{"error":{"kind":"parse","message":`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.cm)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if !strings.HasPrefix(string(data), tt.want) {
+				t.Errorf("json.Marshal() = %s, want prefix %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_UnmarshalJSON(t *testing.T) {
+	var req struct {
+		Schedule *CronMath `json:"schedule"`
+	}
+	if err := json.Unmarshal([]byte(`{"schedule": {"expression": "30 23 * * *"}}`), &req); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got, want := req.Schedule.Add(Hours(1)).String(), "30 0 * * *"; got != want {
+		t.Errorf("Add() = %q, want %q", got, want)
+	}
+
+	var invalid CronMath
+	if err := json.Unmarshal([]byte(`{"expression": "0 9 * *"}`), &invalid); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if KindOf(invalid.Error()) != KindParse {
+		t.Errorf("Error() = %v, want a parse error", invalid.Error())
+	}
+
+	for _, data := range []string{`{"error": {"kind": "parse", "message": "bad"}}`, `{}`, `"0 9 * * *"`} {
+		var cm CronMath
+		if err := json.Unmarshal([]byte(data), &cm); err == nil {
+			t.Errorf("json.Unmarshal(%s) expected error, got nil", data)
+		}
+	}
+}
+
+func TestCronMath_JSONRoundTrip(t *testing.T) {
+	for _, cm := range []*CronMath{
+		New("0 9 * * MON-FRI").Sub(Minutes(30)),
+		New("30 0 9 * * *", WithAutoFields()),
+		New("0 0 9 1 1 ? 2025", WithAutoFields()),
+	} {
+		data, err := json.Marshal(cm)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		var got CronMath
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+		}
+		if got.Error() != nil {
+			t.Fatalf("json.Unmarshal(%s) gave %v", data, got.Error())
+		}
+		if got.String() != cm.String() {
+			t.Errorf("round trip = %q, want %q", got.String(), cm.String())
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package cronmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Severity ranks how serious a lint warning is
+type Severity int
+
+const (
+	// SeverityWarning marks expressions that work but probably not as
+	// intended
+	SeverityWarning Severity = iota
+	// SeverityError marks expressions that can never fire
+	SeverityError
+)
+
+// String returns "warning" or "error"
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// WarningKind identifies the check that produced a warning
+type WarningKind string
+
+const (
+	// InvalidField reports a field that does not parse
+	InvalidField WarningKind = "invalid-field"
+	// ImpossibleDate reports a day of month that exists in none of the
+	// months the expression is restricted to
+	ImpossibleDate WarningKind = "impossible-date"
+	// LeapDayOnly reports an expression that can only fire on February 29
+	LeapDayOnly WarningKind = "leap-day-only"
+	// DayUnion reports an expression restricting both day of month and
+	// day of week, which fires when either matches rather than both
+	DayUnion WarningKind = "day-union"
+	// YearUnchanged reports a year shift that had no year to move
+	YearUnchanged WarningKind = "year-unchanged"
+	// UnevenStep reports a step that does not divide its range, so the
+	// gaps between firings are not all the same
+	UnevenStep WarningKind = "uneven-step"
+)
+
+// Warning is a single finding of Lint
+type Warning struct {
+	Kind     WarningKind
+	Severity Severity
+	// Field is the name of the field the warning is about, if any
+	Field   string
+	Message string
+	// Suggestion optionally proposes an alternative expression
+	Suggestion string
+}
+
+// String renders the warning as "severity: message"
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Severity, w.Message)
+}
+
+// Lint checks the expression for mistakes that parse fine but make it
+// fire differently than intended, or not at all, such as "0 0 30 2 *".
+func (c *CronTime) Lint() []Warning {
+	if c.IsReboot() {
+		return nil
+	}
+
+	var warnings []Warning
+
+	valid := true
+	for i, field := range c.fieldPtrs() {
+		spec := standardFields[i]
+		if err := parseStandardField(i, *field); err != nil {
+			warnings = append(warnings, Warning{
+				Kind:     InvalidField,
+				Severity: SeverityError,
+				Field:    spec.name,
+				Message:  fmt.Sprintf("invalid %s %q: %v", spec.name, *field, err),
+			})
+			valid = false
+		}
+	}
+	if !valid {
+		return warnings
+	}
+
+	s, _ := c.schedule()
+	warnings = append(warnings, lintDates(c, s)...)
+	warnings = append(warnings, lintDayUnion(c, s)...)
+	warnings = append(warnings, lintSteps(c)...)
+	return warnings
+}
+
+// lintSteps flags steps that do not divide their range evenly, as
+// "0-59/7" fires at :56 and then four minutes later at :00. The day
+// fields are left alone: weeks and months restart them anyway, and
+// "*/2" for every other day is written knowing that.
+func lintSteps(c *CronTime) []Warning {
+	type stepped struct {
+		spec        fieldSpec
+		value, unit string
+	}
+	fields := []stepped{
+		{minuteField, c.Minute, "minute"},
+		{hourField, c.Hour, "hour"},
+		{monthField, c.Month, "month"},
+	}
+	if c.layout.hasSeconds() {
+		fields = append(fields, stepped{secondField, c.Second, "second"})
+	}
+
+	var warnings []Warning
+	for _, field := range fields {
+		for _, part := range strings.Split(field.value, ",") {
+			if msg, ok := unevenStep(part, field.spec, field.unit); ok {
+				warnings = append(warnings, Warning{
+					Kind:     UnevenStep,
+					Severity: SeverityWarning,
+					Field:    field.spec.name,
+					Message:  msg,
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// unevenStep describes how the stepped element part of a field fires
+// unevenly, if it does
+func unevenStep(part string, f fieldSpec, unit string) (string, bool) {
+	if !strings.Contains(part, "/") {
+		return "", false
+	}
+	lo, hi, step, err := parseRange(part, f)
+	if err != nil || hi-lo < step {
+		return "", false
+	}
+
+	last := lo + (hi-lo)/step*step
+	var fires []string
+	for v := lo; v <= last; v += step {
+		fires = append(fires, strconv.Itoa(v))
+	}
+	pattern := strings.Join(fires, ", ")
+
+	if hi < f.max {
+		// A bounded range is meant to pause, but not to stop short
+		if last == hi {
+			return "", false
+		}
+		return fmt.Sprintf("%s %s fires at %s, stopping %s before %d, as %d does not divide %d-%d evenly",
+			f.name, part, pattern, plural(hi-last, unit), hi, step, lo, hi), true
+	}
+
+	// The range runs to the end of the field, so the firings continue
+	// from lo in the next cycle
+	wrap := f.max + 1 - last + lo - f.min
+	if wrap == step {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s fires at %s and then %s later at %d: the gaps are uneven, the largest %s",
+		f.name, part, pattern, plural(wrap, unit), lo, plural(max(step, wrap), unit)), true
+}
+
+// plural renders n units, e.g. "1 minute" or "4 minutes"
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// lintDayUnion flags expressions where both day fields are restricted, as
+// "0 9 13 * 5" fires on every 13th and on every Friday, not only on
+// Friday the 13th
+func lintDayUnion(c *CronTime, s *schedule) []Warning {
+	if !s.unionDays {
+		return nil
+	}
+
+	return []Warning{{
+		Kind:     DayUnion,
+		Severity: SeverityWarning,
+		Field:    dayOfWeekField.name,
+		Message: fmt.Sprintf("both day of month (%s) and day of week (%s) are restricted, so the expression fires on days matching either, not only days matching both",
+			c.DayOfMonth, c.DayOfWeek),
+		Suggestion: fmt.Sprintf("Quartz keeps the two conditions apart: %q for the days of month and %q for the days of week",
+			fmt.Sprintf("0 %s %s %s %s ?", c.Minute, c.Hour, c.DayOfMonth, c.Month),
+			fmt.Sprintf("0 %s %s ? %s %s", c.Minute, c.Hour, c.Month, formatNamedSet(s.dow, dayOfWeekField))),
+	}}
+}
+
+// lintDates checks that the day-of-month field names a day that exists in
+// at least one of the listed months
+func lintDates(c *CronTime, s *schedule) []Warning {
+	// Days of the week fire on their own when both day fields are
+	// restricted, so the day of month cannot make the expression dead
+	if !isRestricted(c.DayOfMonth) || s.unionDays {
+		return nil
+	}
+
+	// The last days of a month exist in every month
+	common := s.domFromEnd != 0 || s.domLastWeekday
+	leap := false
+	for _, m := range s.month.values() {
+		month := time.Month(m)
+		for _, d := range s.dom.values() {
+			switch {
+			case d <= daysIn(2001, month):
+				common = true
+			case d <= daysIn(2000, month):
+				leap = true
+			}
+		}
+	}
+
+	switch {
+	case common:
+		return nil
+	case leap:
+		return []Warning{{
+			Kind:     LeapDayOnly,
+			Severity: SeverityWarning,
+			Field:    dayOfMonthField.name,
+			Message:  fmt.Sprintf("day of month %s with month %s only fires in leap years", c.DayOfMonth, c.Month),
+		}}
+	default:
+		return []Warning{{
+			Kind:     ImpossibleDate,
+			Severity: SeverityError,
+			Field:    dayOfMonthField.name,
+			Message:  fmt.Sprintf("day of month %s does not exist in %s, so the expression never fires", c.DayOfMonth, describeMonths(s.month)),
+		}}
+	}
+}
+
+// describeMonths names the months of a set, e.g. "April, June"
+func describeMonths(months valueSet) string {
+	names := make([]string, 0, months.len())
+	for _, m := range months.values() {
+		names = append(names, time.Month(m).String())
+	}
+	if len(names) == 12 {
+		return "any month"
+	}
+	return strings.Join(names, ", ")
+}
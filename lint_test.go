@@ -0,0 +1,187 @@
+package cronmath
+
+import "testing"
+
+func TestCronTime_Lint(t *testing.T) {
+	tests := []struct {
+		name     string
+		cronStr  string
+		want     []WarningKind
+		severity Severity
+	}{
+		{"ordinary daily", "0 9 * * *", nil, 0},
+		{"day that always exists", "0 0 28 2 *", nil, 0},
+		{"february 30th", "0 0 30 2 *", []WarningKind{ImpossibleDate}, SeverityError},
+		{"31st of short months", "0 0 31 4,6,9,11 *", []WarningKind{ImpossibleDate}, SeverityError},
+		{"31st with one long month", "0 0 31 4,6,7 *", nil, 0},
+		{"some days possible", "0 0 15,31 4 *", nil, 0},
+		{"leap day", "0 9 29 2 *", []WarningKind{LeapDayOnly}, SeverityWarning},
+		{"leap day or later", "0 9 29-31 2 *", []WarningKind{LeapDayOnly}, SeverityWarning},
+		{"weekday keeps it alive", "0 0 30 2 1", []WarningKind{DayUnion}, SeverityWarning},
+		{"starred weekday does not", "0 0 30 2 */2", []WarningKind{ImpossibleDate}, SeverityError},
+		{"named month", "0 0 30 FEB *", []WarningKind{ImpossibleDate}, SeverityError},
+		{"invalid field", "0 0 32 * *", []WarningKind{InvalidField}, SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+
+			warnings := cron.Lint()
+			if len(warnings) != len(tt.want) {
+				t.Fatalf("Lint() = %v, want kinds %v", warnings, tt.want)
+			}
+			for i, w := range warnings {
+				if w.Kind != tt.want[i] {
+					t.Errorf("Lint()[%d].Kind = %q, want %q", i, w.Kind, tt.want[i])
+				}
+				if w.Severity != tt.severity {
+					t.Errorf("Lint()[%d].Severity = %v, want %v", i, w.Severity, tt.severity)
+				}
+				if w.Message == "" {
+					t.Errorf("Lint()[%d].Message is empty", i)
+				}
+			}
+		})
+	}
+}
+
+func TestCronTime_LintMessage(t *testing.T) {
+	cron, _ := ParseCron("0 0 31 4,6 *")
+	warnings := cron.Lint()
+	if len(warnings) != 1 {
+		t.Fatalf("Lint() = %v, want one warning", warnings)
+	}
+
+	want := "error: day of month 31 does not exist in April, June, so the expression never fires"
+	if got := warnings[0].String(); got != want {
+		t.Errorf("Lint()[0].String() = %q, want %q", got, want)
+	}
+	if warnings[0].Field != "day of month" {
+		t.Errorf("Lint()[0].Field = %q, want %q", warnings[0].Field, "day of month")
+	}
+}
+
+func TestParseCronWith_Strict(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		wantErr bool
+	}{
+		{"0 9 * * *", false},
+		{"0 9 29 2 *", false},
+		{"0 0 30 2 *", true},
+		{"0 25 * * *", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			_, err := ParseCronWith(tt.cronStr, WithStrict())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCronWith(%q, WithStrict()) error = %v, wantErr %v", tt.cronStr, err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ParseCron("0 0 30 2 *"); err != nil {
+		t.Errorf("ParseCron() without strict mode error = %v, want nil", err)
+	}
+}
+
+func TestCronTime_LintDayUnion(t *testing.T) {
+	cron, _ := ParseCron("0 9 13 * 5")
+	warnings := cron.Lint()
+	if len(warnings) != 1 || warnings[0].Kind != DayUnion {
+		t.Fatalf("Lint() = %v, want one %s warning", warnings, DayUnion)
+	}
+
+	w := warnings[0]
+	if w.Severity != SeverityWarning {
+		t.Errorf("Severity = %v, want %v", w.Severity, SeverityWarning)
+	}
+	wantSuggestion := `Quartz keeps the two conditions apart: "0 0 9 13 * ?" for the days of month and "0 0 9 ? * FRI" for the days of week`
+	if w.Suggestion != wantSuggestion {
+		t.Errorf("Suggestion = %q, want %q", w.Suggestion, wantSuggestion)
+	}
+
+	for _, expr := range []string{"0 9 13 * *", "0 9 * * 5", "0 9 */2 * 5", "0 9 13 * */2"} {
+		cron, _ := ParseCron(expr)
+		for _, w := range cron.Lint() {
+			if w.Kind == DayUnion {
+				t.Errorf("Lint(%q) reported %s, want none", expr, DayUnion)
+			}
+		}
+	}
+
+	cron, _ = ParseCronWith("0 9 13 * 5", WithDOMDOWIntersection())
+	if warnings := cron.Lint(); len(warnings) != 0 {
+		t.Errorf("Lint() with WithDOMDOWIntersection = %v, want none", warnings)
+	}
+}
+
+func TestFormatNamedSet(t *testing.T) {
+	tests := []struct {
+		field string
+		spec  fieldSpec
+		want  string
+	}{
+		{"1-5", dayOfWeekField, "MON-FRI"},
+		{"0,6", dayOfWeekField, "SUN,SAT"},
+		{"1,3,5,10-12", monthField, "JAN,MAR,MAY,OCT-DEC"},
+	}
+	for _, tt := range tests {
+		s, _ := parseSet(tt.field, tt.spec)
+		if got := formatNamedSet(s, tt.spec); got != tt.want {
+			t.Errorf("formatNamedSet(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestCronTime_LintUnevenStep(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		opts    []Option
+		want    []string
+	}{
+		{"*/15 * * * *", nil, nil},
+		{"5/15 * * * *", nil, nil},
+		{"0-30/5 * * * *", nil, nil},
+		{"0 */6 * * *", nil, nil},
+		{"0 0 1 */3 *", nil, nil},
+		{"0 0 * * */2", nil, nil},
+		{"0-59/7 * * * *", nil, []string{"minute 0-59/7 fires at 0, 7, 14, 21, 28, 35, 42, 49, 56 and then 4 minutes later at 0: the gaps are uneven, the largest 7 minutes"}},
+		{"0 */5 * * *", nil, []string{"hour */5 fires at 0, 5, 10, 15, 20 and then 4 hours later at 0: the gaps are uneven, the largest 5 hours"}},
+		{"0 0 1 */5 *", nil, []string{"month */5 fires at 1, 6, 11 and then 2 months later at 1: the gaps are uneven, the largest 5 months"}},
+		{"0-30/7 * * * *", nil, []string{"minute 0-30/7 fires at 0, 7, 14, 21, 28, stopping 2 minutes before 30, as 7 does not divide 0-30 evenly"}},
+		{"0,*/25 9 * * *", nil, []string{"minute */25 fires at 0, 25, 50 and then 10 minutes later at 0: the gaps are uneven, the largest 25 minutes"}},
+		{"*/45 0 9 * * *", []Option{WithAutoFields()}, []string{"second */45 fires at 0, 45 and then 15 seconds later at 0: the gaps are uneven, the largest 45 seconds"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			var got []string
+			for _, w := range cron.Lint() {
+				if w.Kind == UnevenStep {
+					if w.Severity != SeverityWarning {
+						t.Errorf("Lint() severity = %v, want warning", w.Severity)
+					}
+					got = append(got, w.Message)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Lint() uneven steps = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Lint()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package cronmath
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// catalog holds the phrases Describe builds descriptions from in one
+// language
+type catalog struct {
+	everyMinute    string
+	everyMinutes   func(n string) string
+	minuteOfHour   func(minute int) string
+	minutePastHour func(minute, hour string) string
+	clock          func(hour, minute int) string
+	atTimes        func(times string) string
+
+	// dayNumber renders a day of the month in a list of days, and
+	// monthDays wraps that list of n days
+	dayNumber   func(day int) string
+	monthDays   func(days string, n int) string
+	fromEnd     func(k int) string
+	lastWeekday string
+	ofMonth     func(days string) string
+
+	weekday    func(time.Weekday) string
+	nthWeekday func(n int, d time.Weekday) string
+	weekdays   func(days string) string
+	// workweek names Monday through Friday, or is empty to list them
+	workweek string
+
+	month   func(time.Month) string
+	either  func(dom, dow string) string
+	both    func(dom, dow string) string
+	through func(from, to string) string
+	list    func(items []string) string
+
+	sentence func(p phrase) string
+}
+
+// phrase is a description split into the parts a catalog orders
+type phrase struct {
+	clock string
+	// daily is set when clock names fixed times of day
+	daily bool
+
+	days string
+	// monthly is set when days are positions in the month
+	monthly bool
+
+	months string
+}
+
+// catalogs maps primary language subtags to their catalogs
+var catalogs = map[string]*catalog{
+	"en": &english,
+	"ja": &japanese,
+}
+
+// catalogFor returns the catalog for a language tag, or English when there
+// is none
+func catalogFor(lang string) *catalog {
+	primary, _, _ := strings.Cut(strings.ToLower(lang), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	if cat, ok := catalogs[primary]; ok {
+		return cat
+	}
+	return &english
+}
+
+var ordinals = [...]string{1: "first", 2: "second", 3: "third", 4: "fourth", 5: "fifth"}
+
+var english = catalog{
+	everyMinute:    "every minute",
+	everyMinutes:   func(n string) string { return fmt.Sprintf("every %s minutes", n) },
+	minuteOfHour:   func(m int) string { return fmt.Sprintf("at minute %d of every hour", m) },
+	minutePastHour: func(m, h string) string { return fmt.Sprintf("at minute %s past hour %s", m, h) },
+	clock:          func(h, m int) string { return fmt.Sprintf("%02d:%02d", h, m) },
+	atTimes:        func(times string) string { return "at " + times },
+
+	monthDays: func(days string, n int) string {
+		if n == 1 {
+			return "day " + days
+		}
+		return "days " + days
+	},
+	fromEnd: func(k int) string {
+		switch k {
+		case 0:
+			return "the last day"
+		case 1:
+			return "1 day before the last day"
+		}
+		return fmt.Sprintf("%d days before the last day", k)
+	},
+	lastWeekday: "the last weekday",
+	ofMonth:     func(days string) string { return "on " + days + " of the month" },
+
+	weekday: time.Weekday.String,
+	nthWeekday: func(n int, d time.Weekday) string {
+		return fmt.Sprintf("the %s %s of the month", ordinals[n], d)
+	},
+	weekdays: func(days string) string { return "on " + days },
+
+	month:   time.Month.String,
+	either:  func(dom, dow string) string { return dom + " or " + dow },
+	both:    func(dom, dow string) string { return dom + " if it falls " + dow },
+	through: func(from, to string) string { return from + " through " + to },
+	list:    func(items []string) string { return joinList(items, ", ", " and ") },
+
+	sentence: func(p phrase) string {
+		parts := []string{p.clock}
+		if p.days != "" {
+			parts = append(parts, p.days)
+		}
+		if p.months != "" {
+			parts = append(parts, "in "+p.months)
+		}
+		return strings.Join(parts, " ")
+	},
+}
+
+// japaneseWeekdays are the Japanese names of the days of the week
+var japaneseWeekdays = [...]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"}
+
+var japanese = catalog{
+	everyMinute:    "毎分",
+	everyMinutes:   func(n string) string { return n + "分ごと" },
+	minuteOfHour:   func(m int) string { return fmt.Sprintf("毎時%d分", m) },
+	minutePastHour: func(m, h string) string { return fmt.Sprintf("%s時の%s分", h, m) },
+	clock:          func(h, m int) string { return fmt.Sprintf("%d時%02d分", h, m) },
+	atTimes:        func(times string) string { return times },
+
+	dayNumber: func(d int) string { return fmt.Sprintf("%d日", d) },
+	monthDays: func(days string, _ int) string { return days },
+	fromEnd: func(k int) string {
+		if k == 0 {
+			return "末日"
+		}
+		return fmt.Sprintf("末日の%d日前", k)
+	},
+	lastWeekday: "最終平日",
+	ofMonth:     func(days string) string { return days },
+
+	weekday:    func(d time.Weekday) string { return japaneseWeekdays[d] },
+	nthWeekday: func(n int, d time.Weekday) string { return fmt.Sprintf("第%d%s", n, japaneseWeekdays[d]) },
+	weekdays:   func(days string) string { return days },
+	workweek:   "平日",
+
+	month:   func(m time.Month) string { return fmt.Sprintf("%d月", m) },
+	either:  func(dom, dow string) string { return dom + "または" + dow },
+	both:    func(dom, dow string) string { return dom + "かつ" + dow },
+	through: func(from, to string) string { return from + "から" + to },
+	list:    func(items []string) string { return joinList(items, "、", "と") },
+
+	// Japanese names the date before the time, "毎月1日 9時00分"
+	sentence: func(p phrase) string {
+		var date string
+		switch {
+		case p.months != "" && p.days != "":
+			date = p.months + "の" + p.days
+		case p.months != "":
+			date = p.months + "の毎日"
+		case p.monthly:
+			date = "毎月" + p.days
+		case p.days != "":
+			date = p.days
+		case p.daily:
+			date = "毎日"
+		}
+		if date == "" {
+			return p.clock
+		}
+		return date + " " + p.clock
+	},
+}
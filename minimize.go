@@ -0,0 +1,127 @@
+package cronmath
+
+import (
+	"slices"
+	"strings"
+)
+
+// Minimize drops the redundant parts of every field without changing when
+// the expression fires, and then keeps for each field the shorter of that
+// and the Compress rendering. Ranges of a single value become the value,
+// "5-5" becoming "5", list elements repeated or covered by the rest of the
+// list are dropped, and fields matching every value become "*", as in
+// "0 9 1-31 * 0-7" minimized to "0 9 * * *". The day fields follow cron's
+// day-of-month/day-of-week OR rule as described for Normalize. "@reboot"
+// is left as it is.
+func (c *CronTime) Minimize() error {
+	if c.IsReboot() {
+		return nil
+	}
+	want, err := c.schedule()
+	if err != nil {
+		return err
+	}
+
+	m := *c
+	for i, field := range m.fieldPtrs() {
+		simplified := simplifyList(*field, standardFields[i])
+		// Whether a day field is restricted is left to collapse
+		if (i == dayOfMonthIndex || i == dayOfWeekIndex) && isRestricted(simplified) != isRestricted(*field) {
+			continue
+		}
+		*field = simplified
+	}
+	if m.layout.hasSeconds() {
+		m.Second = simplifyList(m.Second, secondField)
+	}
+	m.collapse()
+
+	compressed := *c
+	if err := compressed.Compress(); err != nil {
+		return err
+	}
+
+	out := m
+	for _, f := range [][2]*string{{&out.Minute, &compressed.Minute}, {&out.Hour, &compressed.Hour}, {&out.Month, &compressed.Month}} {
+		if len(*f[1]) < len(*f[0]) {
+			*f[0] = *f[1]
+		}
+	}
+	// The day fields are taken as a pair, as each decides how the other
+	// combines with it
+	if len(compressed.DayOfMonth)+len(compressed.DayOfWeek) < len(out.DayOfMonth)+len(out.DayOfWeek) {
+		out.DayOfMonth, out.DayOfWeek = compressed.DayOfMonth, compressed.DayOfWeek
+	}
+
+	// Keep the expression as written should both fire differently
+	for _, candidate := range []CronTime{out, m} {
+		if got, err := candidate.schedule(); err == nil && got.equivalent(want) {
+			*c = candidate
+			break
+		}
+	}
+	return nil
+}
+
+// simplifyList drops the redundant parts of a field: single-value ranges
+// become the value, a range spanning the whole field becomes "*", and
+// elements repeated or covered by the rest of the list are dropped.
+// Elements that do not parse as plain values, ranges and steps are kept
+// as written.
+func simplifyList(field string, f fieldSpec) string {
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		parts[i] = simplifyPart(part, f)
+	}
+
+	sets := make([]valueSet, len(parts))
+	for i, part := range parts {
+		s, err := parseSetPart(part, f)
+		if err != nil || strings.ContainsAny(part, "LW#?") {
+			s = 0
+		}
+		sets[i] = s
+	}
+
+	kept := parts[:0:0]
+	for i, part := range parts {
+		if slices.Contains(kept, part) {
+			continue
+		}
+		if sets[i] != 0 && len(parts) > 1 {
+			var rest valueSet
+			for j, s := range sets {
+				if j != i {
+					rest |= s
+				}
+			}
+			if sets[i]&^rest == 0 {
+				// Covered by the others, which no longer count on it
+				sets[i] = 0
+				continue
+			}
+		}
+		kept = append(kept, part)
+	}
+	return strings.Join(kept, ",")
+}
+
+// simplifyPart rewrites a range matching a single value as the value and
+// a range spanning the whole field as "*"
+func simplifyPart(part string, f fieldSpec) string {
+	base, step, hasStep := strings.Cut(part, "/")
+	lo, _, isRange := strings.Cut(base, "-")
+	s, err := parseSetPart(part, f)
+	switch {
+	case !isRange || err != nil:
+		return part
+	case s.len() == 1:
+		return lo
+	case s == f.fullSet():
+		return "*"
+	}
+	if whole, err := parseSetPart("*/"+step, f); hasStep && err == nil && s == whole {
+		return "*/" + step
+	}
+	return part
+}
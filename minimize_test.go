@@ -0,0 +1,91 @@
+package cronmath
+
+import "testing"
+
+func TestCronTime_Minimize(t *testing.T) {
+	tests := []struct {
+		cronStr string
+		opts    []Option
+		want    string
+	}{
+		{"0 9 1-31 * 0-7", nil, "0 9 * * *"},
+		{"0 9 * 1-12 *", nil, "0 9 * * *"},
+		{"5-5 9 * * *", nil, "5 9 * * *"},
+		{"0,0,30 9 * * *", nil, "0,30 9 * * *"},
+		{"0-10,5 9 * * *", nil, "0-10 9 * * *"},
+		{"0 9-17,12 * * MON-MON", nil, "0 9-17 * * 1"},
+		{"0-59/15 * * * *", nil, "*/15 * * * *"},
+		{"0,15,30,45 * * * *", nil, "*/15 * * * *"},
+		{"0 9 10-10/5 * *", nil, "0 9 10 * *"},
+		{"0 0 1,2,3,4,5 * *", nil, "0 0 1-5 * *"},
+		// Restricted day fields keep their restriction under the OR rule
+		{"0 9 1-31 * 1", nil, "0 9 * * *"},
+		{"0 9 13 * 0-7", nil, "0 9 * * *"},
+		{"0 9 13 * 1-1", nil, "0 9 13 * 1"},
+		{"0 9 1-31/2 * 1", nil, "0 9 1-31/2 * 1"},
+		{"0 9 L,L * 5", nil, "0 9 L * 5"},
+		{"0-59 0-23 * * *", nil, "* * * * *"},
+		{"0-59/30 0 9 * * *", []Option{WithAutoFields()}, "*/30 0 9 * * *"},
+		{"@reboot", nil, "@reboot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if err := cron.Minimize(); err != nil {
+				t.Fatalf("Minimize() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("Minimize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCronTime_MinimizePreservesSchedule checks that Minimize, alone and
+// composed with Compress and Normalize, keeps the field expansion
+func TestCronTime_MinimizePreservesSchedule(t *testing.T) {
+	exprs := []string{
+		"0 9 1-31 * 0-7", "0 9 13 * 5", "0 9 1-31 * 1", "0 9 */2 * 1-5",
+		"*/7 3-3,4 1,1,15 1-12 *", "0-30/10,5 9-17/2 * JAN-JUN 1-5",
+		"0 0 L-2,L * *", "0 0 * * 5#2,5#2", "0 9 1-7 * 1", "0,30 * 31 4,6 *",
+	}
+	steps := map[string]func(*CronTime) error{
+		"Minimize":          (*CronTime).Minimize,
+		"Compress Minimize": func(c *CronTime) error { c.Compress(); return c.Minimize() },
+		"Minimize Compress": func(c *CronTime) error { c.Minimize(); return c.Compress() },
+		"Minimize Normalize": func(c *CronTime) error {
+			c.Minimize()
+			return c.Normalize()
+		},
+	}
+
+	for _, expr := range exprs {
+		for name, step := range steps {
+			cron, err := ParseCron(expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", expr, err)
+			}
+			want, _ := cron.schedule()
+			if err := step(cron); err != nil {
+				t.Fatalf("%s(%q) error = %v", name, expr, err)
+			}
+			got, err := cron.schedule()
+			if err != nil || !got.equivalent(want) {
+				t.Errorf("%s(%q) = %q, which fires differently", name, expr, cron.String())
+			}
+			if len(cron.String()) > len(expr) {
+				t.Errorf("%s(%q) = %q, longer than the original", name, expr, cron.String())
+			}
+		}
+	}
+}
+
+func TestCronMath_Minimize(t *testing.T) {
+	if got := New("0-59/15 9-9 * 1-12 *").Minimize().String(); got != "*/15 9 * * *" {
+		t.Errorf("Minimize() = %q, want %q", got, "*/15 9 * * *")
+	}
+}
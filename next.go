@@ -0,0 +1,175 @@
+package cronmath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNeverFires is returned when asking for an occurrence of an
+// expression that matches no date at all, such as "0 0 30 2 *"
+var ErrNeverFires = errors.New("expression never fires")
+
+// Next returns the first time strictly after after at which the
+// expression fires, in the location given by WithLocation or else in
+// after's location. Times skipped by a daylight saving change are moved
+// forward as time.Date does.
+func (c *CronTime) Next(after time.Time) (time.Time, error) {
+	return c.NextContext(context.Background(), after)
+}
+
+// NextContext is like Next, but gives up when ctx is done, returning a
+// *ScanError with ctx's error and the date the scan had reached. Sparse
+// expressions such as "0 0 29 2 *" can be scanned for years ahead.
+func (c *CronTime) NextContext(ctx context.Context, after time.Time) (time.Time, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return time.Time{}, err
+	}
+	next, ok, err := s.nextContext(ctx, c.in(after).Truncate(time.Second).Add(time.Second), gregorianCycleDays)
+	switch {
+	case err != nil:
+		return time.Time{}, err
+	case !ok:
+		return time.Time{}, neverFires(c)
+	}
+	return next, nil
+}
+
+// NextN returns the next n times after after at which the expression
+// fires. See Next.
+func (c *CronTime) NextN(after time.Time, n int) ([]time.Time, error) {
+	if n <= 0 {
+		if _, err := c.schedule(); err != nil {
+			return nil, err
+		}
+		return []time.Time{}, nil
+	}
+
+	times := make([]time.Time, 0, n)
+	for t, err := range c.OccurrencesContext(context.Background(), after) {
+		if err != nil {
+			return nil, err
+		}
+		if times = append(times, t); len(times) == n {
+			break
+		}
+	}
+	return times, nil
+}
+
+// neverFires returns ErrNeverFires for c
+func neverFires(c *CronTime) error {
+	return fmt.Errorf("%w: %s", ErrNeverFires, c.String())
+}
+
+// NeverFires reports whether the expression matches no date at all, and
+// why, so "0 0 31 4 *" never fires as "day of month 31 does not exist in
+// April". Days of the month missing from every listed month are found
+// from the fields; any other combination of the day fields and months is
+// checked by scanning a full calendar cycle, so "0 0 * 2 MON#5" fires as
+// February has a fifth Monday in some leap years. An expression that does
+// not parse never fires either, with its error as the reason.
+func (c *CronTime) NeverFires() (bool, string) {
+	s, err := c.schedule()
+	switch {
+	case c.IsReboot():
+		return false, ""
+	case err != nil:
+		return true, err.Error()
+	}
+
+	if w := lintDates(c, s); len(w) != 0 && w[0].Kind == ImpossibleDate {
+		return true, fmt.Sprintf("day of month %s does not exist in %s", c.DayOfMonth, describeMonths(s.month))
+	}
+	if _, ok := s.next(cycleStart.at(0, 0, time.UTC)); !ok {
+		return true, fmt.Sprintf("no date matches day of month %s, month %s and day of week %s", c.DayOfMonth, c.Month, c.DayOfWeek)
+	}
+	return false, ""
+}
+
+// in returns t in the location the expression fires in
+func (c *CronTime) in(t time.Time) time.Time {
+	if c.cfg.location != nil {
+		return t.In(c.cfg.location)
+	}
+	return t
+}
+
+// next returns the first time at or after t, rounded up to the second, at
+// which the schedule fires, in t's location. It reports false when the
+// schedule never fires.
+func (s *schedule) next(t time.Time) (time.Time, bool) {
+	next, ok, _ := s.nextContext(context.Background(), t, gregorianCycleDays)
+	return next, ok
+}
+
+// scanCheckDays is how many days a scan goes between checks of its
+// context
+const scanCheckDays = 256
+
+// nextContext is next, scanning at most days days past the day of t and
+// checking ctx as it goes. It reports false when no firing is found in
+// that span, and a *ScanError when ctx is done first.
+func (s *schedule) nextContext(ctx context.Context, t time.Time, days int) (time.Time, bool, error) {
+	if rounded := t.Truncate(time.Second); rounded.Before(t) {
+		t = rounded.Add(time.Second)
+	}
+
+	loc := t.Location()
+	d := dateOf(t)
+	// Nothing fires past the last year of a year field
+	if last, ok := s.year.last(); ok {
+		days = min(days, int(time.Date(last+1, time.January, 1, 0, 0, 0, 0, time.UTC).Sub(d.at(0, 0, time.UTC))/(24*time.Hour)))
+	}
+	for i := 0; i <= days; i++ {
+		if i%scanCheckDays == 0 {
+			if err := ctx.Err(); err != nil {
+				return time.Time{}, false, &ScanError{Reached: d.at(0, 0, loc), Err: err}
+			}
+		}
+		if s.matchesDate(d) {
+			if next, ok := s.firstOn(d, loc, t); ok {
+				return next, true, nil
+			}
+		}
+		d = d.next()
+	}
+	return time.Time{}, false, nil
+}
+
+// ScanError reports a scan for firings stopped by its context. Reached is
+// the start of the day the scan had got to.
+type ScanError struct {
+	Reached time.Time
+	Err     error
+}
+
+// Error renders the error as "scan stopped at 2031-04-02: context canceled"
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("scan stopped at %s: %v", e.Reached.Format(time.DateOnly), e.Err)
+}
+
+// Unwrap returns the error of the context
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// firstOn returns the earliest firing on d that is not before start
+func (s *schedule) firstOn(d date, loc *time.Location, start time.Time) (time.Time, bool) {
+	for _, h := range s.hour.values() {
+		for _, m := range s.minute.values() {
+			// Skip whole minutes before start; the seconds decide the rest
+			if d.at(h, m, loc).Add(time.Minute).Before(start) {
+				continue
+			}
+			for _, sec := range s.second.values() {
+				if t := d.at(h, m, loc).Add(time.Duration(sec) * time.Second); !t.Before(start) {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,200 @@
+package cronmath
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCronTime_Next(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		after   time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{"same day", "30 9 * * *", nil, time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC), time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC), nil},
+		{"strictly after", "30 9 * * *", nil, time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC), time.Date(2025, 6, 3, 9, 30, 0, 0, time.UTC), nil},
+		{"leap day", "0 0 29 2 *", nil, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), nil},
+		{"last weekday", "0 18 LW * *", nil, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 30, 18, 0, 0, 0, time.UTC), nil},
+		{"seconds", "*/20 0 9 * * *", []Option{WithAutoFields()}, time.Date(2025, 6, 2, 9, 0, 25, 0, time.UTC), time.Date(2025, 6, 2, 9, 0, 40, 0, time.UTC), nil},
+		{"location", "0 9 * * *", []Option{WithLocation(tokyo)}, time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC), time.Date(2025, 6, 2, 9, 0, 0, 0, tokyo), nil},
+		{"never", "0 0 30 2 *", nil, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}, ErrNeverFires},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+
+			got, err := cron.Next(tt.after)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Next() error = %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_NextN(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * 1#1")
+	got, err := cron.NextN(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+
+	want := []time.Time{
+		time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("NextN() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("NextN()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCronTime_NeverFires(t *testing.T) {
+	tests := []struct {
+		expr       string
+		want       bool
+		wantReason string
+	}{
+		{"0 0 31 2 *", true, "day of month 31 does not exist in February"},
+		{"0 0 30 2 *", true, "day of month 30 does not exist in February"},
+		{"0 0 31 4 *", true, "day of month 31 does not exist in April"},
+		{"0 0 31 4,6,9,11 *", true, "day of month 31 does not exist in April, June, September, November"},
+		{"0 0 29 2 *", false, ""},
+		{"0 0 31 2 MON", false, ""},
+		{"0 0 * 2 MON#5", false, ""},
+		{"0 9 * * 1-5", false, ""},
+		{"@reboot", false, ""},
+		{"0 25 * * *", true, "invalid hour, field 2: value 25 out of range [0, 23]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			got, reason := c.NeverFires()
+			if got != tt.want || reason != tt.wantReason {
+				t.Errorf("NeverFires() = %v, %q, want %v, %q", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCronTime_NextDayFields(t *testing.T) {
+	// Friday the 13th falls in June 2025, February 2026 and March 2026,
+	// and in no month between
+	tests := []struct {
+		name  string
+		opts  []Option
+		after time.Time
+		want  []string
+	}{
+		{
+			name:  "either day field",
+			after: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2025-06-06", "2025-06-13", "2025-06-20", "2025-06-27", "2025-07-04", "2025-07-11", "2025-07-13"},
+		},
+		{
+			name:  "either day field without a Friday the 13th",
+			after: time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2025-10-03", "2025-10-10", "2025-10-13", "2025-10-17"},
+		},
+		{
+			name:  "both day fields",
+			opts:  []Option{WithDOMDOWIntersection()},
+			after: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2025-06-13", "2026-02-13", "2026-03-13", "2026-11-13"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith("0 9 13 * 5", tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			times, err := cron.NextN(tt.after, len(tt.want))
+			if err != nil {
+				t.Fatalf("NextN() error = %v", err)
+			}
+			for i, got := range times {
+				if got.Format(time.DateOnly) != tt.want[i] || got.Hour() != 9 {
+					t.Errorf("NextN()[%d] = %v, want 09:00 on %s", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+
+	// A single day field is unaffected by the option
+	cron, _ := ParseCronWith("0 9 13 * *", WithDOMDOWIntersection())
+	if got, _ := cron.Next(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)); got.Format(time.DateOnly) != "2025-07-13" {
+		t.Errorf("Next() = %v, want 2025-07-13", got)
+	}
+}
+
+// TestLeapDay pins a leap-day schedule to known leap and common years,
+// 2000 and 2100 included
+func TestLeapDay(t *testing.T) {
+	cron, _ := ParseCron("0 9 29 2 *")
+	leap := func(year int) time.Time { return time.Date(year, time.February, 29, 9, 0, 0, 0, time.UTC) }
+
+	nexts := []struct {
+		after, want time.Time
+	}{
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), leap(2028)},
+		{time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), leap(2028)},
+		{time.Date(2024, 2, 29, 8, 59, 0, 0, time.UTC), leap(2024)},
+		{time.Date(1999, 3, 1, 0, 0, 0, 0, time.UTC), leap(2000)},
+		{time.Date(2097, 1, 1, 0, 0, 0, 0, time.UTC), leap(2104)},
+		{time.Date(2399, 12, 31, 0, 0, 0, 0, time.UTC), leap(2400)},
+	}
+	for _, tt := range nexts {
+		if got, err := cron.Next(tt.after); err != nil || !got.Equal(tt.want) {
+			t.Errorf("Next(%v) = %v, %v, want %v", tt.after, got, err, tt.want)
+		}
+	}
+
+	years := map[int]int{2024: 1, 2025: 0, 2028: 1, 2000: 1, 2100: 0, 2400: 1}
+	for year, want := range years {
+		if got, err := cron.Cardinality(year, nil); err != nil || got != want {
+			t.Errorf("Cardinality(%d) = %d, %v, want %d", year, got, err, want)
+		}
+	}
+
+	got, err := cron.OccurrencesBetween(time.Date(2096, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2110, 1, 1, 0, 0, 0, 0, time.UTC))
+	if want := []time.Time{leap(2096), leap(2104), leap(2108)}; err != nil || !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("OccurrencesBetween(2096, 2110) = %v, %v, want %v", got, err, want)
+	}
+
+	if never, reason := cron.NeverFires(); never {
+		t.Errorf("NeverFires() = true, %q, want false", reason)
+	}
+	if w := cron.Lint(); len(w) != 1 || w[0].Kind != LeapDayOnly {
+		t.Errorf("Lint() = %v, want a LeapDayOnly warning", w)
+	}
+	if never, _ := mustParse(t, "0 9 30 2 *").NeverFires(); !never {
+		t.Error(`NeverFires("0 9 30 2 *") = false, want true`)
+	}
+}
@@ -0,0 +1,501 @@
+package cronmath
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize rewrites the expression into a canonical form without changing
+// when it fires: numbers lose leading zeros and plus signs, Sunday is
+// written as 0 (or 7 with WithSundayAsSeven), and names are written in the
+// case selected with WithNameCase. Lists are sorted by value with repeated
+// elements dropped, while the ranges and steps in them are kept as
+// written. Fields matching every value, such as "*/1" or "0-59", become
+// "*", and fields matching one value become that value. "@reboot" is left
+// as it is.
+func (c *CronTime) Normalize() error {
+	if c.IsReboot() {
+		return nil
+	}
+	if _, err := c.schedule(); err != nil {
+		return err
+	}
+
+	for _, field := range c.fieldPtrs() {
+		*field = canonicalNumbers(*field)
+	}
+	c.collapse()
+	if c.cfg.zeroPad {
+		c.Minute, c.Hour = zeroPad(c.Minute), zeroPad(c.Hour)
+	}
+	c.Month, c.DayOfWeek = c.styleNames(c.Month, c.DayOfWeek)
+	for i, field := range c.fieldPtrs() {
+		*field = sortList(*field, i, c.cfg.sundaySeven)
+	}
+	return nil
+}
+
+// collapse writes fields matching every value as "*" and fields matching
+// a single value as that value. A restricted day field only becomes "*"
+// when the other day field is unrestricted or matches every day too, as
+// under cron's day-of-month/day-of-week OR rule "0 9 1-31 * 1" fires
+// every day but "0 9 * * 1" only on Mondays. Likewise a day field such as
+// "*/31" keeps its "*" rather than becoming a restricted "1".
+func (c *CronTime) collapse() {
+	plain := func(field string) bool { return !strings.ContainsAny(field, ",-/*?") }
+
+	type setField struct {
+		field *string
+		spec  fieldSpec
+	}
+	clock := []setField{{&c.Minute, minuteField}, {&c.Hour, hourField}, {&c.Month, monthField}}
+	if c.layout.hasSeconds() {
+		clock = append(clock, setField{&c.Second, secondField})
+	}
+	for _, f := range clock {
+		s, err := parseSet(*f.field, f.spec)
+		switch {
+		case err != nil || plain(*f.field):
+		case s == f.spec.fullSet():
+			*f.field = "*"
+		case s.len() == 1:
+			*f.field = formatSet(s, f.spec)
+		}
+	}
+
+	dom, domErr := parseDayOfMonth(c.DayOfMonth)
+	dow, nth, dowErr := parseDayOfWeek(c.DayOfWeek)
+	if domErr != nil || dowErr != nil {
+		return
+	}
+	domAll := dom.days == dayOfMonthField.fullSet() && !dom.countsFromEnd()
+	dowAll := dow == dayOfWeekField.fullSet() && nth == 0
+	domFree, dowFree := !isRestricted(c.DayOfMonth), !isRestricted(c.DayOfWeek)
+
+	switch {
+	case plain(c.DayOfMonth) || c.DayOfMonth == "?":
+	case domAll && (domFree || dowFree || dowAll):
+		c.DayOfMonth = "*"
+	case !domFree && !dom.countsFromEnd() && dom.days.len() == 1:
+		c.DayOfMonth = formatSet(dom.days, dayOfMonthField)
+	}
+	switch {
+	case plain(c.DayOfWeek) || c.DayOfWeek == "?":
+	case dowAll && (dowFree || domFree || domAll):
+		c.DayOfWeek = "*"
+	case !dowFree && nth == 0 && dow.len() == 1:
+		c.DayOfWeek = formatSet(dow, dayOfWeekField)
+	}
+}
+
+// sortList orders the elements of a list field by the first value they
+// match and drops repeated elements, so "10,5,0,5" becomes "0,5,10".
+// Elements are kept as written otherwise.
+func sortList(field string, i int, seven bool) string {
+	parts := strings.Split(field, ",")
+	if len(parts) < 2 {
+		return field
+	}
+
+	keys := make(map[string]int, len(parts))
+	uniq := parts[:0]
+	for _, part := range parts {
+		if _, ok := keys[part]; ok {
+			continue
+		}
+		k, ok := listKey(part, i, seven)
+		if !ok {
+			return field
+		}
+		keys[part] = k
+		uniq = append(uniq, part)
+	}
+	sort.SliceStable(uniq, func(a, b int) bool { return keys[uniq[a]] < keys[uniq[b]] })
+	return strings.Join(uniq, ",")
+}
+
+// listKey returns the position of a list element of field i in the order
+// its values occur: days of the month before days counted from the end,
+// and Sunday last when written as 7
+func listKey(part string, i int, seven bool) (int, bool) {
+	switch i {
+	case dayOfMonthIndex:
+		m, err := parseDayOfMonth(part)
+		switch {
+		case err != nil:
+			return 0, false
+		case m.days != 0:
+			return m.days.values()[0], true
+		case m.fromEnd != 0:
+			offsets := m.fromEnd.values()
+			return 100 - offsets[len(offsets)-1], true
+		}
+		return 101, true
+	case dayOfWeekIndex:
+		days, nth, err := parseDayOfWeek(part)
+		switch {
+		case err != nil:
+			return 0, false
+		case days == 1 && seven:
+			return 7, true
+		case days != 0:
+			return days.values()[0], true
+		}
+		return 8 + nth.values()[0], true
+	}
+
+	s, err := parseSetPart(part, standardFields[i])
+	if err != nil || s == 0 {
+		return 0, false
+	}
+	return s.values()[0], true
+}
+
+// zeroPad writes the single-digit values of a field with a leading zero,
+// leaving steps alone, so "5,7-9/2" becomes "05,07-09/2"
+func zeroPad(field string) string {
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		base, step, hasStep := strings.Cut(part, "/")
+		bounds := strings.Split(base, "-")
+		for j, v := range bounds {
+			if len(v) == 1 && isDigit(v[0]) {
+				bounds[j] = "0" + v
+			}
+		}
+		parts[i] = strings.Join(bounds, "-")
+		if hasStep {
+			parts[i] += "/" + step
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// appendNames appends a field to dst with every month or weekday name in
+// the given case. Words that are not names of the field are left alone.
+func appendNames(dst []byte, field string, f fieldSpec, nc NameCase) []byte {
+	for i := 0; i < len(field); {
+		j := i
+		for j < len(field) && isLetter(field[j]) {
+			j++
+		}
+		if j == i {
+			dst = append(dst, field[i])
+			i++
+			continue
+		}
+
+		word := field[i:j]
+		if f.nameIndex(word) >= 0 {
+			dst = nc.appendName(dst, word)
+		} else {
+			dst = append(dst, word...)
+		}
+		i = j
+	}
+	return dst
+}
+
+// styleNames writes the month and day-of-week fields with names or
+// numbers as configured, Sunday as configured and names in the configured
+// case
+func (c *CronTime) styleNames(month, dow string) (string, string) {
+	month, dow = c.convertValueNames(month, dow)
+	return string(appendNames(nil, month, monthField, c.cfg.nameCase)),
+		string(appendDayOfWeek(nil, dow, c.cfg.sundaySeven, c.cfg.nameCase))
+}
+
+// convertValueNames writes the month and day-of-week fields with names or
+// numbers as configured
+func (c *CronTime) convertValueNames(month, dow string) (string, string) {
+	style := c.cfg.valueNames
+	if style == namedValues && c.cfg.dialect != nil && !c.cfg.dialect.Names {
+		style = keepValueNames
+	}
+	if style != keepValueNames {
+		month = convertNames(month, monthField, style == namedValues)
+		dow = convertNames(dow, dayOfWeekField, style == namedValues)
+	}
+	return month, dow
+}
+
+// convertNames writes the values of a month or day-of-week field as names
+// when named is set and as numbers otherwise, leaving steps and the n of
+// "dow#n" alone. Sunday ending a range is written as 7, and "0-7" as
+// "SUN-SAT", so the range keeps its meaning.
+func convertNames(field string, f fieldSpec, named bool) string {
+	convert := func(s string, end bool, lo int) string {
+		v, err := f.parseValue(s)
+		switch {
+		case err != nil:
+			return s
+		case f.sundaySeven && v == f.max+1 && named && lo == f.min:
+			v = f.max
+		case f.sundaySeven && end && v == f.min && lo > f.min:
+			v = f.max + 1
+		}
+		if !named {
+			return strconv.Itoa(v)
+		}
+		if v == f.max+1 {
+			v = f.min
+		}
+		return f.names[v-f.min]
+	}
+
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		base, step, hasStep := strings.Cut(part, "/")
+		value, nth, hasNth := strings.Cut(base, "#")
+		loStr, hiStr, isRange := strings.Cut(value, "-")
+
+		lo, err := f.parseValue(loStr)
+		if err != nil {
+			continue
+		}
+		out := convert(loStr, false, lo)
+		if isRange {
+			out += "-" + convert(hiStr, true, lo)
+		}
+		if hasNth {
+			out += "#" + nth
+		}
+		if hasStep {
+			out += "/" + step
+		}
+		parts[i] = out
+	}
+	return strings.Join(parts, ",")
+}
+
+// apply writes a name in the case nc selects
+func (nc NameCase) apply(name string) string {
+	switch nc {
+	case LowerCase:
+		return strings.ToLower(name)
+	case TitleCase:
+		return strings.ToUpper(name[:1]) + strings.ToLower(name[1:])
+	default:
+		return strings.ToUpper(name)
+	}
+}
+
+// appendName appends a name to dst in the case nc selects
+func (nc NameCase) appendName(dst []byte, name string) []byte {
+	for i := 0; i < len(name); i++ {
+		lower := nc == LowerCase || nc == TitleCase && i > 0
+		dst = append(dst, toCase(name[i], lower))
+	}
+	return dst
+}
+
+// toCase returns an ASCII letter in lower or upper case
+func toCase(b byte, lower bool) byte {
+	switch {
+	case lower && 'A' <= b && b <= 'Z':
+		return b + 'a' - 'A'
+	case !lower && 'a' <= b && b <= 'z':
+		return b - ('a' - 'A')
+	}
+	return b
+}
+
+func isLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// canonicalNumbers strips plus signs and leading zeros from every number
+// in a field
+func canonicalNumbers(field string) string {
+	var b strings.Builder
+	for i := 0; i < len(field); {
+		if field[i] == '+' {
+			i++
+			continue
+		}
+		if !isDigit(field[i]) {
+			b.WriteByte(field[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(field) && isDigit(field[j]) {
+			j++
+		}
+		digits := strings.TrimLeft(field[i:j], "0")
+		if digits == "" {
+			digits = "0"
+		}
+		b.WriteString(digits)
+		i = j
+	}
+	return b.String()
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// appendDayOfWeek appends a day-of-week field to dst with its numeric
+// Sunday values written as 0, or as 7 when seven is set, and names in the
+// case nc selects. Ranges running into or out of Sunday are split so that
+// they stay valid: "5-7" becomes "0,5-6" and, with seven, "0-2" becomes
+// "1-2,7". Steps are left as written.
+func appendDayOfWeek(dst []byte, field string, seven bool, nc NameCase) []byte {
+	for i := 0; ; i++ {
+		part, rest, more := strings.Cut(field, ",")
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendSundayPart(dst, part, seven, nc)
+		if !more {
+			return dst
+		}
+		field = rest
+	}
+}
+
+func appendSundayPart(dst []byte, part string, seven bool, nc NameCase) []byte {
+	sunday := byte('0')
+	if seven {
+		sunday = '7'
+	}
+
+	if strings.Contains(part, "/") {
+		return appendNames(dst, part, dayOfWeekField, nc)
+	}
+	loStr, hiStr, isRange := strings.Cut(part, "-")
+	lo, ok := atoi(loStr)
+	if !ok {
+		return appendNames(dst, part, dayOfWeekField, nc)
+	}
+
+	if !isRange {
+		if lo == 0 || lo == 7 {
+			return append(dst, sunday)
+		}
+		return append(dst, part...)
+	}
+
+	hi, ok := atoi(hiStr)
+	if !ok || lo > hi || lo == 0 && hi == 7 {
+		return appendNames(dst, part, dayOfWeekField, nc)
+	}
+	switch {
+	case !seven && hi == 7:
+		if lo == 7 {
+			return append(dst, sunday)
+		}
+		return appendRange(append(dst, sunday, ','), lo, 6)
+	case seven && lo == 0:
+		if hi == 0 {
+			return append(dst, sunday)
+		}
+		return append(appendRange(dst, 1, hi), ',', sunday)
+	}
+	return append(dst, part...)
+}
+
+// appendRange appends lo-hi to dst, or a single value when they are equal
+func appendRange(dst []byte, lo, hi int) []byte {
+	dst = strconv.AppendInt(dst, int64(lo), 10)
+	if lo == hi {
+		return dst
+	}
+	return strconv.AppendInt(append(dst, '-'), int64(hi), 10)
+}
+
+// atoi parses a number as strconv.Atoi does for the values of a field,
+// without allocating an error when s is not one. Values too large for any
+// field saturate.
+func atoi(s string) (int, bool) {
+	s = strings.TrimPrefix(s, "+")
+	if s == "" {
+		return 0, false
+	}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return 0, false
+		}
+		n = min(n*10+int(s[i]-'0'), math.MaxInt32)
+	}
+	return n, true
+}
+
+// Compress rewrites every field into its shortest equivalent syntax,
+// turning explicit lists back into ranges and steps: "0,15,30,45" becomes
+// "*/15" and "9,10,11,12,13,14,15,16,17" becomes "9-17". The fields still
+// match exactly the same values. "@reboot" is left as it is.
+func (c *CronTime) Compress() error {
+	if c.IsReboot() {
+		return nil
+	}
+	var out [5]string
+	for i, field := range c.fieldPtrs() {
+		switch i {
+		case dayOfMonthIndex:
+			m, err := parseDayOfMonth(*field)
+			if err != nil {
+				return c.fieldError(dayOfMonthIndex, err)
+			}
+			out[i] = formatSet(m.days, dayOfMonthField)
+			if m.countsFromEnd() {
+				out[i] = m.format()
+			}
+			continue
+		case dayOfWeekIndex:
+			days, nth, err := parseDayOfWeek(*field)
+			if err != nil {
+				return c.fieldError(dayOfWeekIndex, err)
+			}
+			out[i] = formatSet(days, dayOfWeekField)
+			if nth != 0 {
+				parts := formatNth(nth)
+				if days != 0 {
+					parts = append([]string{formatRestricted(days, dayOfWeekField)}, parts...)
+				}
+				out[i] = strings.Join(parts, ",")
+			}
+			continue
+		}
+
+		s, err := parseSet(*field, standardFields[i])
+		if err != nil {
+			return c.fieldError(i, err)
+		}
+		out[i] = formatSet(s, standardFields[i])
+	}
+
+	// Whether a day field starts with "*" decides between AND and OR
+	// semantics when the other day field is restricted, so keep it as is.
+	// The exception is a union that covers every day anyway.
+	if isRestricted(c.DayOfMonth) && isRestricted(c.DayOfWeek) && (out[dayOfMonthIndex] == "*" || out[dayOfWeekIndex] == "*") {
+		out[dayOfMonthIndex], out[dayOfWeekIndex] = "*", "*"
+	} else {
+		out[dayOfMonthIndex] = keepDayRestriction(c.DayOfMonth, out[dayOfMonthIndex], c.DayOfWeek, dayOfMonthField)
+		out[dayOfWeekIndex] = keepDayRestriction(c.DayOfWeek, out[dayOfWeekIndex], c.DayOfMonth, dayOfWeekField)
+	}
+
+	for i, field := range c.fieldPtrs() {
+		*field = out[i]
+	}
+	return nil
+}
+
+// keepDayRestriction returns the compressed rendering of a day field,
+// falling back to a rendering that keeps the original's restriction
+// whenever a change would alter how the two day fields combine
+func keepDayRestriction(orig, compressed, other string, f fieldSpec) string {
+	if !isRestricted(other) || isRestricted(orig) == isRestricted(compressed) {
+		return compressed
+	}
+	if isRestricted(orig) {
+		s, _ := parseSet(orig, f)
+		return formatRestricted(s, f)
+	}
+	return orig
+}
@@ -0,0 +1,486 @@
+package cronmath
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronTime_Compress(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		want    string
+	}{
+		{"step", "0,15,30,45 * * * *", "*/15 * * * *"},
+		{"range", "0 9,10,11,12,13,14,15,16,17 * * *", "0 9-17 * * *"},
+		{"offset step", "5,20,35,50 * * * *", "5-50/15 * * * *"},
+		{"full range", "0-59 0-23 1-31 1-12 0-6", "* * * * *"},
+		{"unsorted with duplicates", "30,0,30 9 * * 5,1,3", "0,30 9 * * 1,3,5"},
+		{"already compact", "5 9 * * 1-5", "5 9 * * 1-5"},
+		{"leading zeros", "05 09 * * *", "5 9 * * *"},
+		{"union covering every day", "0 9 1-31 * 0-6", "0 9 * * *"},
+		{"union with every weekday", "0 9 1-15 * 0-6", "0 9 * * *"},
+		{"union weekdays stay restricted", "0 9 1-15 * 1-5", "0 9 1-15 * 1-5"},
+		{"union day list stays restricted", "0 9 1,3,5,7,9,11,13,15,17,19,21,23,25,27,29,31 * 1", "0 9 1-31/2 * 1"},
+		{"starred day keeps AND semantics", "0 9 */30 * 1", "0 9 */30 * 1"},
+		{"starred day with unrestricted weekday", "0 9 */30 * *", "0 9 1,31 * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			if err := cron.Compress(); err != nil {
+				t.Fatalf("Compress() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("Compress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_CompressInvalid(t *testing.T) {
+	cron, _ := ParseCron("0,15,61 * * * *")
+	if err := cron.Compress(); err == nil {
+		t.Error("Compress() expected error for out-of-range minute, got nil")
+	}
+	if cron.Minute != "0,15,61" {
+		t.Errorf("Compress() modified the expression on error: %q", cron.String())
+	}
+}
+
+func TestCronMath_Compress(t *testing.T) {
+	got := New("0 9 * * 1,2,3,4,5").Add(Minutes(15)).Compress()
+	if err := got.Error(); err != nil {
+		t.Fatalf("Compress() error = %v", err)
+	}
+	if want := "15 9 * * 1-5"; got.String() != want {
+		t.Errorf("Compress() = %q, want %q", got.String(), want)
+	}
+}
+
+// randomList renders a random non-empty subset of the field as an
+// explicit, unsorted list
+func randomList(r *rand.Rand, f fieldSpec) string {
+	var vals []string
+	for v := f.min; v <= f.max; v++ {
+		if r.Intn(3) == 0 {
+			vals = append(vals, strconv.Itoa(v))
+		}
+	}
+	if len(vals) == 0 {
+		vals = append(vals, strconv.Itoa(f.min+r.Intn(f.max-f.min+1)))
+	}
+	r.Shuffle(len(vals), func(i, j int) { vals[i], vals[j] = vals[j], vals[i] })
+	return strings.Join(vals, ",")
+}
+
+// randomProgression renders a random arithmetic progression of the field
+// as an explicit list
+func randomProgression(r *rand.Rand, f fieldSpec) string {
+	step := 1 + r.Intn(f.max-f.min)
+	var vals []string
+	for v := f.min + r.Intn(step); v <= f.max; v += step {
+		vals = append(vals, strconv.Itoa(v))
+	}
+	return strings.Join(vals, ",")
+}
+
+func TestCronTime_CompressPreservesSchedule(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	field := func(f fieldSpec) string {
+		switch r.Intn(4) {
+		case 0:
+			return "*"
+		case 1:
+			return randomProgression(r, f)
+		default:
+			return randomList(r, f)
+		}
+	}
+
+	for i := 0; i < 500; i++ {
+		orig := &CronTime{
+			Minute:     field(minuteField),
+			Hour:       field(hourField),
+			DayOfMonth: field(dayOfMonthField),
+			Month:      field(monthField),
+			DayOfWeek:  field(dayOfWeekField),
+		}
+		compressed := *orig
+		if err := compressed.Compress(); err != nil {
+			t.Fatalf("Compress(%q) error = %v", orig.String(), err)
+		}
+
+		wantDay, _ := orig.ExpandDay()
+		gotDay, err := compressed.ExpandDay()
+		if err != nil {
+			t.Fatalf("Compress(%q) = %q does not expand: %v", orig.String(), compressed.String(), err)
+		}
+		if !slices.Equal(gotDay, wantDay) {
+			t.Fatalf("Compress(%q) = %q changed the daily pattern", orig.String(), compressed.String())
+		}
+
+		want, _ := orig.schedule()
+		got, _ := compressed.schedule()
+		d := dateOf(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+		for day := 0; day < 366; day++ {
+			if got.matchesDate(d) != want.matchesDate(d) {
+				t.Fatalf("Compress(%q) = %q changed whether it fires on %d-%02d-%02d",
+					orig.String(), compressed.String(), d.year, d.month, d.day)
+			}
+			d = d.next()
+		}
+	}
+}
+
+func TestCronTime_NormalizePreservesSchedule(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	field := func(f fieldSpec) string {
+		lo := f.min + r.Intn(f.max-f.min+1)
+		hi := lo + r.Intn(f.max-lo+1)
+		switch r.Intn(6) {
+		case 0:
+			return "*"
+		case 1:
+			return "*/1"
+		case 2:
+			return fmt.Sprintf("%d-%d", f.min, f.max)
+		case 3:
+			return fmt.Sprintf("%d-%d", lo, lo)
+		case 4:
+			return fmt.Sprintf("%d-%d,%d-%d", f.min, hi, lo, f.max)
+		}
+		return randomList(r, f)
+	}
+
+	for i := 0; i < 1000; i++ {
+		orig := &CronTime{
+			Minute:     field(minuteField),
+			Hour:       field(hourField),
+			DayOfMonth: field(dayOfMonthField),
+			Month:      field(monthField),
+			DayOfWeek:  field(dayOfWeekField),
+		}
+		normalized := *orig
+		if err := normalized.Normalize(); err != nil {
+			t.Fatalf("Normalize(%q) error = %v", orig.String(), err)
+		}
+
+		want, _ := orig.schedule()
+		got, err := normalized.schedule()
+		if err != nil {
+			t.Fatalf("Normalize(%q) = %q does not expand: %v", orig.String(), normalized.String(), err)
+		}
+		if got.minute != want.minute || got.hour != want.hour || got.month != want.month {
+			t.Fatalf("Normalize(%q) = %q changed the time fields", orig.String(), normalized.String())
+		}
+		d := dateOf(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+		for day := 0; day < 366; day++ {
+			if got.matchesDate(d) != want.matchesDate(d) {
+				t.Fatalf("Normalize(%q) = %q changed whether it fires on %d-%02d-%02d",
+					orig.String(), normalized.String(), d.year, d.month, d.day)
+			}
+			d = d.next()
+		}
+	}
+}
+
+func TestParseCron_SundaySeven(t *testing.T) {
+	sunday := time.Date(2025, time.March, 2, 9, 0, 0, 0, time.UTC)
+	for _, expr := range []string{"0 9 * * 0", "0 9 * * 7", "0 9 * * SUN", "0 9 * * 5-7", "0 9 * * 0-2"} {
+		cron, err := ParseCron(expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error = %v", expr, err)
+		}
+		s, err := cron.schedule()
+		if err != nil {
+			t.Fatalf("schedule(%q) error = %v", expr, err)
+		}
+		if !s.matchesDate(dateOf(sunday)) {
+			t.Errorf("%q does not fire on Sunday", expr)
+		}
+	}
+}
+
+func TestCronTime_SundayOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		seven   bool
+		want    string
+	}{
+		{"seven written as zero", "0 9 * * 7", false, "0 9 * * 0"},
+		{"zero kept", "0 9 * * 0", false, "0 9 * * 0"},
+		{"zero written as seven", "0 9 * * 0", true, "0 9 * * 7"},
+		{"range ending on seven", "0 9 * * 5-7", false, "0 9 * * 0,5-6"},
+		{"range ending on seven kept", "0 9 * * 5-7", true, "0 9 * * 5-7"},
+		{"range starting at zero", "0 9 * * 0-2", true, "0 9 * * 1-2,7"},
+		{"range of only sunday", "0 9 * * 7-7", false, "0 9 * * 0"},
+		{"full range", "0 9 * * 0-7", false, "0 9 * * 0-7"},
+		{"names survive", "0 9 * * FRI-SUN", false, "0 9 * * FRI-SUN"},
+		{"lists", "0 9 * * 1,7", false, "0 9 * * 1,0"},
+		{"steps are left alone", "0 9 * * 0-7/7", false, "0 9 * * 0-7/7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.seven {
+				opts = append(opts, WithSundayAsSeven())
+			}
+			cron, err := ParseCronWith(tt.cronStr, opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+
+			// The rewritten form must still parse to the same days
+			want, _ := parseSet(tt.cronStr[len("0 9 * * "):], dayOfWeekField)
+			got, err := parseSet(cron.String()[len("0 9 * * "):], dayOfWeekField)
+			if err != nil || got != want {
+				t.Errorf("String() = %q changed the days (%v, %v)", cron.String(), got.values(), err)
+			}
+		})
+	}
+}
+
+func TestCronTime_Normalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		want    string
+	}{
+		{"leading zeros", "05 09 01 02 *", nil, "5 9 1 2 *"},
+		{"plus signs", "+5 9 * * *", nil, "5 9 * * *"},
+		{"zero stays zero", "00 0 * * 00", nil, "0 0 * * 0"},
+		{"sunday as zero", "0 9 * * 07", nil, "0 9 * * 0"},
+		{"sunday as seven", "0 9 * * 0,3", []Option{WithSundayAsSeven()}, "0 9 * * 3,7"},
+		{"lists sorted", "10,5,0 12,9 * * 5,1", nil, "0,5,10 9,12 * * 1,5"},
+		{"duplicates dropped", "5,0,5,05 9 * * *", nil, "0,5 9 * * *"},
+		{"ranges sorted by start", "30-40,0-10/5 9 * * *", nil, "0-10/5,30-40 9 * * *"},
+		{"days from the end last", "0 9 LW,L,L-3,15 * *", nil, "0 9 15,L-3,L,LW * *"},
+		{"nth weekdays after days", "0 9 * * 1#2,5", nil, "0 9 * * 5,1#2"},
+		{"structure kept", "00-30/05 9 * JAN MON-FRI", nil, "0-30/5 9 * JAN MON-FRI"},
+		{"step of one", "*/1 * * * *", nil, "* * * * *"},
+		{"full ranges", "0-59 0-23 1-31 1-12 0-7", nil, "* * * * *"},
+		{"full named ranges", "0 9 * JAN-DEC SUN-SAT", nil, "0 9 * * *"},
+		{"full list", "0-29,30-59 9 * * *", nil, "* 9 * * *"},
+		{"single value range", "5-5 9-9/3 * * MON-MON", nil, "5 9 * * 1"},
+		{"repeated single value", "5,5 9 * * *", nil, "5 9 * * *"},
+		{"full day of month with weekday kept", "0 9 1-31 * 1", nil, "0 9 1-31 * 1"},
+		{"full day of month with starred weekday", "0 9 1-31 * */2", nil, "0 9 * * */2"},
+		{"both day fields full", "0 9 1-31 * 0-6", nil, "0 9 * * *"},
+		{"starred single day kept", "0 9 */31 * 1", nil, "0 9 */31 * 1"},
+		{"question mark kept", "0 9 ? * 1-5", nil, "0 9 ? * 1-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if err := cron.Normalize(); err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+			if got := cron.fieldString(); got != tt.want {
+				t.Errorf("Normalize() fields = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	cron, _ := ParseCronWith("0-59 0 9 * * *", WithAutoFields())
+	if err := cron.Normalize(); err != nil || cron.String() != "* 0 9 * * *" {
+		t.Errorf("Normalize() = %q, error %v, want %q", cron.String(), err, "* 0 9 * * *")
+	}
+
+	cron, _ = ParseCron("0 9 * * 8")
+	if err := cron.Normalize(); err == nil {
+		t.Error("Normalize() expected error for out-of-range day of week, got nil")
+	}
+}
+
+func TestCronTime_ShiftDays(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		days    int
+		opts    []Option
+		want    string
+		wantErr bool
+	}{
+		{"forward", "0 9 * * FRI", 1, nil, "0 9 * * 6", false},
+		{"into sunday", "0 9 * * 6", 1, nil, "0 9 * * 0", false},
+		{"into sunday as seven", "0 9 * * 6", 1, []Option{WithSundayAsSeven()}, "0 9 * * 7", false},
+		{"out of sunday seven", "0 9 * * 7", 1, nil, "0 9 * * 1", false},
+		{"zero and seven are one slot", "0 9 * * 0,7", 1, nil, "0 9 * * 1", false},
+		{"backward across sunday", "0 9 * * 1-5", -1, nil, "0 9 * * 0-4", false},
+		{"range through sunday", "0 9 * * 5-7", 1, []Option{WithSundayAsSeven()}, "0 9 * * 1,6,7", false},
+		{"whole weeks", "0 9 * * 1-5", 14, nil, "0 9 * * 1-5", false},
+		{"every day", "0 9 * * *", 3, nil, "0 9 * * *", false},
+		{"day of month", "0 9 1 * *", 1, nil, "0 9 2 * *", false},
+		{"day of month into previous month", "0 9 1,2 * *", -2, nil, "0 9 L-1,L * *", false},
+		{"both day fields", "0 9 1 * MON", 1, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			err = cron.ShiftDays(tt.days)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ShiftDays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("ShiftDays() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_ShiftDaysNamedRangeToSunday(t *testing.T) {
+	cron, err := ParseCron("0 9 * * FRI-SUN")
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	if err := cron.ShiftDays(-1); err != nil {
+		t.Fatalf("ShiftDays() error = %v", err)
+	}
+	if want := "0 9 * * 4-6"; cron.String() != want {
+		t.Errorf("ShiftDays() = %q, want %q", cron.String(), want)
+	}
+}
+
+func TestCronTime_NameCaseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		want    string
+	}{
+		{"upper by default", "0 9 * jan Mon-fri,sat", nil, "0 9 * JAN MON-FRI,SAT"},
+		{"lower", "0 9 * JAN MON-FRI", []Option{WithNameCase(LowerCase)}, "0 9 * jan mon-fri"},
+		{"title", "0 9 * jAN mON-fRI", []Option{WithNameCase(TitleCase)}, "0 9 * Jan Mon-Fri"},
+		{"numbers untouched", "0 9 * 1 1-5", []Option{WithNameCase(LowerCase)}, "0 9 * 1 1-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if err := cron.Normalize(); err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if got := cron.fieldString(); got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_NamedOutput(t *testing.T) {
+	named, numeric := []Option{WithNamedOutput()}, []Option{WithNumericOutput()}
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		want    string
+	}{
+		{"as written by default", "0 9 * 1,FEB MON-5", nil, "0 9 * 1,FEB MON-5"},
+		{"numbers to names", "0 9 * 1,6-8 1-5", named, "0 9 * JAN,JUN-AUG MON-FRI"},
+		{"names to numbers", "0 9 * JAN,JUN-AUG MON-FRI", numeric, "0 9 * 1,6-8 1-5"},
+		{"steps stay numbers", "0 9 * 1-12/3 1-5/2", named, "0 9 * JAN-DEC/3 MON-FRI/2"},
+		{"wildcard steps untouched", "0 9 * */3 */2", named, "0 9 * */3 */2"},
+		{"nth weekday", "0 9 * * 1#2,5#3", named, "0 9 * * MON#2,FRI#3"},
+		{"nth weekday to numbers", "0 9 * * MON#2", numeric, "0 9 * * 1#2"},
+		{"sunday seven", "0 9 * * 7,5-7", named, "0 9 * * SUN,FRI-SUN"},
+		{"whole week", "0 9 * * 0-7/2", named, "0 9 * * SUN-SAT/2"},
+		{"range to sunday as numbers", "0 9 * * FRI-SUN", append(numeric, WithSundayAsSeven()), "0 9 * * 5-7"},
+		{"with name case", "0 9 * 12 0", append(named, WithNameCase(TitleCase)), "0 9 * Dec Sun"},
+		{"dialect without names", "0 9 * 1 1-5", append(named, WithDialect(POSIX)), "0 9 * 1 1-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+
+			orig := cron.String()
+			if err := cron.Normalize(); err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if got := cron.String(); got != orig {
+				t.Errorf("String() after Normalize() = %q, want %q", got, orig)
+			}
+			before, _ := ParseCron(tt.cronStr)
+			want, _ := before.schedule()
+			if got, err := cron.schedule(); err != nil || *got != *want {
+				t.Errorf("Normalize() = %q changed the schedule", cron.fieldString())
+			}
+		})
+	}
+}
+
+func TestCronTime_ZeroPadOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		opts    []Option
+		want    string
+	}{
+		{"unpadded by default", "05 09 * * *", nil, "5 9 * * *"},
+		{"single values", "5 9 * * *", []Option{WithZeroPad()}, "05 09 * * *"},
+		{"lists and ranges", "0,30 8-17 * * 1-5", []Option{WithZeroPad()}, "00,30 08-17 * * 1-5"},
+		{"steps untouched", "*/5 1-9/2 * * *", []Option{WithZeroPad()}, "*/5 01-09/2 * * *"},
+		{"already padded", "05 09 1 * *", []Option{WithZeroPad()}, "05 09 1 * *"},
+		{"wildcards", "* * * * *", []Option{WithZeroPad()}, "* * * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			if err := cron.Normalize(); err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_ZeroPadAfterAdd(t *testing.T) {
+	if got, want := New("55 8 * * *", WithZeroPad()).Add(Minutes(10)).String(), "05 09 * * *"; got != want {
+		t.Errorf("Add() = %q, want %q", got, want)
+	}
+}
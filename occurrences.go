@@ -0,0 +1,182 @@
+package cronmath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// Occurrences returns the times strictly after after at which the
+// expression fires, in order, as computed by Next. The sequence is
+// computed lazily and never ends for most expressions, so callers must
+// bound their loops:
+//
+//	for t := range c.Occurrences(start) {
+//		if t.After(end) {
+//			break
+//		}
+//		...
+//	}
+//
+// Starting again from any yielded time continues the sequence after it.
+// The sequence is empty when the expression is invalid or never fires;
+// use Next to tell why.
+func (c *CronTime) Occurrences(after time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t, err := range c.OccurrencesContext(context.Background(), after) {
+			if err != nil || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// OccurrencesContext is like Occurrences, but stops when ctx is done,
+// even in the middle of a long scan for the next firing. An invalid
+// expression, one that never fires or the end of ctx, as a *ScanError, is
+// yielded once as an error, after which the sequence ends.
+func (c *CronTime) OccurrencesContext(ctx context.Context, after time.Time) iter.Seq2[time.Time, error] {
+	return func(yield func(time.Time, error) bool) {
+		s, err := c.schedule()
+		if err != nil {
+			yield(time.Time{}, err)
+			return
+		}
+
+		t := c.in(after).Truncate(time.Second).Add(time.Second)
+		for {
+			next, ok, err := s.nextContext(ctx, t, gregorianCycleDays)
+			if err != nil {
+				yield(time.Time{}, err)
+				return
+			}
+			if !ok {
+				yield(time.Time{}, neverFires(c))
+				return
+			}
+			if !yield(next, nil) {
+				return
+			}
+			t = next.Add(time.Second)
+		}
+	}
+}
+
+// OccurrencesBetween returns the times from start up to but excluding end
+// at which the expression fires, in order. See OccurrencesBetweenContext.
+func (c *CronTime) OccurrencesBetween(start, end time.Time) ([]time.Time, error) {
+	return c.OccurrencesBetweenContext(context.Background(), start, end)
+}
+
+// OccurrencesBetweenContext is like OccurrencesBetween, but gives up when
+// ctx is done, returning a *ScanError with ctx's error and the date the
+// scan had reached. The scan stops at end, so an expression firing
+// nowhere between start and end gives no times rather than an error.
+func (c *CronTime) OccurrencesBetweenContext(ctx context.Context, start, end time.Time) ([]time.Time, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+	t, end := c.in(start), c.in(end)
+	for t.Before(end) {
+		// Days shortened by daylight saving can put end a date further
+		days := int(end.Sub(t)/(24*time.Hour)) + 2
+		next, ok, err := s.nextContext(ctx, t, days)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || !next.Before(end) {
+			break
+		}
+		times = append(times, next)
+		t = next.Add(time.Second)
+	}
+	return times, nil
+}
+
+// ErrFewOccurrences is matched by the *OccurrenceCountError returned when
+// a month has fewer firings than asked for
+var ErrFewOccurrences = errors.New("too few occurrences in month")
+
+// OccurrenceCountError reports a month with only Count of the K firings
+// KthOccurrenceInMonth was asked for. K is negative when counted from the
+// end of the month.
+type OccurrenceCountError struct {
+	Year  int
+	Month time.Month
+	K     int
+	Count int
+}
+
+func (e *OccurrenceCountError) Error() string {
+	return fmt.Sprintf("%v: occurrence %d asked for, %s %d has %d", ErrFewOccurrences, e.K, e.Month, e.Year, e.Count)
+}
+
+// Is reports whether target is ErrFewOccurrences
+func (e *OccurrenceCountError) Is(target error) bool {
+	return target == ErrFewOccurrences
+}
+
+// KthOccurrenceInMonth returns the kth time the expression fires in the
+// given month, counting from 1, so the 3rd run of "0 9 * * 1-5" in March
+// 2025 is on the 5th. A negative k counts back from the end of the month,
+// -1 being the last run. The month is taken in loc, or in the location
+// given by WithLocation or else UTC when loc is nil. A month with fewer
+// than |k| firings gets an *OccurrenceCountError.
+func (c *CronTime) KthOccurrenceInMonth(year int, month time.Month, k int, loc *time.Location) (time.Time, error) {
+	if k == 0 {
+		return time.Time{}, fmt.Errorf("invalid occurrence 0: want 1 or more, or -1 or less")
+	}
+	if month < time.January || month > time.December {
+		return time.Time{}, fmt.Errorf("invalid month %d", month)
+	}
+	s, err := c.schedule()
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc = c.locationOr(loc)
+
+	start := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 1, 0)
+	// The last |k| firings are kept for counting from the end
+	var last []time.Time
+	count := 0
+	for t := start; ; {
+		next, ok := s.next(t)
+		if !ok || !next.Before(end) {
+			break
+		}
+		count++
+		if count == k {
+			return next, nil
+		}
+		if k < 0 {
+			if len(last) == -k {
+				last = last[1:]
+			}
+			last = append(last, next)
+		}
+		t = next.Add(time.Second)
+	}
+
+	if k < 0 && count >= -k {
+		return last[0], nil
+	}
+	return time.Time{}, &OccurrenceCountError{Year: year, Month: month, K: k, Count: count}
+}
+
+// locationOr returns loc, or when it is nil the location given by
+// WithLocation or else UTC
+func (c *CronTime) locationOr(loc *time.Location) *time.Location {
+	switch {
+	case loc != nil:
+		return loc
+	case c.cfg.location != nil:
+		return c.cfg.location
+	}
+	return time.UTC
+}
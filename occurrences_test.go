@@ -0,0 +1,217 @@
+package cronmath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCronTime_Occurrences(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * 1-5")
+	start := time.Date(2025, 6, 6, 12, 0, 0, 0, time.UTC) // a Friday
+
+	var got []time.Time
+	for occ := range cron.Occurrences(start) {
+		if got = append(got, occ); len(got) == 3 {
+			break
+		}
+	}
+	want, _ := cron.NextN(start, 3)
+	if len(got) != 3 {
+		t.Fatalf("Occurrences() yielded %d times, want 3", len(got))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("Occurrences()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCronTime_OccurrencesResume(t *testing.T) {
+	cron, _ := ParseCron("*/20 * * * *")
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var last time.Time
+	for occ := range cron.Occurrences(start) {
+		last = occ
+		break
+	}
+	for occ := range cron.Occurrences(last) {
+		if want := time.Date(2025, 1, 1, 0, 40, 0, 0, time.UTC); !occ.Equal(want) {
+			t.Errorf("resumed Occurrences() = %v, want %v", occ, want)
+		}
+		break
+	}
+}
+
+func TestCronTime_OccurrencesEmpty(t *testing.T) {
+	for _, cronStr := range []string{"0 0 30 2 *", "0 9 * * 9"} {
+		cron, _ := ParseCron(cronStr)
+		for occ := range cron.Occurrences(time.Now()) {
+			t.Errorf("Occurrences(%q) yielded %v, want none", cronStr, occ)
+		}
+	}
+}
+
+func TestCronTime_OccurrencesContext(t *testing.T) {
+	cron, _ := ParseCron("* * * * *")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	n := 0
+	var gotErr error
+	for _, err := range cron.OccurrencesContext(ctx, start) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		if n++; n == 5 {
+			cancel()
+		}
+	}
+	if n != 5 || !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("OccurrencesContext() yielded %d times then %v, want 5 then context.Canceled", n, gotErr)
+	}
+
+	never, _ := ParseCron("0 0 31 4 *")
+	for _, err := range never.OccurrencesContext(context.Background(), start) {
+		if !errors.Is(err, ErrNeverFires) {
+			t.Errorf("OccurrencesContext() error = %v, want ErrNeverFires", err)
+		}
+	}
+}
+
+func TestCronTime_KthOccurrenceInMonth(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		expr      string
+		k         int
+		loc       *time.Location
+		want      time.Time
+		wantCount int
+	}{
+		{"0 9 * * 1-5", 1, nil, time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC), 0},
+		{"0 9 * * 1-5", 3, nil, time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC), 0},
+		{"0 9 * * 1-5", 21, nil, time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC), 0},
+		{"0 9 * * 1-5", -1, nil, time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC), 0},
+		{"0 9 * * 1-5", -2, nil, time.Date(2025, 3, 28, 9, 0, 0, 0, time.UTC), 0},
+		{"0 9 * * 1-5", -21, nil, time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC), 0},
+		{"0 9 * * 1-5", 22, nil, time.Time{}, 21},
+		{"0 9 * * 1-5", -22, nil, time.Time{}, 21},
+		{"0 0,12 1 * *", 2, tokyo, time.Date(2025, 3, 1, 12, 0, 0, 0, tokyo), 0},
+		{"0 0 31 * *", 1, nil, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), 0},
+		{"0 0 30 2 *", 1, nil, time.Time{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.expr, tt.k), func(t *testing.T) {
+			cron, _ := ParseCron(tt.expr)
+			got, err := cron.KthOccurrenceInMonth(2025, time.March, tt.k, tt.loc)
+			if tt.want.IsZero() {
+				var countErr *OccurrenceCountError
+				if !errors.As(err, &countErr) || !errors.Is(err, ErrFewOccurrences) || countErr.Count != tt.wantCount {
+					t.Fatalf("KthOccurrenceInMonth() error = %v, want %d occurrences", err, tt.wantCount)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) || got.Location() != tt.want.Location() {
+				t.Errorf("KthOccurrenceInMonth() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_KthOccurrenceInMonthErrors(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * *")
+	if _, err := cron.KthOccurrenceInMonth(2025, time.March, 0, nil); err == nil {
+		t.Error("KthOccurrenceInMonth(k = 0) error = nil")
+	}
+	if _, err := cron.KthOccurrenceInMonth(2025, 13, 1, nil); err == nil {
+		t.Error("KthOccurrenceInMonth(month 13) error = nil")
+	}
+
+	_, err := cron.KthOccurrenceInMonth(2025, time.February, 29, nil)
+	if want := "too few occurrences in month: occurrence 29 asked for, February 2025 has 28"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+
+	located, _ := ParseCronWith("0 9 * * *", WithLocation(time.FixedZone("EST", -5*60*60)))
+	got, err := located.KthOccurrenceInMonth(2025, time.March, 1, nil)
+	if err != nil || got.Location().String() != "EST" || got.Day() != 1 {
+		t.Errorf("KthOccurrenceInMonth() = %v, %v, want March 1 in EST", got, err)
+	}
+}
+
+// countdownContext is a context that is done once Err has been called
+// calls times
+type countdownContext struct {
+	context.Context
+	calls int
+}
+
+func (c *countdownContext) Err() error {
+	if c.calls--; c.calls < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestCronTime_OccurrencesBetween(t *testing.T) {
+	cron, _ := ParseCron("0 9 * * 1-5")
+	start := time.Date(2025, 6, 6, 9, 0, 0, 0, time.UTC) // a Friday
+	got, err := cron.OccurrencesBetween(start, start.AddDate(0, 0, 7))
+	want := []time.Time{
+		start,
+		time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 11, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 12, 9, 0, 0, 0, time.UTC),
+	}
+	if err != nil || !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("OccurrencesBetween() = %v, %v, want %v", got, err, want)
+	}
+
+	leap, _ := ParseCron("0 0 29 2 *")
+	got, err = leap.OccurrencesBetween(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || len(got) != 0 {
+		t.Errorf("OccurrencesBetween() = %v, %v, want no times", got, err)
+	}
+}
+
+func TestCronTime_ScanContext(t *testing.T) {
+	cron, _ := ParseCron("0 0 29 2 *")
+	after := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// The scan gets through one check before the context is done
+	_, err := cron.NextContext(&countdownContext{Context: context.Background(), calls: 1}, after)
+	var scanErr *ScanError
+	if !errors.As(err, &scanErr) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("NextContext() error = %v, want a *ScanError for context.Canceled", err)
+	}
+	if want := after.AddDate(0, 0, scanCheckDays); !scanErr.Reached.Equal(want) {
+		t.Errorf("Reached = %v, want %v", scanErr.Reached, want)
+	}
+	if want := "scan stopped at 2025-11-12: context canceled"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cron.OccurrencesBetweenContext(ctx, after, after.AddDate(10, 0, 0)); !errors.Is(err, context.Canceled) {
+		t.Errorf("OccurrencesBetweenContext() error = %v, want context.Canceled", err)
+	}
+	for _, err := range cron.OccurrencesContext(ctx, after) {
+		if !errors.As(err, &scanErr) {
+			t.Errorf("OccurrencesContext() error = %v, want a *ScanError", err)
+		}
+		break
+	}
+
+	if got, err := cron.NextContext(context.Background(), after); err != nil || !got.Equal(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextContext() = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,252 @@
+package cronmath
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option configures how an expression is parsed and rendered
+type Option func(*config)
+
+// config holds the settings applied by Options
+type config struct {
+	dialect     *Dialect
+	sundaySeven bool
+	nameCase    NameCase
+	strict      bool
+	autoFields  bool
+	macroOutput bool
+	zeroPad     bool
+	valueNames  valueNames
+	// intersectDays is set by WithDOMDOWIntersection
+	intersectDays bool
+	// noWrap is set by WithNoWrap
+	noWrap bool
+	// expandSteps is set by WithExpandSteps
+	expandSteps bool
+	// dialectErr is set by WithDialectName for names never registered
+	dialectErr error
+
+	// location is the time zone occurrences are computed in, or nil for
+	// the location of the time they are computed from
+	location *time.Location
+
+	// anchor is the first instant of the anchor month, or zero
+	anchor time.Time
+
+	// wallLocation and wallTime are set by NewAnchored: the location
+	// whose wall clock shifts are computed on, and the instant in it on
+	// the anchor date that the last shift landed on
+	wallLocation *time.Location
+	wallTime     time.Time
+
+	// calendar and calendarAnchor are set by WithCalendar
+	calendar       Calendar
+	calendarAnchor time.Time
+
+	// clock is set by WithClock, or nil for the system clock
+	clock Clock
+
+	// crontabRunner is set by WithCrontabRunner, or nil for the crontab
+	// binary
+	crontabRunner CrontabRunner
+}
+
+// newConfig applies opts to a default configuration
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithDialect makes ParseCronWith reject syntax the dialect does not
+// accept, and makes String() render only syntax the dialect accepts.
+// Without it, expressions are parsed leniently and rendered as written.
+// Dialects such as NCrontab that combine the day fields with AND imply
+// WithDOMDOWIntersection, and those with a seconds field accept six fields.
+func WithDialect(d Dialect) Option {
+	return func(cfg *config) {
+		cfg.dialect = &d
+		if d.IntersectDays {
+			cfg.intersectDays = true
+		}
+	}
+}
+
+// WithDialectName is WithDialect for the dialect registered under name
+// with RegisterDialect. Parsing fails for names that are not registered.
+func WithDialectName(name string) Option {
+	return func(cfg *config) {
+		d, ok := LookupDialect(name)
+		if !ok {
+			cfg.dialectErr = fmt.Errorf("unknown dialect %q", name)
+			return
+		}
+		WithDialect(d)(cfg)
+	}
+}
+
+// WithStrict makes ParseCronWith reject expressions whose fields do not
+// parse, or that Lint finds can never fire
+func WithStrict() Option {
+	return func(cfg *config) {
+		cfg.strict = true
+	}
+}
+
+// WithAutoFields makes ParseCronWith accept the six-field layout with a
+// leading seconds field used by Quartz and robfig/cron, and Quartz's
+// seven-field layout with a trailing year, telling them apart by the
+// number of fields. The detected layout is reported by CronTime.Layout and
+// kept by String().
+func WithAutoFields() Option {
+	return func(cfg *config) {
+		cfg.autoFields = true
+	}
+}
+
+// WithMacroOutput makes String() write "@hourly", "@daily", "@weekly",
+// "@monthly" or "@yearly" instead of the five fields when the expression
+// fires exactly when the macro does. Dialects without macros are written
+// in full.
+func WithMacroOutput() Option {
+	return func(cfg *config) {
+		cfg.macroOutput = true
+	}
+}
+
+// WithZeroPad makes String() and Normalize() write minute and hour values
+// with two digits, "05 09 * * *" rather than "5 9 * * *", including in
+// lists and ranges. Steps are left as they are. Both forms are always
+// accepted on parse.
+func WithZeroPad() Option {
+	return func(cfg *config) {
+		cfg.zeroPad = true
+	}
+}
+
+// valueNames selects how month and weekday values are written
+type valueNames int
+
+const (
+	// keepValueNames writes values as they were written
+	keepValueNames valueNames = iota
+	// namedValues writes values as names, "MON-FRI"
+	namedValues
+	// numericValues writes values as numbers, "1-5"
+	numericValues
+)
+
+// WithNamedOutput makes String() and Normalize() write months and days of
+// the week as names, such as "JAN" and "MON-FRI", including in lists and
+// ranges, however they were written. Steps and the n of "MON#2" stay
+// numbers, and dialects without names are written with numbers.
+func WithNamedOutput() Option {
+	return func(cfg *config) {
+		cfg.valueNames = namedValues
+	}
+}
+
+// WithNumericOutput makes String() and Normalize() write months and days
+// of the week as numbers, "1-5" rather than "MON-FRI", however they were
+// written
+func WithNumericOutput() Option {
+	return func(cfg *config) {
+		cfg.valueNames = numericValues
+	}
+}
+
+// WithLocation makes the expression fire on the wall clock of loc.
+// Without it, occurrences are computed in the location of the time passed
+// in.
+func WithLocation(loc *time.Location) Option {
+	return func(cfg *config) {
+		cfg.location = loc
+	}
+}
+
+// WithNoWrap makes Add and Sub fail with a *DayBoundaryError, matching
+// ErrDayBoundary, when a shift would move the time of day past midnight,
+// rather than wrapping it into the next or previous day
+func WithNoWrap() Option {
+	return func(cfg *config) {
+		cfg.noWrap = true
+	}
+}
+
+// WithExpandSteps makes Add and Sub write a stepped hour field as the
+// list of hours it fires in when the shift is not a whole number of
+// steps, so "0 */6 * * *" plus 30 minutes is "30 0,6,12,18 * * *" rather
+// than "30 */6 * * *", and plus 2 hours "0 2,8,14,20 * * *". Shifts by
+// whole steps keep the step.
+func WithExpandSteps() Option {
+	return func(cfg *config) {
+		cfg.expandSteps = true
+	}
+}
+
+// WithDOMDOWIntersection makes an expression restricted by both day of
+// month and day of week fire only on days matching both, as Quartz does,
+// so "0 9 13 * 5" fires on Friday the 13th alone. Without it the classic
+// cron rule applies and the expression fires on days matching either.
+func WithDOMDOWIntersection() Option {
+	return func(cfg *config) {
+		cfg.intersectDays = true
+	}
+}
+
+// WithSundayAsSeven makes String() and Normalize() write Sunday as 7
+// instead of the default 0. Both forms are always accepted on parse.
+func WithSundayAsSeven() Option {
+	return func(cfg *config) {
+		cfg.sundaySeven = true
+	}
+}
+
+// WithAnchorMonth makes shifts that cross midnight on an expression
+// restricted by day of month use the calendar of the given month. The
+// resulting day of month is written with concrete days, e.g. "0 2 1 * *"
+// moved back three hours with an anchor of March 2025 becomes
+// "0 23 28 * *". Without an anchor such shifts use "L" and "L-n" for days
+// counted from the end of the month, and refuse shifts that depend on the
+// length of the month.
+func WithAnchorMonth(year int, month time.Month) Option {
+	return func(cfg *config) {
+		cfg.anchor = time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// WithCalendar makes AddBusiness and SubBusiness skip the holidays of cal
+// as well as weekends. Holidays fall on concrete dates, so the expression
+// is taken to fire on the days from anchor on: its days of the week in the
+// week starting at anchor, and its days of the month in anchor's month.
+func WithCalendar(cal Calendar, anchor time.Time) Option {
+	return func(cfg *config) {
+		cfg.calendar = cal
+		y, m, d := anchor.Date()
+		cfg.calendarAnchor = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// NameCase selects how month and weekday names are written
+type NameCase int
+
+const (
+	// UpperCase writes names as "MON" and "JAN"
+	UpperCase NameCase = iota
+	// LowerCase writes names as "mon" and "jan"
+	LowerCase
+	// TitleCase writes names as "Mon" and "Jan"
+	TitleCase
+)
+
+// WithNameCase makes String() and Normalize() write month and weekday
+// names in the given case. Names are accepted in any case on parse and
+// written in upper case by default.
+func WithNameCase(nc NameCase) Option {
+	return func(cfg *config) {
+		cfg.nameCase = nc
+	}
+}
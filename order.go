@@ -0,0 +1,129 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrNotComparable is returned when two expressions are never active on
+// the same day, so the order of their firings is meaningless
+var ErrNotComparable = errors.New("expressions are never active on the same day")
+
+// AlwaysBefore reports whether, on every day both a and b are active, each
+// firing of a is followed by a firing of b at least minGap later on the
+// same day. ErrNotComparable is returned when no such day exists, e.g. for
+// a on Mondays and b on Tuesdays.
+func AlwaysBefore(a, b *CronTime, minGap Duration) (bool, error) {
+	sa, err := a.schedule()
+	if err != nil {
+		return false, err
+	}
+	sb, err := b.schedule()
+	if err != nil {
+		return false, err
+	}
+
+	if !shareDay(sa, sb) {
+		return false, fmt.Errorf("%w: %q and %q", ErrNotComparable, a.String(), b.String())
+	}
+
+	// The latest firing of a is the hardest to follow, and the latest
+	// firing of b follows everything that can be followed
+	lastA := slices.Max(expandDay(sa.hour, sa.minute))
+	lastB := slices.Max(expandDay(sb.hour, sb.minute))
+	return Minutes(lastB-lastA) >= minGap, nil
+}
+
+// shareDay reports whether both schedules are ever active on the same day
+func shareDay(a, b *schedule) bool {
+	d := cycleStart
+	for i := 0; i < gregorianCycleDays; i++ {
+		if a.matchesDate(d) && b.matchesDate(d) {
+			return true
+		}
+		d = d.next()
+	}
+	return false
+}
+
+// EnsureGap returns the smallest non-negative shift to apply to downstream
+// so that it fires at least gap after upstream, both having a single fixed
+// time. Upstream "30 3 * * *" and downstream "40 3 * * *" with a 30 minute
+// gap need a shift of 20 minutes. As for AlwaysBefore, downstream must
+// fire on the same day, so one earlier in the day is shifted past
+// upstream, and a gap reaching past midnight cannot be kept.
+func EnsureGap(upstream, downstream *CronTime, gap Duration) (Duration, error) {
+	if gap < 0 || gap >= Hours(24) {
+		return 0, fmt.Errorf("gap %v is not between zero and a day", gap)
+	}
+
+	up, err := upstream.MinuteOfDay()
+	if err != nil {
+		return 0, fmt.Errorf("upstream %q: %v", upstream.String(), err)
+	}
+	down, err := downstream.MinuteOfDay()
+	if err != nil {
+		return 0, fmt.Errorf("downstream %q: %v", downstream.String(), err)
+	}
+
+	// Round the gap up to whole minutes, the resolution of cron
+	need := int((gap + time.Minute - 1) / time.Minute)
+	if up+need >= minutesPerDay {
+		return 0, fmt.Errorf("downstream cannot fire %v after upstream %q on the same day", gap, upstream.String())
+	}
+	if have := down - up; have < need {
+		return Minutes(need - have), nil
+	}
+	return 0, nil
+}
+
+// CompareTime orders expressions by the time of day they fire, for use
+// with slices.SortFunc. Expressions without a single fixed time, such as
+// "*/15 * * * *" or "0,30 9 * * *", come first, ordered by their minute
+// and hour fields as written. Ties are broken by the day-of-week, then the
+// day-of-month, then the month field as written, and finally by the whole
+// expression, so only identical expressions compare equal.
+func CompareTime(a, b *CronTime) int {
+	ma, errA := a.MinuteOfDay()
+	mb, errB := b.MinuteOfDay()
+	switch {
+	case errA != nil && errB == nil:
+		return -1
+	case errA == nil && errB != nil:
+		return 1
+	case errA != nil:
+		if c := strings.Compare(a.Minute+" "+a.Hour, b.Minute+" "+b.Hour); c != 0 {
+			return c
+		}
+	case ma != mb:
+		return ma - mb
+	}
+
+	for _, f := range [][2]string{
+		{a.DayOfWeek, b.DayOfWeek},
+		{a.DayOfMonth, b.DayOfMonth},
+		{a.Month, b.Month},
+		{a.String(), b.String()},
+	} {
+		if c := strings.Compare(f[0], f[1]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// SortByTime sorts crons in place by CompareTime, leaving the expressions
+// themselves untouched. It fails, without sorting, if any expression does
+// not parse.
+func SortByTime(crons []*CronTime) error {
+	for _, c := range crons {
+		if _, err := c.schedule(); err != nil {
+			return fmt.Errorf("cannot sort %q: %v", c.String(), err)
+		}
+	}
+	slices.SortStableFunc(crons, CompareTime)
+	return nil
+}
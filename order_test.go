@@ -0,0 +1,158 @@
+package cronmath
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAlwaysBefore(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		gap         time.Duration
+		want        bool
+		wantErrType error
+	}{
+		{"fixed times", "0 2 * * *", "30 2 * * *", Minutes(30), true, nil},
+		{"gap too small", "0 2 * * *", "20 2 * * *", Minutes(30), false, nil},
+		{"b fires first", "0 3 * * *", "0 2 * * *", 0, false, nil},
+		{"lists", "0 1,2 * * *", "0 1,3 * * *", Hours(1), true, nil},
+		{"late firing of a", "0 1,4 * * *", "0 3 * * *", 0, false, nil},
+		{"overlapping days", "0 2 * * *", "0 3 * * 1-5", Hours(1), true, nil},
+		{"disjoint days", "0 2 * * MON", "0 3 * * TUE", 0, false, ErrNotComparable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := ParseCron(tt.a)
+			b, _ := ParseCron(tt.b)
+			got, err := AlwaysBefore(a, b, tt.gap)
+			if tt.wantErrType != nil {
+				if !errors.Is(err, tt.wantErrType) {
+					t.Fatalf("AlwaysBefore() error = %v, want %v", err, tt.wantErrType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AlwaysBefore() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AlwaysBefore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureGap(t *testing.T) {
+	tests := []struct {
+		name     string
+		up, down string
+		gap      time.Duration
+		want     time.Duration
+		wantErr  bool
+	}{
+		{"needs a shift", "30 3 * * *", "40 3 * * *", Minutes(30), Minutes(20), false},
+		{"already satisfied", "30 3 * * *", "30 4 * * *", Minutes(30), 0, false},
+		{"across midnight", "50 23 * * *", "10 0 * * *", Minutes(30), 0, true},
+		{"downstream earlier in the day", "0 4 * * *", "0 3 * * *", Minutes(30), Minutes(90), false},
+		{"downstream just before", "30 3 * * *", "20 3 * * *", Minutes(30), Minutes(40), false},
+		{"gap up to midnight", "30 23 * * *", "0 1 * * *", Minutes(29), Minutes(1379), false},
+		{"same time", "0 4 * * *", "0 4 * * *", Minutes(15), Minutes(15), false},
+		{"wildcard", "* 4 * * *", "0 5 * * *", Minutes(15), 0, true},
+		{"gap of a day", "0 4 * * *", "0 5 * * *", Hours(24), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, _ := ParseCron(tt.up)
+			down, _ := ParseCron(tt.down)
+			got, err := EnsureGap(up, down, tt.gap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnsureGap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EnsureGap() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			if err := down.Add(got); err != nil {
+				t.Fatalf("Add(%v) error = %v", got, err)
+			}
+			if ok, err := AlwaysBefore(up, down, tt.gap); err != nil || !ok {
+				t.Errorf("AlwaysBefore(%q, %q, %v) = %v, %v after the shift", up, down, tt.gap, ok, err)
+			}
+		})
+	}
+}
+
+func TestCronMath_After(t *testing.T) {
+	up, _ := ParseCron("30 3 * * *")
+	if got, want := New("40 3 * * *").After(up, Minutes(30)).String(), "0 4 * * *"; got != want {
+		t.Errorf("After() = %q, want %q", got, want)
+	}
+}
+
+func TestSortByTime(t *testing.T) {
+	exprs := []string{
+		"30 9 * * *",
+		"0 18 * * 1-5",
+		"*/15 * * * *",
+		"0 9 * * 1",
+		"0 0 * * *",
+		"0 9 * * 0",
+		"0,30 9 * * *",
+		"0 9 1 * *",
+	}
+	want := []string{
+		"*/15 * * * *",
+		"0,30 9 * * *",
+		"0 0 * * *",
+		"0 9 1 * *",
+		"0 9 * * 0",
+		"0 9 * * 1",
+		"30 9 * * *",
+		"0 18 * * 1-5",
+	}
+
+	crons := make([]*CronTime, len(exprs))
+	for i, s := range exprs {
+		crons[i], _ = ParseCron(s)
+	}
+	if err := SortByTime(crons); err != nil {
+		t.Fatalf("SortByTime() error = %v", err)
+	}
+
+	got := make([]string, len(crons))
+	for i, c := range crons {
+		got[i] = c.String()
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortByTime() = %q, want %q", got, want)
+	}
+}
+
+func TestSortByTime_Invalid(t *testing.T) {
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("0 25 * * *")
+	crons := []*CronTime{a, b}
+	if err := SortByTime(crons); err == nil {
+		t.Error("SortByTime() expected error for an out-of-range hour, got nil")
+	}
+	if crons[0] != a {
+		t.Error("SortByTime() reordered crons on error")
+	}
+}
+
+func TestCompareTime(t *testing.T) {
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("00 09 * * *")
+	if got := CompareTime(a, a); got != 0 {
+		t.Errorf("CompareTime(a, a) = %d, want 0", got)
+	}
+	if CompareTime(a, b) == 0 || CompareTime(a, b) != -CompareTime(b, a) {
+		t.Errorf("CompareTime() is not antisymmetric for differently written expressions")
+	}
+}
@@ -0,0 +1,143 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// FieldName names a field of an expression, as in Field.Name
+type FieldName string
+
+// The fields that can be pinned with CronMath.Pin
+const (
+	Second     FieldName = "second"
+	Minute     FieldName = "minute"
+	Hour       FieldName = "hour"
+	DayOfMonth FieldName = "day of month"
+	Month      FieldName = "month"
+	DayOfWeek  FieldName = "day of week"
+	Year       FieldName = "year"
+)
+
+// fieldNames lists every FieldName
+var fieldNames = []FieldName{Second, Minute, Hour, DayOfMonth, Month, DayOfWeek, Year}
+
+// ErrPinnedFieldWouldChange is matched by the *PinnedFieldError returned
+// when an operation would change a field pinned with CronMath.Pin
+var ErrPinnedFieldWouldChange = errors.New("operation would change a pinned field")
+
+// PinnedFieldError reports an operation refused because it would have
+// changed the pinned Field from From to To
+type PinnedFieldError struct {
+	Field    FieldName
+	From, To string
+}
+
+func (e *PinnedFieldError) Error() string {
+	return fmt.Sprintf("%v: %s would change from %s to %s", ErrPinnedFieldWouldChange, e.Field, e.From, e.To)
+}
+
+// Is reports whether target is ErrPinnedFieldWouldChange
+func (e *PinnedFieldError) Is(target error) bool {
+	return target == ErrPinnedFieldWouldChange
+}
+
+// Pin keeps fields as they are for the rest of the chain: an operation
+// that would change what one of them matches fails with a
+// *PinnedFieldError instead, leaving the expression as it was. So
+// New("50 9 * * *").Pin(Hour).Add(Minutes(5)) is "55 9 * * *", while
+// adding 15 minutes fails rather than carry into the hour. Pinning a day
+// field likewise refuses shifts across midnight that would move the days,
+// while rewriting a field without changing what it matches, as Compress
+// does, is allowed. Pins are recorded in the history and undone with it.
+func (cm *CronMath) Pin(fields ...FieldName) *CronMath {
+	args := make([]any, len(fields))
+	for i, f := range fields {
+		args[i] = f
+	}
+	return cm.apply("Pin", args, func() error {
+		for _, f := range fields {
+			if !slices.Contains(fieldNames, f) {
+				return fmt.Errorf("cannot pin unknown field %q", f)
+			}
+		}
+		for _, f := range fields {
+			if !slices.Contains(cm.pinned, f) {
+				cm.pinned = append(cm.pinned, f)
+			}
+		}
+		return nil
+	})
+}
+
+// Pinned returns the fields pinned so far
+func (cm *CronMath) Pinned() []FieldName {
+	return slices.Clone(cm.pinned)
+}
+
+// checkPins returns a *PinnedFieldError for the first pinned field that
+// differs between the expression with the fields before and the current
+// one
+func (cm *CronMath) checkPins(fields exprFields) error {
+	if len(cm.pinned) == 0 {
+		return nil
+	}
+	before := cm.exprAt(fields)
+	// Expressions that do not parse are compared as written
+	sb, errBefore := before.schedule()
+	sa, errAfter := cm.cron.schedule()
+	if errBefore != nil || errAfter != nil {
+		sb, sa = nil, nil
+	}
+	for _, f := range cm.pinned {
+		if before.pinnedPart(sb, f) != cm.cron.pinnedPart(sa, f) {
+			return &PinnedFieldError{Field: f, From: before.fieldNamed(f), To: cm.cron.fieldNamed(f)}
+		}
+	}
+	return nil
+}
+
+// pinnedPart returns what the field f matches in s, the schedule of c, or
+// the field as written when s is nil
+func (c *CronTime) pinnedPart(s *schedule, f FieldName) any {
+	if s == nil {
+		return c.fieldNamed(f)
+	}
+	switch f {
+	case Second:
+		return s.second
+	case Minute:
+		return s.minute
+	case Hour:
+		return s.hour
+	case DayOfMonth:
+		return [3]any{s.dom, s.domFromEnd, s.domLastWeekday}
+	case Month:
+		return s.month
+	case DayOfWeek:
+		return [2]valueSet{s.dow, s.dowNth}
+	}
+	return c.fieldNamed(f)
+}
+
+// fieldNamed returns the field f as written
+func (c *CronTime) fieldNamed(f FieldName) string {
+	switch f {
+	case Second:
+		return c.Second
+	case Minute:
+		return c.Minute
+	case Hour:
+		return c.Hour
+	case DayOfMonth:
+		return c.DayOfMonth
+	case Month:
+		return c.Month
+	case DayOfWeek:
+		return c.DayOfWeek
+	case Year:
+		return c.Year
+	}
+	return ""
+}
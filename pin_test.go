@@ -0,0 +1,79 @@
+package cronmath
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCronMath_Pin(t *testing.T) {
+	tests := []struct {
+		name    string
+		cm      *CronMath
+		want    string
+		wantErr bool
+	}{
+		{"within the hour", New("50 9 * * *").Pin(Hour).Add(Minutes(5)), "55 9 * * *", false},
+		{"carry into the hour", New("50 9 * * *").Pin(Hour).Add(Minutes(15)), "", true},
+		{"back into the hour", New("5 9 * * *").Pin(Hour).Sub(Minutes(10)), "", true},
+		{"pinned minute", New("30 9 * * *").Pin(Minute).Add(Hours(2)), "30 11 * * *", false},
+		{"pinned minute rounded", New("7 9 * * *").Pin(Minute).Round(Minutes(15)), "", true},
+		{"days stay", New("30 22 * * 1-5").Pin(DayOfWeek).Add(Hours(1)), "30 23 * * 1-5", false},
+		{"days would move", New("30 23 * * 1-5").Pin(DayOfWeek).Add(Hours(1)), "", true},
+		{"day of month would move", New("0 1 1 * *").Pin(DayOfMonth).Sub(Hours(2)), "", true},
+		{"wildcard days", New("30 23 * * *").Pin(DayOfMonth, DayOfWeek).Add(Hours(1)), "30 0 * * *", false},
+		{"rewrite keeps values", New("0 9 * * 1,2,3,4,5").Pin(DayOfWeek).Compress(), "0 9 * * 1-5", false},
+		{"pins accumulate", New("50 9 * * 1").Pin(DayOfWeek).Pin(Hour).Add(Minutes(15)), "", true},
+		{"unknown field", New("0 9 * * *").Pin("weekday"), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cm.Error()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.cm.String() != tt.want {
+				t.Errorf("String() = %q, want %q", tt.cm.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_PinError(t *testing.T) {
+	cm := New("50 9 * * *").Pin(Hour).Add(Minutes(15))
+	var pinErr *PinnedFieldError
+	if !errors.As(cm.Error(), &pinErr) || !errors.Is(cm.Error(), ErrPinnedFieldWouldChange) {
+		t.Fatalf("Error() = %v, want a *PinnedFieldError", cm.Error())
+	}
+	if *pinErr != (PinnedFieldError{Field: Hour, From: "9", To: "10"}) {
+		t.Errorf("PinnedFieldError = %+v", *pinErr)
+	}
+	if want := "operation would change a pinned field: hour would change from 9 to 10"; pinErr.Error() != want {
+		t.Errorf("Error() = %q, want %q", pinErr.Error(), want)
+	}
+	if got := cm.History()[1].Result; got != "50 9 * * *" {
+		t.Errorf("refused step left %q, want the expression unchanged", got)
+	}
+}
+
+func TestCronMath_PinHistory(t *testing.T) {
+	cm := New("50 9 * * *").Pin(Hour, Minute)
+	if got := cm.Pinned(); !slices.Equal(got, []FieldName{Hour, Minute}) {
+		t.Errorf("Pinned() = %v", got)
+	}
+	if !strings.Contains(cm.Explain(), "Pin(hour, minute)    50 9 * * *\n") {
+		t.Errorf("Explain() =\n%s\nwant the Pin step", cm.Explain())
+	}
+
+	cm.Undo().Add(Minutes(15))
+	if cm.Error() != nil || cm.String() != "5 10 * * *" || len(cm.Pinned()) != 0 {
+		t.Errorf("after Undo = %q, %v, pinned %v", cm.String(), cm.Error(), cm.Pinned())
+	}
+
+	base := New("50 9 * * *").Pin(Hour)
+	if d := base.Derive().Add(Minutes(15)); !errors.Is(d.Error(), ErrPinnedFieldWouldChange) {
+		t.Errorf("Derive() lost the pins: %v", d)
+	}
+}
@@ -0,0 +1,188 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoQuartz is returned when an expression has no Quartz equivalent
+	ErrNoQuartz = errors.New("expression has no Quartz equivalent")
+
+	// ErrNoFiveField is returned when a Quartz expression has no
+	// five-field equivalent
+	ErrNoFiveField = errors.New("Quartz expression has no five-field equivalent")
+)
+
+// quartzDays is the day-of-week field in Quartz numbering, Sunday to
+// Saturday as 1 to 7
+var quartzDays = fieldSpec{name: "day of week", min: 1, max: 7}
+
+// FromQuartz converts a Quartz or Spring expression, with its seconds and
+// optional year fields, into five-field cron, so "0 5 9 ? * 2-6" becomes
+// "5 9 * * 1-5". Exactly one of the day fields must be "?", as Quartz
+// requires, and days of the week numbered 1 to 7 from Sunday are
+// renumbered 0 to 6. "L", "L-n", "LW" and "#" are kept, as the package
+// understands them; validate the result with ValidateFor to target a cron
+// that does not.
+//
+// ErrNoFiveField is returned for seconds other than 0, a restricted year,
+// and the nearest-weekday "nW" and last-weekday "nL" tokens.
+func FromQuartz(s string, opts ...Option) (*CronTime, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("%w: expected 6 or 7 fields in Quartz expression, got %d", ErrInvalidExpression, len(fields))
+	}
+	sec, dom, dow := fields[0], fields[3], fields[5]
+
+	if n, ok := atoi(sec); !ok || n != 0 {
+		return nil, fmt.Errorf("%w: %q fires at seconds %s", ErrNoFiveField, s, sec)
+	}
+	if len(fields) == 7 && fields[6] != "*" {
+		return nil, fmt.Errorf("%w: %q is restricted by year", ErrNoFiveField, s)
+	}
+	if (dom == "?") == (dow == "?") {
+		return nil, fmt.Errorf("%w: exactly one of day of month and day of week must be \"?\" in Quartz expression: %s %s", ErrInvalidExpression, dom, dow)
+	}
+	if strings.Contains(strings.ReplaceAll(dom, "LW", ""), "W") {
+		return nil, fmt.Errorf("%w: %q uses the nearest weekday to a day of month", ErrNoFiveField, s)
+	}
+
+	dow, err := fromQuartzDays(dow)
+	if err != nil {
+		return nil, err
+	}
+	if dom == "?" {
+		dom = "*"
+	}
+	if dow == "?" {
+		dow = "*"
+	}
+	return ParseCronWith(strings.Join([]string{fields[1], fields[2], dom, fields[4], dow}, " "), append([]Option{WithStrict()}, opts...)...)
+}
+
+// fromQuartzDays renumbers a Quartz day-of-week field from 0. A lone "L"
+// is Quartz's last day of the week, Saturday.
+func fromQuartzDays(field string) (string, error) {
+	if field == "L" {
+		return "6", nil
+	}
+
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		if strings.HasSuffix(part, "L") {
+			return "", fmt.Errorf("%w: day of week %s is the last weekday of the month", ErrNoFiveField, part)
+		}
+		base, step, hasStep := strings.Cut(part, "/")
+		value, nth, hasNth := strings.Cut(base, "#")
+		loStr, hiStr, isRange := strings.Cut(value, "-")
+
+		out, err := fromQuartzDay(loStr)
+		if err != nil {
+			return "", err
+		}
+		if isRange {
+			hi, err := fromQuartzDay(hiStr)
+			if err != nil {
+				return "", err
+			}
+			out += "-" + hi
+		}
+		if hasNth {
+			out += "#" + nth
+		}
+		if hasStep {
+			out += "/" + step
+		}
+		parts[i] = out
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// fromQuartzDay renumbers a single Quartz day of the week, leaving names
+// and wildcards alone
+func fromQuartzDay(s string) (string, error) {
+	if s == "*" || s == "?" || hasNames(s) {
+		return s, nil
+	}
+	n, ok := atoi(s)
+	if !ok || n < quartzDays.min || n > quartzDays.max {
+		return "", fmt.Errorf("%w: invalid day of week %s in Quartz expression, want 1-7 or SUN-SAT", ErrInvalidExpression, s)
+	}
+	return strconv.Itoa(n - 1), nil
+}
+
+// ToQuartz converts the expression into Quartz syntax, adding a seconds
+// field of 0 unless it has one, so "5 9 * * 1-5" becomes "0 5 9 ? * 2-6".
+// The unrestricted day field is written as "?" and days of the week are
+// renumbered 1 to 7 from Sunday, names being kept as written.
+//
+// ErrNoQuartz is returned when both day fields are restricted, which
+// Quartz does not support, and for lists mixing "L" or "#" with other
+// days.
+func (c *CronTime) ToQuartz() (string, error) {
+	if c.IsReboot() {
+		return "", fmt.Errorf("%w: %s", ErrNoQuartz, reboot)
+	}
+	s, err := c.schedule()
+	if err != nil {
+		return "", err
+	}
+
+	// Steps such as "*/2" restrict the days without counting as restricted
+	// for cron's OR rule, so the days matched decide
+	domDays := s.dom != dayOfMonthField.fullSet() || s.domFromEnd != 0 || s.domLastWeekday
+	dowDays := s.dow != dayOfWeekField.fullSet() || s.dowNth != 0
+
+	dom, dow := c.DayOfMonth, c.DayOfWeek
+	switch {
+	case domDays && dowDays:
+		return "", fmt.Errorf("%w: %q restricts both day of month and day of week", ErrNoQuartz, c.String())
+	case dowDays:
+		dom = "?"
+		if dow, err = toQuartzDays(dow); err != nil {
+			return "", fmt.Errorf("%w: %q %v", ErrNoQuartz, c.String(), err)
+		}
+	default:
+		if strings.Contains(dom, "L") && strings.Contains(dom, ",") {
+			return "", fmt.Errorf("%w: %q lists last days with other days of month", ErrNoQuartz, c.String())
+		}
+		if dom == "?" {
+			dom = "*"
+		}
+		dow = "?"
+	}
+
+	sec := "0"
+	if c.layout.hasSeconds() {
+		sec = c.Second
+	}
+	out := []string{sec, c.Minute, c.Hour, dom, c.Month, dow}
+	if c.layout.hasYear() {
+		out = append(out, c.Year)
+	}
+	return strings.Join(out, " "), nil
+}
+
+// toQuartzDays renumbers a restricted day-of-week field from 1. Fields
+// written with names only need no renumbering.
+func toQuartzDays(field string) (string, error) {
+	if !strings.ContainsAny(field, "0123456789") {
+		return field, nil
+	}
+
+	days, nth, err := parseDayOfWeek(field)
+	if err != nil {
+		return "", err
+	}
+	if nth != 0 {
+		bits := nth.values()
+		if len(bits) > 1 || days != 0 {
+			return "", fmt.Errorf("lists an nth weekday with other days")
+		}
+		return fmt.Sprintf("%d#%d", bits[0]/8+1, bits[0]%8), nil
+	}
+	return formatRestricted(days<<1, quartzDays), nil
+}
@@ -0,0 +1,155 @@
+package cronmath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFromQuartz(t *testing.T) {
+	tests := []struct {
+		quartz  string
+		want    string
+		wantErr error
+	}{
+		{"0 5 9 ? * 2-6", "5 9 * * 1-5", nil},
+		{"0 5 9 ? * MON-FRI", "5 9 * * MON-FRI", nil},
+		{"0 0 12 * * ?", "0 12 * * *", nil},
+		{"0 0/5 14 * * ?", "0/5 14 * * *", nil},
+		{"0 15 10 ? * 6#3", "15 10 * * 5#3", nil},
+		{"0 0 0 ? * 1,7", "0 0 * * 0,6", nil},
+		{"0 0 0 ? * 1/2", "0 0 * * 0/2", nil},
+		{"0 0 0 ? * L", "0 0 * * 6", nil},
+		{"0 15 10 L * ?", "15 10 L * *", nil},
+		{"0 0 18 LW * ?", "0 18 LW * *", nil},
+		{"0 0 0 * * ? *", "0 0 * * *", nil},
+		{"30 0 0 * * ?", "", ErrNoFiveField},
+		{"0 0 0 * * ? 2030", "", ErrNoFiveField},
+		{"0 15 10 15W * ?", "", ErrNoFiveField},
+		{"0 15 10 ? * 6L", "", ErrNoFiveField},
+		{"0 0 0 * * 1", "", ErrInvalidExpression},
+		{"0 0 0 ? * ?", "", ErrInvalidExpression},
+		{"0 0 0 ? * 0", "", ErrInvalidExpression},
+		{"0 0 0 ? * 8", "", ErrInvalidExpression},
+		{"0 0 25 ? * 1", "", ErrInvalidExpression},
+		{"0 0 * * *", "", ErrInvalidExpression},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quartz, func(t *testing.T) {
+			cron, err := FromQuartz(tt.quartz)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FromQuartz() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && cron.String() != tt.want {
+				t.Errorf("FromQuartz() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTime_ToQuartz(t *testing.T) {
+	tests := []struct {
+		expr    string
+		opts    []Option
+		want    string
+		wantErr error
+	}{
+		{"5 9 * * 1-5", nil, "0 5 9 ? * 2-6", nil},
+		{"5 9 * * MON-FRI", nil, "0 5 9 ? * MON-FRI", nil},
+		{"0 0 * * 0", nil, "0 0 0 ? * 1", nil},
+		{"0 0 * * 5-7", nil, "0 0 0 ? * 1,6,7", nil},
+		{"0 0 * * */2", nil, "0 0 0 ? * 1-7/2", nil},
+		{"0 9 * * 1#2", nil, "0 0 9 ? * 2#2", nil},
+		{"0 0 1,15 * *", nil, "0 0 0 1,15 * ?", nil},
+		{"0 0 L-2 * *", nil, "0 0 0 L-2 * ?", nil},
+		{"@daily", nil, "0 0 0 * * ?", nil},
+		{"15 0 9 * * ?", []Option{WithAutoFields()}, "15 0 9 * * ?", nil},
+		{"0 0 9 ? * MON 2030", []Option{WithAutoFields()}, "0 0 9 ? * MON 2030", nil},
+		{"0 0 13 * 5", nil, "", ErrNoQuartz},
+		{"0 0 */2 * 1-5", nil, "", ErrNoQuartz},
+		{"0 0 L,15 * *", nil, "", ErrNoQuartz},
+		{"0 0 * * 1#2,3", nil, "", ErrNoQuartz},
+		{"@reboot", nil, "", ErrNoQuartz},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.expr, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			got, err := cron.ToQuartz()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ToQuartz() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ToQuartz() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuartz_RoundTrip(t *testing.T) {
+	// Expressions as found in Spring @Scheduled annotations and Quartz
+	// triggers, already in the form ToQuartz writes
+	quartz := []string{
+		"0 0 12 * * ?",
+		"0 15 10 * * ?",
+		"0 0/5 14 * * ?",
+		"0 0-5 14 * * ?",
+		"0 10,44 14 ? 3 4",
+		"0 15 10 ? * MON-FRI",
+		"0 15 10 15 * ?",
+		"0 15 10 L * ?",
+		"0 15 10 L-2 * ?",
+		"0 15 10 ? * 6#3",
+		"0 0 12 1/5 * ?",
+		"0 11 11 11 11 ?",
+		"0 0 2 ? * 1",
+		"0 30 1 ? * 2-6",
+		"0 0 18 LW * ?",
+		"0 0 */6 * * ?",
+	}
+	for _, q := range quartz {
+		cron, err := FromQuartz(q)
+		if err != nil {
+			t.Errorf("FromQuartz(%q) error = %v", q, err)
+			continue
+		}
+		if got, err := cron.ToQuartz(); got != q || err != nil {
+			t.Errorf("FromQuartz(%q).ToQuartz() = %q, %v", q, got, err)
+		}
+	}
+
+	// Crontab lines, which must come back firing at the same times
+	unix := []string{
+		"*/15 * * * *",
+		"0 3 * * *",
+		"30 9 * * 1-5",
+		"0 0 * * 0",
+		"0 22 * * 5-7",
+		"0 0 1 * *",
+		"0 4 1,15 * *",
+		"0 0 * * */2",
+		"5 4 * JAN,JUL SUN",
+		"0 12 L * *",
+		"0 9 * * 1#1",
+		"@hourly",
+	}
+	for _, u := range unix {
+		cron, _ := ParseCron(u)
+		q, err := cron.ToQuartz()
+		if err != nil {
+			t.Errorf("ToQuartz(%q) error = %v", u, err)
+			continue
+		}
+		back, err := FromQuartz(q)
+		if err != nil {
+			t.Errorf("FromQuartz(%q) error = %v", q, err)
+			continue
+		}
+		if ok, err := Equivalent(cron, back); !ok || err != nil {
+			t.Errorf("%q became %q and %q, which fires differently", u, q, back)
+		}
+	}
+}
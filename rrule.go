@@ -0,0 +1,379 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNoRRule is returned when an expression has no RFC 5545 RRULE
+// equivalent
+var ErrNoRRule = errors.New("expression has no RRULE equivalent")
+
+// rruleDays lists the RRULE weekday codes starting with Sunday
+var rruleDays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ToRRule converts the expression into an RFC 5545 recurrence rule:
+// "30 9 * * MON,WED" becomes "FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=30".
+// Minute and hour steps dividing the hour or day evenly become an
+// INTERVAL, which assumes the rule's DTSTART falls on a firing.
+//
+// ErrNoRRule is returned for expressions restricting both day of month
+// and day of week, whose OR semantics RRULE cannot express, and for other
+// combinations without an equivalent.
+func (c *CronTime) ToRRule() (string, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return "", err
+	}
+	if s.unionDays {
+		return "", fmt.Errorf("%w: %q fires when either day field matches", ErrNoRRule, c.String())
+	}
+	if c.layout.hasSeconds() && c.Second != "0" {
+		return "", fmt.Errorf("%w: %q fires at seconds other than 0", ErrNoRRule, c.String())
+	}
+	if c.layout.hasYear() && c.Year != "*" {
+		return "", fmt.Errorf("%w: %q is restricted by year", ErrNoRRule, c.String())
+	}
+
+	var freq, interval string
+	var by [][2]string
+	add := func(name, value string) {
+		by = append(by, [2]string{name, value})
+	}
+
+	// The day fields decide the frequency unless a minute or hour step
+	// repeats more often. A yearly rule with only BYMONTH would fire on
+	// the day of DTSTART, so months alone just limit a daily one, and the
+	// last weekday is counted within each month.
+	monthDays := s.dom != dayOfMonthField.fullSet() || s.domFromEnd != 0 || s.domLastWeekday
+	weekDays := s.dow != dayOfWeekField.fullSet() || s.dowNth != 0
+	switch {
+	case s.month != monthField.fullSet() && (monthDays || weekDays) && !s.domLastWeekday:
+		freq = "YEARLY"
+	case monthDays || s.dowNth != 0:
+		freq = "MONTHLY"
+	case weekDays:
+		freq = "WEEKLY"
+	default:
+		freq = "DAILY"
+	}
+
+	minuteStep, minuteAligned := alignedStep(s.minute, minuteField)
+	hourStep, hourAligned := alignedStep(s.hour, hourField)
+	subDaily := minuteAligned || hourAligned
+	switch {
+	case minuteAligned:
+		freq, interval = "MINUTELY", minuteStep
+	case hourAligned:
+		freq, interval = "HOURLY", hourStep
+	}
+
+	if s.month != monthField.fullSet() {
+		add("BYMONTH", joinInts(s.month.values()))
+	}
+
+	if monthDays {
+		if s.domLastWeekday {
+			if s.dom != 0 || s.domFromEnd != 0 || weekDays || subDaily || len(expandDay(s.hour, s.minute)) != 1 {
+				return "", fmt.Errorf("%w: %q combines the last weekday with other days or times", ErrNoRRule, c.String())
+			}
+			add("BYDAY", "MO,TU,WE,TH,FR")
+			add("BYSETPOS", "-1")
+		} else {
+			days := s.dom.values()
+			offsets := s.domFromEnd.values()
+			for i := len(offsets) - 1; i >= 0; i-- {
+				days = append(days, -(offsets[i] + 1))
+			}
+			add("BYMONTHDAY", joinInts(days))
+		}
+	}
+
+	if weekDays {
+		if s.dowNth != 0 && subDaily {
+			return "", fmt.Errorf("%w: %q repeats within the day on an nth weekday", ErrNoRRule, c.String())
+		}
+		var days []string
+		for _, d := range s.dow.values() {
+			days = append(days, rruleDays[d])
+		}
+		for _, bit := range s.dowNth.values() {
+			days = append(days, strconv.Itoa(bit%8)+rruleDays[bit/8])
+		}
+		add("BYDAY", strings.Join(days, ","))
+	}
+
+	// An hourly INTERVAL already covers the hours, and a minutely one the
+	// minutes
+	if s.hour != hourField.fullSet() && freq != "HOURLY" {
+		add("BYHOUR", joinInts(s.hour.values()))
+	}
+	if freq != "MINUTELY" {
+		add("BYMINUTE", joinInts(s.minute.values()))
+	}
+
+	parts := []string{"FREQ=" + freq}
+	if interval != "" {
+		parts = append(parts, "INTERVAL="+interval)
+	}
+	for _, kv := range by {
+		parts = append(parts, kv[0]+"="+kv[1])
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+// alignedStep reports whether a set is the whole field or a step from
+// its minimum dividing the field evenly, returning the step as INTERVAL
+// should write it ("" for the whole field)
+func alignedStep(s valueSet, f fieldSpec) (string, bool) {
+	if s == f.fullSet() {
+		return "", true
+	}
+	vals := s.values()
+	if len(vals) < 2 || vals[0] != f.min {
+		return "", false
+	}
+
+	step, size := vals[1]-vals[0], f.max-f.min+1
+	if size%step != 0 || s != rangeSet(f.min, f.max, step) {
+		return "", false
+	}
+	return strconv.Itoa(step), true
+}
+
+// joinInts joins numbers with commas
+func joinInts(vals []int) string {
+	strs := make([]string, len(vals))
+	for i, v := range vals {
+		strs[i] = strconv.Itoa(v)
+	}
+	return strings.Join(strs, ",")
+}
+
+// ParseRRule converts an RFC 5545 recurrence rule into the equivalent
+// expression: "FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=30" becomes
+// "30 9 * * 1,3". FREQ may be MINUTELY, HOURLY, DAILY, WEEKLY, MONTHLY or
+// YEARLY. The rule must name every field it would otherwise take from
+// DTSTART, such as BYHOUR and BYMINUTE for a daily rule, and INTERVAL is
+// only accepted for minutes and hours dividing the hour or day evenly.
+// COUNT and UNTIL have no cron equivalent and are rejected.
+func ParseRRule(rule string) (*CronTime, error) {
+	parts := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(rule, "RRULE:"), ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid RRULE %q: malformed part %q", rule, part)
+		}
+		name = strings.ToUpper(name)
+		if _, dup := parts[name]; dup {
+			return nil, fmt.Errorf("invalid RRULE %q: %s given more than once", rule, name)
+		}
+		parts[name] = strings.ToUpper(value)
+	}
+
+	c, err := parseRRuleParts(parts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RRULE %q: %v", rule, err)
+	}
+	return c, nil
+}
+
+// parseRRuleParts builds the expression for the parts of an RRULE
+func parseRRuleParts(parts map[string]string) (*CronTime, error) {
+	for name := range parts {
+		switch name {
+		case "FREQ", "INTERVAL", "BYMINUTE", "BYHOUR", "BYDAY", "BYMONTHDAY", "BYMONTH", "BYSETPOS", "WKST":
+		case "COUNT", "UNTIL":
+			return nil, fmt.Errorf("%s has no cron equivalent", name)
+		default:
+			return nil, fmt.Errorf("unsupported part %s", name)
+		}
+	}
+
+	interval := 1
+	if s, ok := parts["INTERVAL"]; ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid INTERVAL %s", s)
+		}
+		interval = n
+	}
+
+	c := &CronTime{Minute: "*", Hour: "*", DayOfMonth: "*", Month: "*", DayOfWeek: "*"}
+	byField := func(name string, f fieldSpec, field *string) error {
+		s, ok := parts[name]
+		if !ok {
+			return nil
+		}
+		set, err := parseIntList(s, f)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", name, err)
+		}
+		*field = formatSet(set, f)
+		return nil
+	}
+	if err := byField("BYMINUTE", minuteField, &c.Minute); err != nil {
+		return nil, err
+	}
+	if err := byField("BYHOUR", hourField, &c.Hour); err != nil {
+		return nil, err
+	}
+	if err := byField("BYMONTH", monthField, &c.Month); err != nil {
+		return nil, err
+	}
+
+	freq := parts["FREQ"]
+	require := func(names ...string) error {
+		for _, name := range names {
+			if _, ok := parts[name]; !ok {
+				return fmt.Errorf("FREQ=%s needs %s, as cron cannot take it from DTSTART", freq, name)
+			}
+		}
+		return nil
+	}
+	// stepped sets field to every interval-th value, which must divide
+	// the span of the field, an hour of minutes or a day of hours
+	stepped := func(f fieldSpec, field *string, span string) error {
+		if interval == 1 {
+			return nil
+		}
+		by := "BY" + strings.ToUpper(f.name)
+		if _, ok := parts[by]; ok {
+			return fmt.Errorf("INTERVAL=%d with FREQ=%s cannot be combined with %s", interval, freq, by)
+		}
+		if (f.max-f.min+1)%interval != 0 {
+			return fmt.Errorf("INTERVAL=%d with FREQ=%s does not divide the %s evenly", interval, freq, span)
+		}
+		*field = fmt.Sprintf("*/%d", interval)
+		return nil
+	}
+
+	var err error
+	switch freq {
+	case "MINUTELY":
+		err = stepped(minuteField, &c.Minute, "hour")
+	case "HOURLY":
+		if err = require("BYMINUTE"); err == nil {
+			err = stepped(hourField, &c.Hour, "day")
+		}
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		if interval != 1 {
+			return nil, fmt.Errorf("INTERVAL=%d with FREQ=%s has no cron equivalent", interval, freq)
+		}
+		err = require("BYHOUR", "BYMINUTE")
+	case "":
+		return nil, fmt.Errorf("missing FREQ")
+	default:
+		return nil, fmt.Errorf("unsupported FREQ=%s", freq)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch freq {
+	case "WEEKLY":
+		err = require("BYDAY")
+	case "MONTHLY":
+		if _, ok := parts["BYMONTHDAY"]; !ok {
+			err = require("BYDAY")
+		}
+	case "YEARLY":
+		if err = require("BYMONTH"); err == nil {
+			if _, ok := parts["BYMONTHDAY"]; !ok {
+				err = require("BYDAY")
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.parseRRuleDays(parts, freq); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseRRuleDays sets the day fields from BYMONTHDAY, BYDAY and BYSETPOS
+func (c *CronTime) parseRRuleDays(parts map[string]string, freq string) error {
+	byMonthDay, hasMonthDay := parts["BYMONTHDAY"]
+	byDay, hasDay := parts["BYDAY"]
+	setPos, hasSetPos := parts["BYSETPOS"]
+
+	if hasSetPos {
+		if setPos != "-1" || byDay != "MO,TU,WE,TH,FR" || hasMonthDay || freq != "MONTHLY" {
+			return fmt.Errorf("BYSETPOS is only supported as the last weekday of the month")
+		}
+		c.DayOfMonth = "LW"
+		return nil
+	}
+	if hasMonthDay && hasDay {
+		return fmt.Errorf("BYMONTHDAY and BYDAY must both match in an RRULE but either matches in cron")
+	}
+
+	if hasMonthDay {
+		var m monthDays
+		for _, s := range strings.Split(byMonthDay, ",") {
+			n, err := strconv.Atoi(s)
+			switch {
+			case err != nil || n == 0 || n > dayOfMonthField.max:
+				return fmt.Errorf("invalid BYMONTHDAY %s", s)
+			case n > 0:
+				m.days |= 1 << uint(n)
+			case -n-1 > maxFromEnd:
+				return fmt.Errorf("BYMONTHDAY %s counts back further than cron can", s)
+			default:
+				m.fromEnd |= 1 << uint(-n-1)
+			}
+		}
+		c.DayOfMonth = m.format()
+	}
+
+	if hasDay {
+		var days, nth valueSet
+		for _, s := range strings.Split(byDay, ",") {
+			code := s[max(len(s)-2, 0):]
+			weekday := -1
+			for i, d := range rruleDays {
+				if d == code {
+					weekday = i
+				}
+			}
+			if weekday < 0 {
+				return fmt.Errorf("invalid BYDAY %s", s)
+			}
+
+			if prefix := strings.TrimSuffix(s, code); prefix != "" {
+				n, err := strconv.Atoi(prefix)
+				if err != nil || n < 1 || n > maxNth || (freq != "MONTHLY" && freq != "YEARLY") {
+					return fmt.Errorf("unsupported BYDAY %s", s)
+				}
+				nth |= nthBit(weekday, n)
+				continue
+			}
+			days |= 1 << uint(weekday)
+		}
+
+		var fields []string
+		if days != 0 {
+			fields = append(fields, formatSet(days, dayOfWeekField))
+		}
+		c.DayOfWeek = strings.Join(append(fields, formatNth(nth)...), ",")
+	}
+	return nil
+}
+
+// parseIntList parses a comma-separated list of values of a field
+func parseIntList(s string, f fieldSpec) (valueSet, error) {
+	var set valueSet
+	for _, v := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < f.min || n > f.max {
+			return 0, fmt.Errorf("value %s out of range [%d, %d]", v, f.min, f.max)
+		}
+		set |= 1 << uint(n)
+	}
+	return set, nil
+}
@@ -0,0 +1,154 @@
+package cronmath
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// rruleTests are the conversions of ToRRule, also checked to read back
+// through ParseRRule
+var rruleTests = []struct {
+	cronStr string
+	want    string
+	wantErr error
+}{
+	{"30 9 * * MON,WED", "FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=30", nil},
+	{"0 0 1 * *", "FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0", nil},
+	{"0 9 * * *", "FREQ=DAILY;BYHOUR=9;BYMINUTE=0", nil},
+	{"0 9,17 * * *", "FREQ=DAILY;BYHOUR=9,17;BYMINUTE=0", nil},
+	{"*/15 * * * *", "FREQ=MINUTELY;INTERVAL=15", nil},
+	{"* * * * *", "FREQ=MINUTELY", nil},
+	{"*/15 9-11 * * 1-5", "FREQ=MINUTELY;INTERVAL=15;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9,10,11", nil},
+	{"30 * * * *", "FREQ=HOURLY;BYMINUTE=30", nil},
+	{"0 */6 * * *", "FREQ=HOURLY;INTERVAL=6;BYMINUTE=0", nil},
+	{"5-50/15 * * * *", "FREQ=HOURLY;BYMINUTE=5,20,35,50", nil},
+	{"0 12 25 12 *", "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=12;BYMINUTE=0", nil},
+	{"0 23 L,L-1 * *", "FREQ=MONTHLY;BYMONTHDAY=-2,-1;BYHOUR=23;BYMINUTE=0", nil},
+	{"0 18 LW * *", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;BYHOUR=18;BYMINUTE=0", nil},
+	{"0 9 * * 1#2", "FREQ=MONTHLY;BYDAY=2MO;BYHOUR=9;BYMINUTE=0", nil},
+	{"0 9 * 1 *", "FREQ=DAILY;BYMONTH=1;BYHOUR=9;BYMINUTE=0", nil},
+	{"*/15 * * 1 *", "FREQ=MINUTELY;INTERVAL=15;BYMONTH=1", nil},
+	{"0 9 * 1 MON", "FREQ=YEARLY;BYMONTH=1;BYDAY=MO;BYHOUR=9;BYMINUTE=0", nil},
+	{"0 9 * 1 1#2", "FREQ=YEARLY;BYMONTH=1;BYDAY=2MO;BYHOUR=9;BYMINUTE=0", nil},
+	{"0 18 LW 3,6 *", "FREQ=MONTHLY;BYMONTH=3,6;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;BYHOUR=18;BYMINUTE=0", nil},
+	{"0 9 13 * FRI", "", ErrNoRRule},
+	{"0 9,18 LW * *", "", ErrNoRRule},
+}
+
+func TestCronTime_ToRRule(t *testing.T) {
+	for _, tt := range rruleTests {
+		t.Run(tt.cronStr, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			got, err := cron.ToRRule()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ToRRule() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToRRule() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ToRRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{"FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=30", "30 9 * * 1,3", false},
+		{"RRULE:FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0", "0 0 1 * *", false},
+		{"FREQ=DAILY;BYHOUR=9,17;BYMINUTE=0", "0 9,17 * * *", false},
+		{"FREQ=MINUTELY;INTERVAL=15", "*/15 * * * *", false},
+		{"FREQ=MINUTELY;BYHOUR=9", "* 9 * * *", false},
+		{"FREQ=HOURLY;INTERVAL=6;BYMINUTE=0", "0 */6 * * *", false},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1,-2;BYHOUR=23;BYMINUTE=0", "0 23 L-1,L * *", false},
+		{"FREQ=MONTHLY;BYDAY=2MO;BYHOUR=9;BYMINUTE=0", "0 9 * * 1#2", false},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;BYHOUR=18;BYMINUTE=0", "0 18 LW * *", false},
+		{"FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=12;BYMINUTE=0", "0 12 25 12 *", false},
+		{"freq=daily;byhour=9;byminute=0;wkst=mo", "0 9 * * *", false},
+		{"FREQ=DAILY;BYHOUR=9;BYMINUTE=0;COUNT=10", "", true},
+		{"FREQ=DAILY;BYHOUR=9;BYMINUTE=0;UNTIL=20250101T000000Z", "", true},
+		{"FREQ=DAILY;BYMINUTE=0", "", true},
+		{"FREQ=DAILY;INTERVAL=2;BYHOUR=9;BYMINUTE=0", "", true},
+		{"FREQ=MINUTELY;INTERVAL=7", "", true},
+		{"FREQ=WEEKLY;BYHOUR=9;BYMINUTE=0", "", true},
+		{"FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR;BYHOUR=9;BYMINUTE=0", "", true},
+		{"FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=9;BYMINUTE=0", "", true},
+		{"FREQ=WEEKLY;BYDAY=2MO;BYHOUR=9;BYMINUTE=0", "", true},
+		{"FREQ=SECONDLY", "", true},
+		{"BYHOUR=9", "", true},
+		{"FREQ=DAILY;BYHOUR=24;BYMINUTE=0", "", true},
+		{"FREQ=DAILY;BYHOUR=9;BYHOUR=10;BYMINUTE=0", "", true},
+		{"FREQ=DAILY;byhour=9;BYHOUR=9;BYMINUTE=0", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			cron, err := ParseRRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cron.String() != tt.want {
+				t.Errorf("ParseRRule() = %q, want %q", cron.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRRule_Errors(t *testing.T) {
+	tests := []struct {
+		rule, want string
+	}{
+		{"FREQ=DAILY;BYHOUR=9;BYHOUR=10;BYMINUTE=0", "BYHOUR given more than once"},
+		{"FREQ=MINUTELY;INTERVAL=7", "INTERVAL=7 with FREQ=MINUTELY does not divide the hour evenly"},
+		{"FREQ=HOURLY;INTERVAL=5;BYMINUTE=0", "INTERVAL=5 with FREQ=HOURLY does not divide the day evenly"},
+		{"FREQ=MINUTELY;INTERVAL=15;BYMINUTE=0", "INTERVAL=15 with FREQ=MINUTELY cannot be combined with BYMINUTE"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseRRule(tt.rule); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseRRule(%q) error = %v, want it to say %q", tt.rule, err, tt.want)
+		}
+	}
+}
+
+func TestRRule_RoundTrip(t *testing.T) {
+	exprs := []string{"0 9 * * 1#2,3#4"}
+	for _, tt := range rruleTests {
+		if tt.wantErr == nil {
+			exprs = append(exprs, tt.cronStr)
+		}
+	}
+	for _, cronStr := range exprs {
+		t.Run(cronStr, func(t *testing.T) {
+			cron, _ := ParseCron(cronStr)
+			rule, err := cron.ToRRule()
+			if err != nil {
+				t.Fatalf("ToRRule() error = %v", err)
+			}
+			back, err := ParseRRule(rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) error = %v", rule, err)
+			}
+
+			want, _ := cron.schedule()
+			got, err := back.schedule()
+			if err != nil {
+				t.Fatalf("schedule() of %q error = %v", back.String(), err)
+			}
+			if *got != *want {
+				t.Errorf("round trip through %q gave %q", rule, back.String())
+			}
+		})
+	}
+}
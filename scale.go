@@ -0,0 +1,130 @@
+package cronmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ScaleFrequency makes the expression fire factor times as often, or
+// -factor times less often when factor is negative, by scaling the step
+// of its minute field, or of its hour field when the minute is fixed.
+// "*/30 * * * *" scaled by 2 becomes "*/15 * * * *", and by -2 becomes
+// "0 * * * *", a step outgrowing the minutes carrying into the hours. The
+// new step must divide the field evenly, and expressions with no step to
+// scale, such as the fixed time "0 9 * * *", are refused.
+func (c *CronTime) ScaleFrequency(factor int) error {
+	if c.IsReboot() {
+		return ErrNotShiftable
+	}
+	if factor == 0 {
+		return fmt.Errorf("invalid frequency factor: 0")
+	}
+
+	switch {
+	case isCadence(c.Minute):
+		minute, carry, err := scaleStep(c.Minute, minuteField, factor)
+		if err != nil {
+			return err
+		}
+		hour := c.Hour
+		if carry > 1 {
+			if !isCadence(c.Hour) {
+				return fmt.Errorf("cannot scale %s to fire less often than hourly with a fixed hour: %s", c.Minute, c.Hour)
+			}
+			var dayCarry int
+			if hour, dayCarry, err = scaleStep(c.Hour, hourField, -carry); err != nil {
+				return err
+			}
+			if dayCarry != 0 {
+				return fmt.Errorf("cannot scale %s %s to fire less often than daily", c.Minute, c.Hour)
+			}
+		}
+		c.Minute, c.Hour = minute, hour
+	case isCadence(c.Hour):
+		hour, carry, err := scaleStep(c.Hour, hourField, factor)
+		if err != nil {
+			return err
+		}
+		if carry > 1 {
+			return fmt.Errorf("cannot scale %s %s to fire less often than daily", c.Minute, c.Hour)
+		}
+		c.Hour = hour
+	default:
+		return fmt.Errorf("cannot scale the frequency of a fixed time: %s %s", c.Minute, c.Hour)
+	}
+	return nil
+}
+
+// Halve makes the expression fire half as often, as ScaleFrequency(-2)
+func (c *CronTime) Halve() error {
+	return c.ScaleFrequency(-2)
+}
+
+// Double makes the expression fire twice as often, as ScaleFrequency(2)
+func (c *CronTime) Double() error {
+	return c.ScaleFrequency(2)
+}
+
+// isCadence reports whether a field fires at a regular step: a wildcard,
+// or a single range with a step
+func isCadence(field string) bool {
+	return field == "*" || strings.Contains(field, "/") && !strings.Contains(field, ",")
+}
+
+// scaleStep scales the step of a cadence field by factor as described
+// for ScaleFrequency. A step growing to a multiple of the whole field
+// leaves only the first value, with carry the number of times the field
+// is covered per firing.
+func scaleStep(field string, f fieldSpec, factor int) (scaled string, carry int, err error) {
+	lo, hi, _, err := parseRange(field, f)
+	if err != nil {
+		return "", 0, err
+	}
+	base, stepStr, hasStep := strings.Cut(field, "/")
+	step := 1
+	if hasStep {
+		if step, err = strconv.Atoi(stepStr); err != nil {
+			return "", 0, fmt.Errorf("invalid step in %s field: %s", f.name, field)
+		}
+	}
+
+	size := f.max - f.min + 1
+	how := fmt.Sprintf("fire %d times as often", factor)
+	if factor < 0 {
+		how = fmt.Sprintf("fire %d times less often", -factor)
+	}
+
+	var n int
+	switch {
+	case factor > 0:
+		if step%factor != 0 {
+			return "", 0, fmt.Errorf("cannot make %s %s %s: %d is not divisible by %d", f.name, field, how, step, factor)
+		}
+		n = step / factor
+	case -factor > size:
+		return "", 0, fmt.Errorf("cannot make %s %s %s", f.name, field, how)
+	default:
+		n = step * -factor
+	}
+
+	if base != "*" {
+		if n > hi-lo {
+			return "", 0, fmt.Errorf("cannot make %s %s %s: a step of %d leaves only %d", f.name, field, how, n, lo)
+		}
+		if n == 1 {
+			return fmt.Sprintf("%d-%d", lo, hi), 0, nil
+		}
+		return fmt.Sprintf("%d-%d/%d", lo, hi, n), 0, nil
+	}
+
+	switch {
+	case n%size == 0:
+		return strconv.Itoa(f.min), n / size, nil
+	case n > size || size%n != 0:
+		return "", 0, fmt.Errorf("cannot make %s %s %s: a step of %d does not divide the %d %ss evenly", f.name, field, how, n, size, f.name)
+	case n == 1:
+		return "*", 0, nil
+	}
+	return "*/" + strconv.Itoa(n), 0, nil
+}
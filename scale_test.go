@@ -0,0 +1,62 @@
+package cronmath
+
+import "testing"
+
+func TestScaleFrequency(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		factor  int
+		want    string
+		wantErr bool
+	}{
+		{"double minutes", "*/30 * * * *", 2, "*/15 * * * *", false},
+		{"halve minutes", "*/30 * * * *", -2, "0 * * * *", false},
+		{"minutes carry into hours", "*/30 * * * *", -4, "0 */2 * * *", false},
+		{"minutes carry into stepped hours", "*/30 */2 * * *", -4, "0 */4 * * *", false},
+		{"to every minute", "*/2 9 * * *", 2, "* 9 * * *", false},
+		{"bounded range", "0-30/10 9 * * *", 2, "0-30/5 9 * * *", false},
+		{"bounded range to every minute", "0-30/2 9 * * *", 2, "0-30 9 * * *", false},
+		{"hours", "0 */6 * * *", 3, "0 */2 * * *", false},
+		{"halve hours", "15 */12 * * 1-5", -2, "15 0 * * 1-5", false},
+		{"hourly to every other hour", "0 * * * *", -2, "0 */2 * * *", false},
+		{"not divisible", "*/45 * * * *", 2, "", true},
+		{"uneven result", "*/20 * * * *", -2, "", true},
+		{"past every minute", "* * * * *", 2, "", true},
+		{"past the range", "0-30/20 9 * * *", -2, "", true},
+		{"past a fixed hour", "*/30 9 * * *", -4, "", true},
+		{"past a day", "0 */12 * * *", -4, "", true},
+		{"fixed time", "0 9 * * *", 2, "", true},
+		{"list", "0,30 9 * * *", 2, "", true},
+		{"zero", "*/30 * * * *", 0, "", true},
+		{"reboot", "@reboot", 2, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			err = c.ScaleFrequency(tt.factor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScaleFrequency(%d) error = %v, wantErr %v", tt.factor, err, tt.wantErr)
+			}
+			if !tt.wantErr && c.String() != tt.want {
+				t.Errorf("ScaleFrequency(%d) = %q, want %q", tt.factor, c.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHalveDouble(t *testing.T) {
+	if got := New("*/30 * * * *").Double().String(); got != "*/15 * * * *" {
+		t.Errorf("Double() = %q, want %q", got, "*/15 * * * *")
+	}
+	if got := New("*/30 * * * *").Halve().String(); got != "0 * * * *" {
+		t.Errorf("Halve() = %q, want %q", got, "0 * * * *")
+	}
+	if got := New("*/10 * * * *").Double().Halve().String(); got != "*/10 * * * *" {
+		t.Errorf("Double().Halve() = %q, want %q", got, "*/10 * * * *")
+	}
+}
@@ -0,0 +1,227 @@
+package cronmath
+
+import "time"
+
+// schedule is a CronTime expanded into value sets for matching against
+// calendar dates and times
+type schedule struct {
+	second, minute, hour, dom, month, dow valueSet
+
+	// year holds the years of a year field, empty for every year
+	year yearSet
+
+	// domFromEnd holds the days counted back from the end of the month,
+	// where bit n stands for "L-n"
+	domFromEnd valueSet
+
+	// domLastWeekday matches the last weekday of the month
+	domLastWeekday bool
+
+	// dowNth holds the nth weekdays of the month, as given by nthBit
+	dowNth valueSet
+
+	// unionDays is set when both day fields are restricted, in which case
+	// cron fires on days matching either of them unless configured with
+	// WithDOMDOWIntersection
+	unionDays bool
+}
+
+// unionDays reports whether c fires on days matching either of its day
+// fields, both being restricted without WithDOMDOWIntersection
+func (c *CronTime) unionDays() bool {
+	return isRestricted(c.DayOfMonth) && isRestricted(c.DayOfWeek) && !c.cfg.intersectDays
+}
+
+// schedule expands every field of c
+func (c *CronTime) schedule() (*schedule, error) {
+	if c.IsReboot() {
+		return nil, errNoSchedule
+	}
+	var sets [5]valueSet
+	for _, i := range []int{minuteIndex, hourIndex, monthIndex} {
+		s, err := parseSet(*c.fieldPtrs()[i], standardFields[i])
+		if err != nil {
+			return nil, c.fieldError(i, err)
+		}
+		sets[i] = s
+	}
+
+	dom, err := parseDayOfMonth(c.DayOfMonth)
+	if err != nil {
+		return nil, c.fieldError(dayOfMonthIndex, err)
+	}
+	dow, nth, err := parseDayOfWeek(c.DayOfWeek)
+	if err != nil {
+		return nil, c.fieldError(dayOfWeekIndex, err)
+	}
+
+	second := valueSet(1)
+	if c.layout.hasSeconds() {
+		if second, err = parseSet(c.Second, secondField); err != nil {
+			return nil, c.fieldError(secondIndex, err)
+		}
+	}
+
+	var year yearSet
+	if c.layout.hasYear() {
+		if year, err = parseYears(c.Year); err != nil {
+			return nil, c.fieldError(yearIndex, err)
+		}
+	}
+
+	return &schedule{
+		second:         second,
+		minute:         sets[minuteIndex],
+		hour:           sets[hourIndex],
+		dom:            dom.days,
+		domFromEnd:     dom.fromEnd,
+		domLastWeekday: dom.lastWeekday,
+		month:          sets[monthIndex],
+		dow:            dow,
+		dowNth:         nth,
+		unionDays:      c.unionDays(),
+		year:           year,
+	}, nil
+}
+
+// ExpandDay returns the sorted minutes of the day (0-1439) at which the
+// expression fires on a day it is active, expanding lists, ranges, steps and
+// wildcards in the minute and hour fields. The day-of-month, month and
+// day-of-week fields are ignored. For example "*/15 9-10 * * *" expands to
+// [540 555 570 585 600 615 630 645].
+func (c *CronTime) ExpandDay() ([]int, error) {
+	minutes, err := parseSet(c.Minute, minuteField)
+	if err != nil {
+		return nil, c.fieldError(minuteIndex, err)
+	}
+
+	hours, err := parseSet(c.Hour, hourField)
+	if err != nil {
+		return nil, c.fieldError(hourIndex, err)
+	}
+
+	return expandDay(hours, minutes), nil
+}
+
+// expandDay returns the sorted minutes of the day formed by every
+// combination of the given hours and minutes
+func expandDay(hours, minutes valueSet) []int {
+	mins := minutes.values()
+	day := make([]int, 0, hours.len()*len(mins))
+	for _, h := range hours.values() {
+		for _, m := range mins {
+			day = append(day, h*60+m)
+		}
+	}
+	return day
+}
+
+// matchesDate reports whether the schedule fires at all on d
+func (s *schedule) matchesDate(d date) bool {
+	if !s.month.has(int(d.month)) || !s.year.matches(d.year) {
+		return false
+	}
+
+	last := daysIn(d.year, d.month)
+	domOK := s.dom.has(d.day) || s.domFromEnd.has(last-d.day) ||
+		s.domLastWeekday && d.day == lastWeekday(last, d)
+	dowOK := s.dow.has(int(d.weekday)) || s.dowNth&nthBit(int(d.weekday), (d.day-1)/7+1) != 0
+	if s.unionDays {
+		return domOK || dowOK
+	}
+	return domOK && dowOK
+}
+
+// equivalent reports whether two schedules fire at the same times. Day
+// fields written differently, such as a union covering every day and
+// "*", are compared date by date over a full calendar cycle.
+func (s *schedule) equivalent(o *schedule) bool {
+	if *s == *o {
+		return true
+	}
+	if s.second != o.second || s.minute != o.minute || s.hour != o.hour {
+		return false
+	}
+	d := cycleStart
+	for i := 0; i < gregorianCycleDays; i, d = i+1, d.next() {
+		if s.matchesDate(d) != o.matchesDate(d) {
+			return false
+		}
+	}
+	return true
+}
+
+// lastWeekday returns the last Monday to Friday of d's month, which has
+// last days
+func lastWeekday(last int, d date) int {
+	weekday := (int(d.weekday) + last - d.day) % 7
+	switch time.Weekday(weekday) {
+	case time.Saturday:
+		return last - 1
+	case time.Sunday:
+		return last - 2
+	}
+	return last
+}
+
+// date is a calendar date, used to scan day by day without the cost of
+// time.Time arithmetic
+type date struct {
+	year    int
+	month   time.Month
+	day     int
+	weekday time.Weekday
+}
+
+// dateOf returns the calendar date of t in t's location
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{year: y, month: m, day: d, weekday: t.Weekday()}
+}
+
+// next returns the following calendar date
+func (d date) next() date {
+	d.weekday = (d.weekday + 1) % 7
+	if d.day++; d.day > daysIn(d.year, d.month) {
+		d.day = 1
+		if d.month++; d.month > time.December {
+			d.month = time.January
+			d.year++
+		}
+	}
+	return d
+}
+
+// at returns the time at hour:minute on d in loc
+func (d date) at(hour, minute int, loc *time.Location) time.Time {
+	return time.Date(d.year, d.month, d.day, hour, minute, 0, 0, loc)
+}
+
+// daysIn returns the number of days in the given month
+func daysIn(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if isLeap(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// isLeap reports whether year is a Gregorian leap year
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// cycleStart is the first day of scans over a full calendar cycle, which
+// from the first year a year field can name covers all of them
+var cycleStart = date{year: minYear, month: time.January, day: 1, weekday: time.Thursday}
+
+// gregorianCycleDays is the length of the 400-year Gregorian cycle, after
+// which dates, weekdays and leap years repeat exactly. Scanning this many
+// days decides whether any combination of day fields ever matches.
+const gregorianCycleDays = 146097
@@ -0,0 +1,79 @@
+package cronmath
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCronTime_ExpandDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronStr string
+		want    []int
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "fixed time",
+			cronStr: "5 9 * * *",
+			want:    []int{545},
+		},
+		{
+			name:    "step over an hour range",
+			cronStr: "*/15 9-10 * * *",
+			want:    []int{540, 555, 570, 585, 600, 615, 630, 645},
+		},
+		{
+			name:    "lists",
+			cronStr: "30,0 23,0 * * *",
+			want:    []int{0, 30, 1380, 1410},
+		},
+		{
+			name:    "day fields are ignored",
+			cronStr: "0 12 31 2 1-5",
+			want:    []int{720},
+		},
+		{
+			name:    "every minute",
+			cronStr: "* * * * *",
+			wantLen: minutesPerDay,
+		},
+		{
+			name:    "invalid minute",
+			cronStr: "60 9 * * *",
+			wantErr: true,
+		},
+		{
+			name:    "invalid hour",
+			cronStr: "0 9-x * * *",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.cronStr)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+
+			got, err := cron.ExpandDay()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandDay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.want != nil && !slices.Equal(got, tt.want) {
+				t.Errorf("ExpandDay() = %v, want %v", got, tt.want)
+			}
+			if tt.wantLen != 0 && len(got) != tt.wantLen {
+				t.Errorf("ExpandDay() returned %d minutes, want %d", len(got), tt.wantLen)
+			}
+			if !slices.IsSorted(got) {
+				t.Errorf("ExpandDay() = %v, want sorted", got)
+			}
+		})
+	}
+}
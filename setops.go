@@ -0,0 +1,456 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrEmptyIntersection is returned when two expressions never fire in
+	// the same minute
+	ErrEmptyIntersection = errors.New("expressions have no schedule in common")
+
+	// ErrNotRepresentable is returned when the result of a set operation
+	// exists but cannot be written as a single cron expression
+	ErrNotRepresentable = errors.New("result cannot be represented as a single cron expression")
+
+	// ErrExcludesAll is returned when an exclusion covers every time an
+	// expression fires
+	ErrExcludesAll = errors.New("exclusion covers every occurrence")
+)
+
+// Intersect returns an expression that fires only when both a and b fire.
+// Each field is intersected as a set of values and re-compressed into
+// compact syntax, e.g. "*/10 9-17 * * *" and "0,30 * * * 1-5" intersect to
+// "0,30 9-17 * * 1-5". The result has a seconds or year field when either
+// a or b has one, a missing seconds field counting as "0" and a missing
+// year field as every year.
+//
+// ErrEmptyIntersection is returned when a and b never fire together, and
+// ErrNotRepresentable when the day-of-month/day-of-week OR rule makes the
+// common schedule impossible to express as one cron expression.
+func Intersect(a, b *CronTime) (*CronTime, error) {
+	minute, err := intersectField(a.Minute, b.Minute, minuteField)
+	if err != nil {
+		return nil, err
+	}
+
+	hour, err := intersectField(a.Hour, b.Hour, hourField)
+	if err != nil {
+		return nil, err
+	}
+
+	month, err := intersectField(a.Month, b.Month, monthField)
+	if err != nil {
+		return nil, err
+	}
+
+	dom, dow, err := intersectDays(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	seconds := a.layout.hasSeconds() || b.layout.hasSeconds()
+	second, err := intersectField(secondOf(a), secondOf(b), secondField)
+	if err != nil {
+		return nil, err
+	}
+
+	hasYear := a.layout.hasYear() || b.layout.hasYear()
+	year, err := intersectYears(yearOf(a), yearOf(b))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &CronTime{
+		Minute:     minute,
+		Hour:       hour,
+		DayOfMonth: dom,
+		Month:      month,
+		DayOfWeek:  dow,
+		layout:     layoutWith(seconds, hasYear),
+	}
+	if seconds {
+		c.Second = second
+	}
+	if hasYear {
+		c.Year = year
+	}
+	return c, nil
+}
+
+// secondOf returns the seconds field of c, "0" for a layout without one
+func secondOf(c *CronTime) string {
+	if c.layout.hasSeconds() {
+		return c.Second
+	}
+	return "0"
+}
+
+// yearOf returns the year field of c, "*" for a layout without one
+func yearOf(c *CronTime) string {
+	if c.layout.hasYear() {
+		return c.Year
+	}
+	return "*"
+}
+
+// intersectYears intersects two year fields
+func intersectYears(x, y string) (string, error) {
+	xs, err := parseYears(x)
+	if err != nil {
+		return "", fmt.Errorf("error parsing year: %v", err)
+	}
+	ys, err := parseYears(y)
+	if err != nil {
+		return "", fmt.Errorf("error parsing year: %v", err)
+	}
+
+	switch {
+	case xs == (yearSet{}):
+		return ys.format(), nil
+	case ys == (yearSet{}):
+		return xs.format(), nil
+	}
+	var s yearSet
+	for i := range s {
+		s[i] = xs[i] & ys[i]
+	}
+	if s == (yearSet{}) {
+		return "", fmt.Errorf("%w: year fields %q and %q share no values", ErrEmptyIntersection, x, y)
+	}
+	return s.format(), nil
+}
+
+// intersectField intersects two renderings of the same field
+func intersectField(x, y string, f fieldSpec) (string, error) {
+	xs, ys, err := parseSetPair(x, y, f)
+	if err != nil {
+		return "", err
+	}
+
+	s := xs & ys
+	if s == 0 {
+		return "", fmt.Errorf("%w: %s fields %q and %q share no values", ErrEmptyIntersection, f.name, x, y)
+	}
+	return formatSet(s, f), nil
+}
+
+// intersectDays intersects the day-of-month and day-of-week fields of two
+// expressions. When either day field starts with "*" cron requires both
+// to match; otherwise it fires when either matches. Only combinations
+// that stay within one of those two rules can be represented.
+func intersectDays(a, b *CronTime) (string, string, error) {
+	aDOM, bDOM, err := parseSetPair(a.DayOfMonth, b.DayOfMonth, dayOfMonthField)
+	if err != nil {
+		return "", "", err
+	}
+	aDOW, bDOW, err := parseSetPair(a.DayOfWeek, b.DayOfWeek, dayOfWeekField)
+	if err != nil {
+		return "", "", err
+	}
+
+	aUnion := isRestricted(a.DayOfMonth) && isRestricted(a.DayOfWeek)
+	bUnion := isRestricted(b.DayOfMonth) && isRestricted(b.DayOfWeek)
+	everyDay := func(dom, dow valueSet) bool {
+		return dom == dayOfMonthField.fullSet() && dow == dayOfWeekField.fullSet()
+	}
+
+	switch {
+	case aUnion && bUnion:
+		if aDOM == bDOM && aDOW == bDOW {
+			return formatSet(aDOM, dayOfMonthField), formatSet(aDOW, dayOfWeekField), nil
+		}
+	case aUnion:
+		if everyDay(bDOM, bDOW) {
+			return a.DayOfMonth, a.DayOfWeek, nil
+		}
+	case bUnion:
+		if everyDay(aDOM, aDOW) {
+			return b.DayOfMonth, b.DayOfWeek, nil
+		}
+	default:
+		dom, dow := aDOM&bDOM, aDOW&bDOW
+		if dom == 0 || dow == 0 {
+			return "", "", fmt.Errorf("%w: day fields of %q and %q share no days", ErrEmptyIntersection, a.String(), b.String())
+		}
+
+		domStr, dowStr := formatSet(dom, dayOfMonthField), formatSet(dow, dayOfWeekField)
+		if !isRestricted(domStr) || !isRestricted(dowStr) {
+			return domStr, dowStr, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("%w: day fields of %q and %q combine differently under cron's day-of-month/day-of-week OR rule",
+		ErrNotRepresentable, a.String(), b.String())
+}
+
+// parseSetPair expands the same field of two expressions
+func parseSetPair(x, y string, f fieldSpec) (valueSet, valueSet, error) {
+	xs, err := parseSet(x, f)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing %s: %v", f.name, err)
+	}
+	ys, err := parseSet(y, f)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing %s: %v", f.name, err)
+	}
+	return xs, ys, nil
+}
+
+// Exclude returns an expression that fires whenever base fires and
+// exclusion does not, e.g. "*/15 * * * *" excluding "0 * * * *" is
+// "15,30,45 * * * *". Fields are subtracted as sets and re-compressed,
+// which gives a single expression when exclusion covers base in every
+// field but one. The result keeps base's layout and options.
+//
+// ErrExcludesAll is returned when exclusion covers every occurrence of
+// base, and ErrNotRepresentable when the difference needs more than one
+// expression; ExcludeAll returns those expressions instead.
+func Exclude(base, exclusion *CronTime) (*CronTime, error) {
+	parts, err := ExcludeAll(base, exclusion)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) > 1 {
+		return nil, fmt.Errorf("%w: %q excluding %q needs %d expressions", ErrNotRepresentable, base.String(), exclusion.String(), len(parts))
+	}
+	return parts[0], nil
+}
+
+// ExcludeAll is Exclude for differences needing more than one expression,
+// returning expressions that never fire in the same second and together
+// fire whenever base fires and exclusion does not. Excluding "0 12 * * *"
+// from "0 * * * 1-5" gives "0 0-11,13-23 * * 1-5" and "0 12 * * 1-5"
+// gives nothing, hence ErrExcludesAll.
+//
+// ErrNotRepresentable is returned when either expression fires on days
+// matching either day field and exclusion does not cover the days of
+// base, and when exclusion is restricted by year.
+func ExcludeAll(base, exclusion *CronTime) ([]*CronTime, error) {
+	if base.IsReboot() || exclusion.IsReboot() {
+		return nil, fmt.Errorf("%w: %s fires at no time", ErrNotRepresentable, reboot)
+	}
+	if exclusion.layout.hasYear() && exclusion.Year != "*" {
+		return nil, fmt.Errorf("%w: %q is restricted by year", ErrNotRepresentable, exclusion.String())
+	}
+	b, err := base.schedule()
+	if err != nil {
+		return nil, err
+	}
+	e, err := exclusion.schedule()
+	if err != nil {
+		return nil, err
+	}
+	if b.domFromEnd != 0 || b.domLastWeekday || b.dowNth != 0 || e.domFromEnd != 0 || e.domLastWeekday || e.dowNth != 0 {
+		return nil, fmt.Errorf("%w: %q excluding %q uses days counted within the month", ErrNotRepresentable, base.String(), exclusion.String())
+	}
+
+	// The fields in the order they are split off, seconds last as they
+	// are usually the same
+	specs := [...]fieldSpec{minuteField, hourField, dayOfMonthField, monthField, dayOfWeekField, secondField}
+	bs := [...]valueSet{b.minute, b.hour, b.dom, b.month, b.dow, b.second}
+	es := [...]valueSet{e.minute, e.hour, e.dom, e.month, e.dow, e.second}
+
+	split := []int{minuteIndex, hourIndex, dayOfMonthIndex, monthIndex, dayOfWeekIndex, secondIndex}
+	if b.unionDays || e.unionDays {
+		everyDay := !e.unionDays && e.dom == dayOfMonthField.fullSet() && e.dow == dayOfWeekField.fullSet()
+		sameDays := b.unionDays == e.unionDays && b.dom == e.dom && b.dow == e.dow
+		if !everyDay && !sameDays {
+			return nil, fmt.Errorf("%w: %q and %q combine their day fields differently", ErrNotRepresentable, base.String(), exclusion.String())
+		}
+		// The days of base are all excluded, so only the other fields
+		// split
+		split = []int{minuteIndex, hourIndex, monthIndex, secondIndex}
+	}
+
+	// Each part takes what base has outside exclusion in one field, and
+	// what the two share in the fields split before it
+	var parts []*CronTime
+	shared := bs
+	for _, i := range split {
+		if rest := bs[i] &^ es[i]; rest != 0 {
+			sets := shared
+			sets[i] = rest
+			part, err := excludePart(base, b.unionDays, sets, specs)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+		}
+		if shared[i] &= es[i]; shared[i] == 0 {
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("%w: %q excludes every time %q fires", ErrExcludesAll, exclusion.String(), base.String())
+	}
+	return parts, nil
+}
+
+// excludePart renders the sets of a part of ExcludeAll with base's layout
+// and options
+func excludePart(base *CronTime, unionDays bool, sets [6]valueSet, specs [6]fieldSpec) (*CronTime, error) {
+	part := &CronTime{layout: base.layout, Year: base.Year, cfg: base.cfg}
+	for i, field := range part.fieldPtrs() {
+		*field = formatSet(sets[i], specs[i])
+	}
+	if part.layout.hasSeconds() {
+		part.Second = formatSet(sets[secondIndex], secondField)
+	}
+
+	if unionDays {
+		part.DayOfMonth, part.DayOfWeek = base.DayOfMonth, base.DayOfWeek
+	} else if part.unionDays() {
+		return nil, fmt.Errorf("%w: %q would fire on days matching either day field", ErrNotRepresentable, part.String())
+	}
+	return part, nil
+}
+
+// Union merges expressions into as few expressions as possible without
+// changing when they fire. Two expressions are merged when they differ in
+// exactly one field, by unioning that field: "0 9 * * *" and "0 10 * * *"
+// become "0 9,10 * * *". Exact duplicates are dropped. The seconds and
+// year fields count like the others, and only expressions with the same
+// layout are merged.
+//
+// The result is deterministic: merged expressions take the position of
+// their earliest input, and expressions that could not be merged are
+// returned unchanged in their original order.
+func Union(exprs ...*CronTime) ([]*CronTime, error) {
+	entries := make([]unionEntry, 0, len(exprs))
+	for _, c := range exprs {
+		e, err := newUnionEntry(c)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	for merged := true; merged; {
+		merged = false
+	scan:
+		for i := range entries {
+			for j := i + 1; j < len(entries); j++ {
+				if m, ok := mergeEntries(entries[i], entries[j]); ok {
+					entries[i] = m
+					entries = slices.Delete(entries, j, j+1)
+					merged = true
+					break scan
+				}
+			}
+		}
+	}
+
+	result := make([]*CronTime, len(entries))
+	for i, e := range entries {
+		result[i] = e.cron
+	}
+	return result, nil
+}
+
+// unionEntry is an expression together with its expanded fields, the
+// seconds at secondIndex
+type unionEntry struct {
+	cron  *CronTime
+	sets  [6]valueSet
+	years yearSet
+}
+
+func newUnionEntry(c *CronTime) (unionEntry, error) {
+	e := unionEntry{cron: &CronTime{}}
+	*e.cron = *c
+
+	for i, field := range c.fieldPtrs() {
+		s, err := parseSet(*field, standardFields[i])
+		if err != nil {
+			return unionEntry{}, fmt.Errorf("%q: %w", c.String(), c.fieldError(i, err))
+		}
+		e.sets[i] = s
+	}
+	s, err := parseSet(secondOf(c), secondField)
+	if err != nil {
+		return unionEntry{}, fmt.Errorf("%q: %w", c.String(), c.fieldError(secondIndex, err))
+	}
+	e.sets[secondIndex] = s
+	if e.years, err = parseYears(yearOf(c)); err != nil {
+		return unionEntry{}, fmt.Errorf("%q: %w", c.String(), c.fieldError(yearIndex, err))
+	}
+	return e, nil
+}
+
+// unionDays reports whether the entry fires on days matching either its
+// day-of-month or its day-of-week field
+func (e unionEntry) unionDays() bool {
+	return e.cron.unionDays()
+}
+
+// mergeEntries merges two entries that differ in at most one field
+func mergeEntries(a, b unionEntry) (unionEntry, bool) {
+	if a.unionDays() != b.unionDays() || a.cron.layout != b.cron.layout {
+		return unionEntry{}, false
+	}
+
+	diff := -1
+	for i := range a.sets {
+		if a.sets[i] == b.sets[i] {
+			continue
+		}
+		if diff >= 0 {
+			return unionEntry{}, false
+		}
+		diff = i
+	}
+	if a.years != b.years {
+		if diff >= 0 {
+			return unionEntry{}, false
+		}
+		diff = yearIndex
+	}
+	if diff < 0 {
+		return a, true
+	}
+
+	m := unionEntry{cron: &CronTime{}, sets: a.sets, years: a.years}
+	*m.cron = *a.cron
+	switch diff {
+	case secondIndex:
+		m.sets[diff] |= b.sets[diff]
+		m.cron.Second = formatSet(m.sets[diff], secondField)
+		return m, true
+	case yearIndex:
+		// An empty set is every year, which any union stays
+		if a.years != (yearSet{}) && b.years != (yearSet{}) {
+			for i := range m.years {
+				m.years[i] |= b.years[i]
+			}
+		} else {
+			m.years = yearSet{}
+		}
+		m.cron.Year = m.years.format()
+		return m, true
+	}
+	m.sets[diff] |= b.sets[diff]
+
+	spec, field := standardFields[diff], m.cron.fieldPtrs()[diff]
+	isDayField := diff == dayOfMonthIndex || diff == dayOfWeekIndex
+
+	switch {
+	case !isDayField:
+		*field = formatSet(m.sets[diff], spec)
+	case a.unionDays() && m.sets[diff] == spec.fullSet():
+		// Either day field matching every day makes the schedule daily
+		m.cron.DayOfMonth, m.cron.DayOfWeek = "*", "*"
+		m.sets[dayOfMonthIndex], m.sets[dayOfWeekIndex] = dayOfMonthField.fullSet(), dayOfWeekField.fullSet()
+	case a.unionDays():
+		*field = formatRestricted(m.sets[diff], spec)
+	default:
+		*field = formatSet(m.sets[diff], spec)
+		if m.unionDays() {
+			// The merged field would switch the schedule to OR semantics
+			return unionEntry{}, false
+		}
+	}
+	return m, true
+}
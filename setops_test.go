@@ -0,0 +1,438 @@
+package cronmath
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "working hours on the half hour",
+			a:    "*/10 9-17 * * *",
+			b:    "0,30 * * * 1-5",
+			want: "0,30 9-17 * * 1-5",
+		},
+		{
+			name: "identical expressions",
+			a:    "5 9 * * *",
+			b:    "5 9 * * *",
+			want: "5 9 * * *",
+		},
+		{
+			name: "overlapping day of month ranges",
+			a:    "0 0 1-15 * *",
+			b:    "0 0 10-20 1-6 *",
+			want: "0 0 10-15 1-6 *",
+		},
+		{
+			name: "both restricted with the same day fields",
+			a:    "0 * 13 * 5",
+			b:    "0 9 13 * 5",
+			want: "0 9 13 * 5",
+		},
+		{
+			name: "union days intersected with every day",
+			a:    "0 9 13 * 5",
+			b:    "* * * * *",
+			want: "0 9 13 * 5",
+		},
+		{
+			name:    "disjoint minutes",
+			a:       "0 9 * * *",
+			b:       "30 9 * * *",
+			wantErr: ErrEmptyIntersection,
+		},
+		{
+			name:    "disjoint weekdays",
+			a:       "0 9 * * 1-5",
+			b:       "0 9 * * 0,6",
+			wantErr: ErrEmptyIntersection,
+		},
+		{
+			name:    "day of month and day of week would need AND",
+			a:       "0 9 1 * *",
+			b:       "0 9 * * 1",
+			wantErr: ErrNotRepresentable,
+		},
+		{
+			name:    "different union day fields",
+			a:       "0 9 13 * 5",
+			b:       "0 9 1 * 1",
+			wantErr: ErrNotRepresentable,
+		},
+		{
+			name: "seconds",
+			a:    "*/15 0 9 * * *",
+			b:    "0,30 0 9 * * *",
+			want: "0,30 0 9 * * *",
+		},
+		{
+			name: "seconds against five fields",
+			a:    "0,30 0 9 * * *",
+			b:    "0 9 * * *",
+			want: "0 0 9 * * *",
+		},
+		{
+			name:    "disjoint seconds",
+			a:       "0 0 9 * * *",
+			b:       "30 0 9 * * *",
+			wantErr: ErrEmptyIntersection,
+		},
+		{
+			name: "years",
+			a:    "0 0 9 1 1 ? 2025-2030",
+			b:    "0 0 9 1 1 ? 2028,2030-2035",
+			want: "0 0 9 1 1 * 2028,2030",
+		},
+		{
+			name: "years against every year",
+			a:    "0 0 9 1 1 ? 2025",
+			b:    "0 9 1 1 *",
+			want: "0 0 9 1 1 * 2025",
+		},
+		{
+			name:    "disjoint years",
+			a:       "0 0 9 1 1 ? 2025",
+			b:       "0 0 9 1 1 ? 2026",
+			wantErr: ErrEmptyIntersection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseCronWith(tt.a, WithAutoFields())
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.a, err)
+			}
+			b, err := ParseCronWith(tt.b, WithAutoFields())
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.b, err)
+			}
+
+			got, err := Intersect(a, b)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Intersect() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Intersect() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Intersect() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersect_InvalidField(t *testing.T) {
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("0 25 * * *")
+	if _, err := Intersect(a, b); err == nil {
+		t.Error("Intersect() expected error for out-of-range hour, got nil")
+	}
+}
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		name  string
+		exprs []string
+		want  []string
+	}{
+		{
+			name:  "adjacent hours",
+			exprs: []string{"0 9 * * *", "0 10 * * *"},
+			want:  []string{"0 9,10 * * *"},
+		},
+		{
+			name:  "contiguous hours collapse into a range",
+			exprs: []string{"0 9 * * *", "0 11 * * *", "0 10 * * *"},
+			want:  []string{"0 9-11 * * *"},
+		},
+		{
+			name:  "duplicates are dropped",
+			exprs: []string{"5 9 * * *", "5 9 * * *"},
+			want:  []string{"5 9 * * *"},
+		},
+		{
+			name:  "more than one differing field stays separate",
+			exprs: []string{"0 9 * * *", "30 10 * * *"},
+			want:  []string{"0 9 * * *", "30 10 * * *"},
+		},
+		{
+			name:  "merges cascade and keep input order",
+			exprs: []string{"30 3 * * *", "0 9 * * 1", "0 9 * * 2", "0 10 * * 1-2"},
+			want:  []string{"30 3 * * *", "0 9,10 * * 1,2"},
+		},
+		{
+			name:  "weekdays and weekends make every day",
+			exprs: []string{"0 9 * * 1-5", "0 9 * * 0,6"},
+			want:  []string{"0 9 * * *"},
+		},
+		{
+			name:  "union day fields stay restricted",
+			exprs: []string{"0 9 1 * 1", "0 9 1 * 3"},
+			want:  []string{"0 9 1 * 1,3"},
+		},
+		{
+			name:  "union days covering every weekday become daily",
+			exprs: []string{"0 9 1 * 1-3", "0 9 1 * 0,4-6"},
+			want:  []string{"0 9 * * *"},
+		},
+		{
+			name:  "different day semantics are not merged",
+			exprs: []string{"0 9 1 * *", "0 9 1 * 1"},
+			want:  []string{"0 9 1 * *", "0 9 1 * 1"},
+		},
+		{
+			name:  "merge that would switch to OR semantics is refused",
+			exprs: []string{"0 9 */2 * 1", "0 9 */3 * 1"},
+			want:  []string{"0 9 */2 * 1", "0 9 */3 * 1"},
+		},
+		{
+			name:  "seconds",
+			exprs: []string{"0 0 9 * * *", "30 0 9 * * *"},
+			want:  []string{"0,30 0 9 * * *"},
+		},
+		{
+			name:  "seconds and minutes stay separate",
+			exprs: []string{"0 0 9 * * *", "30 5 9 * * *"},
+			want:  []string{"0 0 9 * * *", "30 5 9 * * *"},
+		},
+		{
+			name:  "years",
+			exprs: []string{"0 0 9 1 1 ? 2025", "0 0 9 1 1 ? 2026", "0 0 9 1 1 ? 2027"},
+			want:  []string{"0 0 9 1 1 ? 2025-2027"},
+		},
+		{
+			name:  "a year and every year",
+			exprs: []string{"0 0 9 1 1 ? 2025", "0 0 9 1 1 ? *"},
+			want:  []string{"0 0 9 1 1 ? *"},
+		},
+		{
+			name:  "years and hours stay separate",
+			exprs: []string{"0 0 9 1 1 ? 2025", "0 0 10 1 1 ? 2026"},
+			want:  []string{"0 0 9 1 1 ? 2025", "0 0 10 1 1 ? 2026"},
+		},
+		{
+			name:  "different layouts stay separate",
+			exprs: []string{"0 9 * * *", "0 0 9 * * *"},
+			want:  []string{"0 9 * * *", "0 0 9 * * *"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crons := make([]*CronTime, len(tt.exprs))
+			for i, s := range tt.exprs {
+				c, err := ParseCronWith(s, WithAutoFields())
+				if err != nil {
+					t.Fatalf("ParseCron(%q) error = %v", s, err)
+				}
+				crons[i] = c
+			}
+
+			got, err := Union(crons...)
+			if err != nil {
+				t.Fatalf("Union() error = %v", err)
+			}
+
+			gotStrs := make([]string, len(got))
+			for i, c := range got {
+				gotStrs[i] = c.String()
+			}
+			if !slices.Equal(gotStrs, tt.want) {
+				t.Errorf("Union() = %q, want %q", gotStrs, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnion_DoesNotMutateInputs(t *testing.T) {
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("0 10 * * *")
+	if _, err := Union(a, b); err != nil {
+		t.Fatalf("Union() error = %v", err)
+	}
+	if a.String() != "0 9 * * *" || b.String() != "0 10 * * *" {
+		t.Errorf("Union() mutated its inputs: %q, %q", a.String(), b.String())
+	}
+}
+
+func TestUnion_InvalidField(t *testing.T) {
+	a, _ := ParseCron("0 9 * * *")
+	b, _ := ParseCron("0 9 * 13 *")
+	if _, err := Union(a, b); err == nil {
+		t.Error("Union() expected error for out-of-range month, got nil")
+	}
+}
+
+func TestExclude(t *testing.T) {
+	tests := []struct {
+		name           string
+		base, excluded string
+		opts           []Option
+		want           string
+		wantErr        error
+	}{
+		{
+			name:     "top of the hour",
+			base:     "*/15 * * * *",
+			excluded: "0 * * * *",
+			want:     "15,30,45 * * * *",
+		},
+		{
+			name:     "lunch hour",
+			base:     "0 * * * 1-5",
+			excluded: "0 12 * * *",
+			want:     "0 0-11,13-23 * * 1-5",
+		},
+		{
+			name:     "weekends",
+			base:     "30 9 * * *",
+			excluded: "* * * * 0,6",
+			want:     "30 9 * * 1-5",
+		},
+		{
+			name:     "first of the month",
+			base:     "0 3 * * *",
+			excluded: "0 3 1 * *",
+			want:     "0 3 2-31 * *",
+		},
+		{
+			name:     "nothing in common",
+			base:     "0 9 * * *",
+			excluded: "30 9 * * *",
+			want:     "0 9 * * *",
+		},
+		{
+			name:     "union days excluded as a whole",
+			base:     "*/30 * 13 * 5",
+			excluded: "0 * * * *",
+			want:     "30 * 13 * 5",
+		},
+		{
+			name:     "seconds",
+			base:     "*/20 0 9 * * *",
+			excluded: "0 0 9 * * *",
+			opts:     []Option{WithAutoFields()},
+			want:     "20,40 0 9 * * *",
+		},
+		{
+			name:     "everything excluded",
+			base:     "0 12 * * 1-5",
+			excluded: "0 12 * * *",
+			wantErr:  ErrExcludesAll,
+		},
+		{
+			name:     "two fields left over",
+			base:     "*/30 9,10 * * *",
+			excluded: "0 9 * * *",
+			wantErr:  ErrNotRepresentable,
+		},
+		{
+			name:     "day of month would switch to OR",
+			base:     "0 9 * * 1",
+			excluded: "0 9 1 * *",
+			wantErr:  ErrNotRepresentable,
+		},
+		{
+			name:     "union days partly excluded",
+			base:     "0 9 13 * 5",
+			excluded: "0 9 * * 5",
+			wantErr:  ErrNotRepresentable,
+		},
+		{
+			name:     "days counted from the end",
+			base:     "0 9 L * *",
+			excluded: "0 9 * * 0",
+			wantErr:  ErrNotRepresentable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, err := ParseCronWith(tt.base, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith(%q) error = %v", tt.base, err)
+			}
+			excluded, err := ParseCronWith(tt.excluded, tt.opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith(%q) error = %v", tt.excluded, err)
+			}
+
+			got, err := Exclude(base, excluded)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Exclude() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exclude() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Exclude() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExcludeAll(t *testing.T) {
+	base, _ := ParseCron("*/30 9,10 * * *")
+	excluded, _ := ParseCron("0 9 * * *")
+
+	parts, err := ExcludeAll(base, excluded)
+	if err != nil {
+		t.Fatalf("ExcludeAll() error = %v", err)
+	}
+	var strs []string
+	for _, p := range parts {
+		strs = append(strs, p.String())
+	}
+	if want := []string{"30 9,10 * * *", "0 10 * * *"}; !slices.Equal(strs, want) {
+		t.Errorf("ExcludeAll() = %q, want %q", strs, want)
+	}
+
+	// The parts fire exactly when base fires and the exclusion does not
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	firings := func(c *CronTime) map[time.Time]bool {
+		times, err := c.NextN(from, 200)
+		if err != nil {
+			t.Fatalf("NextN() error = %v", err)
+		}
+		m := map[time.Time]bool{}
+		for _, at := range times {
+			if at.Before(from.Add(48 * time.Hour)) {
+				m[at] = true
+			}
+		}
+		return m
+	}
+	want, skip := firings(base), firings(excluded)
+	for at := range skip {
+		delete(want, at)
+	}
+	got := map[time.Time]bool{}
+	for _, p := range parts {
+		for at := range firings(p) {
+			if got[at] {
+				t.Errorf("two parts fire at %v", at)
+			}
+			got[at] = true
+		}
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("parts fire at %d times, want %d", len(got), len(want))
+	}
+}
@@ -0,0 +1,196 @@
+package cronmath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Firing is one time a job fires
+type Firing struct {
+	Time time.Time
+	Job  string
+}
+
+// SimDay is the firings of one calendar day, in order
+type SimDay struct {
+	// Date is the start of the day
+	Date    time.Time
+	Firings []Firing
+}
+
+// SimReport is what Simulate found: every firing in the window, grouped
+// by day, and a summary of them
+type SimReport struct {
+	// Start and End bound the window, End being excluded
+	Start, End time.Time
+	// Days covers every day of the window, with or without firings
+	Days []SimDay
+	// Total is the number of firings, and PerJob the number by job
+	Total  int
+	PerJob map[string]int
+	// Busiest is the minute the most jobs fire in, the first of them on a
+	// tie, and BusiestJobs the jobs firing in it
+	Busiest     time.Time
+	BusiestJobs []string
+}
+
+// Gap is a stretch of time in which no job fires
+type Gap struct {
+	From, To time.Time
+}
+
+// Duration returns the length of the gap
+func (g Gap) Duration() time.Duration {
+	return g.To.Sub(g.From)
+}
+
+// Simulate lists when the jobs of crons fire from start, up to but
+// excluding end, read on the wall clock of loc, or of start when loc is
+// nil. Firings at the same time are ordered by job name. The jobs are
+// walked together with their occurrence iterators, so only the report
+// itself grows with the window. "@reboot" jobs and jobs that never fire
+// contribute nothing.
+func Simulate(crons map[string]*CronTime, start, end time.Time, loc *time.Location) (SimReport, error) {
+	if loc == nil {
+		loc = start.Location()
+	}
+	start, end = start.In(loc), end.In(loc)
+	if end.Before(start) {
+		return SimReport{}, fmt.Errorf("simulation ends at %s, before it starts at %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	names := make([]string, 0, len(crons))
+	for name, c := range crons {
+		if !c.IsReboot() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	// One pulled iterator per job, with the time it fires at next
+	type head struct {
+		next func() (time.Time, error, bool)
+		at   time.Time
+		ok   bool
+	}
+	heads := make([]head, len(names))
+	advance := func(h *head, name string) error {
+		t, err, ok := h.next()
+		switch {
+		case !ok, errors.Is(err, ErrNeverFires):
+			h.ok = false
+		case err != nil:
+			return fmt.Errorf("%s: %v", name, err)
+		default:
+			h.at, h.ok = t.In(loc), t.Before(end)
+		}
+		return nil
+	}
+	for i, name := range names {
+		next, stop := iter.Pull2(crons[name].OccurrencesContext(context.Background(), start.Add(-time.Nanosecond)))
+		defer stop()
+		heads[i] = head{next: next}
+		if err := advance(&heads[i], name); err != nil {
+			return SimReport{}, err
+		}
+	}
+
+	r := SimReport{Start: start, End: end, PerJob: make(map[string]int)}
+	for day := startOfDay(start); day.Before(end); day = startOfDay(day.AddDate(0, 0, 1)) {
+		r.Days = append(r.Days, SimDay{Date: day})
+	}
+
+	var (
+		day    int
+		minute time.Time
+		jobs   []string
+	)
+	for {
+		first := -1
+		for i, h := range heads {
+			if h.ok && (first == -1 || h.at.Before(heads[first].at)) {
+				first = i
+			}
+		}
+		if first == -1 {
+			break
+		}
+		f := Firing{Time: heads[first].at, Job: names[first]}
+		if err := advance(&heads[first], names[first]); err != nil {
+			return SimReport{}, err
+		}
+
+		for day+1 < len(r.Days) && !r.Days[day+1].Date.After(f.Time) {
+			day++
+		}
+		r.Days[day].Firings = append(r.Days[day].Firings, f)
+		r.Total++
+		r.PerJob[f.Job]++
+
+		if m := f.Time.Truncate(time.Minute); !m.Equal(minute) {
+			minute, jobs = m, nil
+		}
+		if !slices.Contains(jobs, f.Job) {
+			jobs = append(jobs, f.Job)
+		}
+		if len(jobs) > len(r.BusiestJobs) {
+			r.Busiest, r.BusiestJobs = minute, slices.Clone(jobs)
+		}
+	}
+	return r, nil
+}
+
+// startOfDay returns the first instant of t's date in its location
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// Gaps returns the stretches between consecutive firings longer than d
+func (r SimReport) Gaps(d time.Duration) []Gap {
+	var gaps []Gap
+	var last time.Time
+	for _, day := range r.Days {
+		for _, f := range day.Firings {
+			if !last.IsZero() && f.Time.Sub(last) > d {
+				gaps = append(gaps, Gap{From: last, To: f.Time})
+			}
+			last = f.Time
+		}
+	}
+	return gaps
+}
+
+// String renders the report as text: each day and its firings, then the
+// summary
+func (r SimReport) String() string {
+	var b strings.Builder
+	for _, day := range r.Days {
+		fmt.Fprintf(&b, "%s\n", day.Date.Format("2006-01-02 Mon"))
+		if len(day.Firings) == 0 {
+			b.WriteString("  no firings\n")
+		}
+		for _, f := range day.Firings {
+			layout := "15:04"
+			if f.Time.Second() != 0 {
+				layout = "15:04:05"
+			}
+			fmt.Fprintf(&b, "  %s  %s\n", f.Time.Format(layout), f.Job)
+		}
+	}
+
+	noun := "firings"
+	if r.Total == 1 {
+		noun = "firing"
+	}
+	fmt.Fprintf(&b, "%d %s from %s to %s\n", r.Total, noun, r.Start.Format("2006-01-02 15:04"), r.End.Format("2006-01-02 15:04"))
+	if r.BusiestJobs != nil {
+		fmt.Fprintf(&b, "busiest minute %s: %s\n", r.Busiest.Format("2006-01-02 15:04"), strings.Join(r.BusiestJobs, ", "))
+	}
+	return b.String()
+}
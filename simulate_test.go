@@ -0,0 +1,96 @@
+package cronmath
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSimulate(t *testing.T) {
+	crons := make(map[string]*CronTime)
+	for name, expr := range map[string]string{
+		"backup": "0 2 * * *",
+		"report": "0 2,14 * * 1-5",
+		"sweep":  "*/30 1-2 * * *",
+		"boot":   "@reboot",
+	} {
+		c, err := ParseCron(expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", expr, err)
+		}
+		crons[name] = c
+	}
+
+	// Friday to Sunday
+	start := time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)
+	r, err := Simulate(crons, start, start.AddDate(0, 0, 3), time.UTC)
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+
+	if len(r.Days) != 3 {
+		t.Fatalf("got %d days, want 3", len(r.Days))
+	}
+	var friday []string
+	for _, f := range r.Days[0].Firings {
+		friday = append(friday, f.Time.Format("15:04")+" "+f.Job)
+	}
+	want := []string{"01:00 sweep", "01:30 sweep", "02:00 backup", "02:00 report", "02:00 sweep", "02:30 sweep", "14:00 report"}
+	if !slices.Equal(friday, want) {
+		t.Errorf("Friday = %q, want %q", friday, want)
+	}
+
+	if r.Total != 17 {
+		t.Errorf("Total = %d, want 17", r.Total)
+	}
+	if got := r.PerJob["report"]; got != 2 {
+		t.Errorf("PerJob[report] = %d, want 2", got)
+	}
+	if want := start.Add(2 * time.Hour); !r.Busiest.Equal(want) || !slices.Equal(r.BusiestJobs, []string{"backup", "report", "sweep"}) {
+		t.Errorf("Busiest = %v %q, want %v [backup report sweep]", r.Busiest, r.BusiestJobs, want)
+	}
+
+	gaps := r.Gaps(12 * time.Hour)
+	if len(gaps) != 1 || gaps[0].Duration() != 22*time.Hour+30*time.Minute {
+		t.Errorf("Gaps(12h) = %v, want one gap of 22h30m, Saturday 02:30 to Sunday 01:00", gaps)
+	}
+}
+
+func TestSimulateString(t *testing.T) {
+	c, _ := ParseCron("30 9 * * 1")
+	start := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+	r, err := Simulate(map[string]*CronTime{"standup": c}, start, start.AddDate(0, 0, 2), nil)
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+
+	want := `2025-06-08 Sun
+  no firings
+2025-06-09 Mon
+  09:30  standup
+1 firing from 2025-06-08 00:00 to 2025-06-10 00:00
+busiest minute 2025-06-09 09:30: standup
+`
+	if got := r.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSimulateErrors(t *testing.T) {
+	start := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+
+	never, _ := ParseCron("0 0 30 2 *")
+	r, err := Simulate(map[string]*CronTime{"never": never}, start, start.AddDate(0, 1, 0), time.UTC)
+	if err != nil || r.Total != 0 {
+		t.Errorf("Simulate(never) = %d firings, %v; want none and no error", r.Total, err)
+	}
+
+	invalid, _ := ParseCron("0 25 * * *")
+	if _, err := Simulate(map[string]*CronTime{"invalid": invalid}, start, start.AddDate(0, 0, 1), time.UTC); err == nil {
+		t.Error("expected an error for an invalid expression")
+	}
+
+	if _, err := Simulate(nil, start, start.Add(-time.Hour), time.UTC); err == nil {
+		t.Error("expected an error for a window ending before it starts")
+	}
+}
@@ -0,0 +1,82 @@
+package cronmath
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Suggestion is an expression rounded by SuggestRounded
+type Suggestion struct {
+	// Granularity is the multiple of time the expression was rounded to
+	Granularity Duration
+	Cron        *CronTime
+	// Delta is how far the rounding moves the firing, negative when it
+	// moves earlier
+	Delta Duration
+}
+
+// defaultGranularities are the roundings SuggestRounded proposes without
+// arguments
+var defaultGranularities = []Duration{Minutes(5), Minutes(15), Minutes(30), Hours(1)}
+
+// SuggestRounded proposes cleaner times near the fixed time of the
+// expression, rounded as Round does to each granularity, 5, 15 and 30
+// minutes and an hour by default. "57 9 * * *" gives "55 9 * * *" 2
+// minutes earlier, then "0 10 * * *" 3 minutes later, which the other
+// granularities round to as well. Seconds are rounded off along with the
+// minute.
+//
+// Suggestions are sorted by how little they move the firing, earlier
+// first on ties, and each expression is proposed once, at the smallest
+// granularity giving it. Granularities Round rejects, and those the
+// expression is already on, propose nothing, as do expressions without
+// a single fixed time. The expression is left untouched.
+func (c *CronTime) SuggestRounded(granularities ...Duration) []Suggestion {
+	if len(granularities) == 0 {
+		granularities = defaultGranularities
+	}
+	m, err := c.MinuteOfDay()
+	if err != nil || c.IsReboot() {
+		return nil
+	}
+	current := Minutes(m)
+	if c.layout.hasSeconds() {
+		sec, ok := atoi(c.Second)
+		if !ok {
+			return nil
+		}
+		current += time.Duration(sec) * time.Second
+	}
+
+	sorted := slices.Clone(granularities)
+	slices.Sort(sorted)
+	var suggestions []Suggestion
+	for _, g := range sorted {
+		if g <= 0 || g > Hours(24) || g%time.Minute != 0 {
+			continue
+		}
+		rounded := (current + g/2) / g * g
+		delta := rounded - current
+		if delta == 0 {
+			continue
+		}
+
+		s := *c
+		if s.layout.hasSeconds() {
+			s.Second = "0"
+		}
+		if err := s.adjustTime(int64((rounded - Minutes(m)) / time.Minute)); err != nil {
+			continue
+		}
+		if slices.ContainsFunc(suggestions, func(o Suggestion) bool { return o.Cron.String() == s.String() }) {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{Granularity: g, Cron: &s, Delta: delta})
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int {
+		return cmp.Or(cmp.Compare(a.Delta.Abs(), b.Delta.Abs()), cmp.Compare(a.Delta, b.Delta))
+	})
+	return suggestions
+}
@@ -0,0 +1,49 @@
+package cronmath
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronTime_SuggestRounded(t *testing.T) {
+	type suggestion struct {
+		expr  string
+		delta time.Duration
+	}
+	tests := []struct {
+		expr          string
+		granularities []Duration
+		want          []suggestion
+	}{
+		{"57 9 * * *", nil, []suggestion{{"55 9 * * *", -Minutes(2)}, {"0 10 * * *", Minutes(3)}}},
+		{"7 9 * * 1-5", nil, []suggestion{{"5 9 * * 1-5", -Minutes(2)}, {"0 9 * * 1-5", -Minutes(7)}}},
+		{"40 9 * * *", nil, []suggestion{{"45 9 * * *", Minutes(5)}, {"30 9 * * *", -Minutes(10)}, {"0 10 * * *", Minutes(20)}}},
+		{"13 57 9 * * *", nil, []suggestion{{"0 55 9 * * *", -(Minutes(2) + 13*time.Second)}, {"0 0 10 * * *", Minutes(2) + 47*time.Second}}},
+		{"0 10 * * *", nil, nil},
+		{"50 23 * * 1", []Duration{Hours(1)}, []suggestion{{"0 0 * * 2", Minutes(10)}}},
+		{"10 9 * * *", []Duration{Hours(1), Minutes(20), 0, Hours(25), time.Second}, []suggestion{{"0 9 * * *", -Minutes(10)}, {"20 9 * * *", Minutes(10)}}},
+		{"*/5 9 * * *", nil, nil},
+		{"@reboot", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.expr, WithAutoFields())
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			got := cron.SuggestRounded(tt.granularities...)
+			if cron.String() != tt.expr {
+				t.Errorf("SuggestRounded() changed the expression to %q", cron.String())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SuggestRounded() = %+v, want %v", got, tt.want)
+			}
+			for i, s := range got {
+				if s.Cron.String() != tt.want[i].expr || s.Delta != tt.want[i].delta {
+					t.Errorf("SuggestRounded()[%d] = %q, %v, want %q, %v", i, s.Cron, s.Delta, tt.want[i].expr, tt.want[i].delta)
+				}
+			}
+		})
+	}
+}
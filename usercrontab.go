@@ -0,0 +1,110 @@
+package cronmath
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoCrontab is returned by LoadUserCrontab when the user has no
+// crontab yet
+var ErrNoCrontab = errors.New("no crontab for user")
+
+// CrontabRunner runs the crontab command for LoadUserCrontab and
+// Crontab.Install, feeding it stdin and returning what it wrote to
+// standard output
+type CrontabRunner interface {
+	Run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error)
+}
+
+// CrontabCommandError reports a crontab command that failed, with what it
+// wrote to standard error
+type CrontabCommandError struct {
+	Args   []string
+	Stderr string
+	Err    error
+}
+
+func (e *CrontabCommandError) Error() string {
+	msg := fmt.Sprintf("crontab %s: %v", strings.Join(e.Args, " "), e.Err)
+	if stderr := strings.TrimSpace(e.Stderr); stderr != "" {
+		msg += ": " + stderr
+	}
+	return msg
+}
+
+func (e *CrontabCommandError) Unwrap() error {
+	return e.Err
+}
+
+// execRunner is the default CrontabRunner, running the crontab binary
+// found in PATH
+type execRunner struct{}
+
+// Run runs crontab with args, killing it when ctx is done
+func (execRunner) Run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "crontab", args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, &CrontabCommandError{Args: args, Stderr: stderr.String(), Err: err}
+	}
+	return stdout.Bytes(), nil
+}
+
+// WithCrontabRunner makes LoadUserCrontab and Crontab.Install run the
+// crontab command through r instead of executing it
+func WithCrontabRunner(r CrontabRunner) Option {
+	return func(cfg *config) {
+		cfg.crontabRunner = r
+	}
+}
+
+// runner returns the configured CrontabRunner
+func (cfg config) runner() CrontabRunner {
+	if cfg.crontabRunner == nil {
+		return execRunner{}
+	}
+	return cfg.crontabRunner
+}
+
+// LoadUserCrontab reads the crontab of the current user with
+// "crontab -l", parsing its entries with opts as ParseCrontab does. A
+// user without a crontab gets ErrNoCrontab along with an empty crontab,
+// which can still be installed.
+func LoadUserCrontab(ctx context.Context, opts ...Option) (*Crontab, error) {
+	cfg := newConfig(opts)
+	out, err := cfg.runner().Run(ctx, nil, "-l")
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		var cmdErr *CrontabCommandError
+		if errors.As(err, &cmdErr) && strings.Contains(strings.ToLower(cmdErr.Stderr), "no crontab for") {
+			return &Crontab{cfg: cfg}, ErrNoCrontab
+		}
+		return nil, err
+	}
+	return ParseCrontab(bytes.NewReader(out), opts...)
+}
+
+// Install replaces the crontab of the current user with t, as
+// "crontab -" does. Nothing is installed when an entry would not be
+// written as a five-field crontab line reading back the same schedule;
+// those entries are reported in a *SetError naming their line.
+func (t *Crontab) Install(ctx context.Context) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	if _, err := t.cfg.runner().Run(ctx, strings.NewReader(t.String()), "-"); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cronmath
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeRunner plays the crontab command, holding the installed crontab
+type fakeRunner struct {
+	crontab   string
+	installed bool
+	err       error
+	calls     [][]string
+}
+
+func (f *fakeRunner) Run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	if err := ctx.Err(); err != nil {
+		return nil, &CrontabCommandError{Args: args, Err: err}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	switch {
+	case slices.Equal(args, []string{"-l"}) && !f.installed:
+		return nil, &CrontabCommandError{Args: args, Stderr: "no crontab for alice\n", Err: &exec.ExitError{}}
+	case slices.Equal(args, []string{"-l"}):
+		return []byte(f.crontab), nil
+	case slices.Equal(args, []string{"-"}):
+		b, err := io.ReadAll(stdin)
+		f.crontab, f.installed = string(b), true
+		return nil, err
+	}
+	return nil, errors.New("unexpected arguments")
+}
+
+func TestLoadUserCrontab(t *testing.T) {
+	runner := &fakeRunner{installed: true, crontab: "MAILTO=ops\n0 9 * * 1-5 /usr/bin/report\n30 23 * * * /usr/bin/backup\n"}
+	ctx := context.Background()
+
+	tab, err := LoadUserCrontab(ctx, WithCrontabRunner(runner))
+	if err != nil {
+		t.Fatalf("LoadUserCrontab() error = %v", err)
+	}
+	if err := tab.ShiftAll(Hours(1)); err != nil {
+		t.Fatalf("ShiftAll() error = %v", err)
+	}
+	if err := tab.Install(ctx); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	want := "MAILTO=ops\n0 10 * * 1-5 /usr/bin/report\n30 0 * * * /usr/bin/backup\n"
+	if runner.crontab != want {
+		t.Errorf("installed crontab =\n%s\nwant\n%s", runner.crontab, want)
+	}
+}
+
+func TestLoadUserCrontab_NoCrontab(t *testing.T) {
+	runner := &fakeRunner{}
+	ctx := context.Background()
+
+	tab, err := LoadUserCrontab(ctx, WithCrontabRunner(runner))
+	if !errors.Is(err, ErrNoCrontab) || tab == nil || len(tab.Entries()) != 0 {
+		t.Fatalf("LoadUserCrontab() = %v, %v, want an empty crontab and ErrNoCrontab", tab, err)
+	}
+	if err := tab.Install(ctx); err != nil || !runner.installed || runner.crontab != "" {
+		t.Errorf("Install() error = %v, installed %q", err, runner.crontab)
+	}
+}
+
+func TestLoadUserCrontab_Errors(t *testing.T) {
+	failure := &CrontabCommandError{Args: []string{"-l"}, Stderr: "crontab: permission denied\n", Err: errors.New("exit status 1")}
+	_, err := LoadUserCrontab(context.Background(), WithCrontabRunner(&fakeRunner{err: failure}))
+	if err != failure || errors.Is(err, ErrNoCrontab) {
+		t.Errorf("LoadUserCrontab() error = %v, want %v", err, failure)
+	}
+	if want := "crontab -l: exit status 1: crontab: permission denied"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := LoadUserCrontab(ctx, WithCrontabRunner(&fakeRunner{installed: true})); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadUserCrontab() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCrontab_InstallRefusesBadEntries(t *testing.T) {
+	runner := &fakeRunner{installed: true, crontab: "0 9 * * * /usr/bin/report\n"}
+	tab, err := LoadUserCrontab(context.Background(), WithCrontabRunner(runner))
+	if err != nil {
+		t.Fatalf("LoadUserCrontab() error = %v", err)
+	}
+	entry := tab.Entries()[0].Cron
+	entry.layout, entry.Second = LayoutSeconds, "30"
+
+	err = tab.Install(context.Background())
+	var setErr *SetError
+	if !errors.As(err, &setErr) || len(setErr.Errors) != 1 || setErr.Errors[0].Name != "line 1" {
+		t.Fatalf("Install() error = %v, want a *SetError for line 1", err)
+	}
+	if len(runner.calls) != 1 || !strings.Contains(runner.crontab, "0 9 * * *") {
+		t.Errorf("Install() ran crontab %v, leaving %q", runner.calls, runner.crontab)
+	}
+}
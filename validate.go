@@ -0,0 +1,47 @@
+package cronmath
+
+import "errors"
+
+// Validate checks every field of the expression, returning the problems
+// of all fields that do not parse joined with errors.Join, each a
+// *ParseError naming the field and its token. Unlike ParseCron, which
+// stops at the first problem, it reports them all at once.
+func (c *CronTime) Validate() error {
+	return c.validate(c.rawString())
+}
+
+// ValidateString is Validate for an expression that has not been parsed,
+// applying the given options. Problems with the expression as a whole,
+// such as the wrong number of fields, are returned on their own.
+func ValidateString(s string, opts ...Option) error {
+	c, expr, err := splitFields(s, newConfig(opts))
+	if err != nil {
+		return err
+	}
+	return c.validate(expr)
+}
+
+// validate checks every field of c, reporting expr in the errors
+func (c *CronTime) validate(expr string) error {
+	if c.IsReboot() {
+		return nil
+	}
+
+	var errs []error
+	if c.layout.hasSeconds() {
+		if _, err := parseSet(c.Second, secondField); err != nil {
+			errs = append(errs, c.parseError(expr, secondIndex, err))
+		}
+	}
+	if c.layout.hasYear() {
+		if _, err := parseYears(c.Year); err != nil {
+			errs = append(errs, c.parseError(expr, yearIndex, err))
+		}
+	}
+	for i, field := range c.fieldPtrs() {
+		if err := parseStandardField(i, *field); err != nil {
+			errs = append(errs, c.parseError(expr, i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
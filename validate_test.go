@@ -0,0 +1,75 @@
+package cronmath
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateString(t *testing.T) {
+	tests := []struct {
+		name       string
+		cronStr    string
+		opts       []Option
+		wantFields []string
+		wantErr    bool
+	}{
+		{"valid", "*/15 9-17 * * MON-FRI", nil, nil, false},
+		{"one field", "0 25 * * *", nil, []string{"hour"}, true},
+		{"every field", "61 25 32 13 8", nil, []string{"minute", "hour", "day of month", "month", "day of week"}, true},
+		{"seconds", "99 0 9 * * XYZ", []Option{WithAutoFields()}, []string{"second", "day of week"}, true},
+		{"field count", "0 9 * *", nil, nil, true},
+		{"reboot", "@reboot", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateString(tt.cronStr, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantFields == nil {
+				return
+			}
+
+			joined, ok := err.(interface{ Unwrap() []error })
+			if !ok {
+				t.Fatalf("ValidateString() error = %v, want joined errors", err)
+			}
+			var fields []string
+			for _, e := range joined.Unwrap() {
+				var pe *ParseError
+				if !errors.As(e, &pe) {
+					t.Fatalf("error %v is not a *ParseError", e)
+				}
+				if pe.Expr != tt.cronStr {
+					t.Errorf("ParseError.Expr = %q, want %q", pe.Expr, tt.cronStr)
+				}
+				fields = append(fields, pe.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("ValidateString() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestCronTime_Validate(t *testing.T) {
+	cron, err := ParseCron("0 9 * * *")
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	if err := cron.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	cron.Hour, cron.DayOfWeek = "24", "1-9"
+	err = cron.Validate()
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Field != "hour" || pe.Value != "24" || pe.Expr != "0 24 * * 1-9" {
+		t.Fatalf("Validate() error = %v, want the hour first", err)
+	}
+	if joined := err.(interface{ Unwrap() []error }).Unwrap(); len(joined) != 2 {
+		t.Errorf("Validate() joined %d errors, want 2", len(joined))
+	}
+}
@@ -0,0 +1,82 @@
+package cronmath
+
+import (
+	"slices"
+	"time"
+)
+
+// maxVerifyExamples is how many times VerifyResult lists of each mismatch
+const maxVerifyExamples = 10
+
+// VerifyResult compares run times against an expression, as reported by
+// VerifyFires. Times are to the minute.
+type VerifyResult struct {
+	// Matched counts the run times the expression fires at
+	Matched int
+	// OffSchedule counts the run times the expression does not fire at;
+	// OffScheduleTimes lists the first 10 of them
+	OffSchedule      int
+	OffScheduleTimes []time.Time
+	// Missed counts the firings between the first and the last run time
+	// with no run at them; MissedTimes lists the first 10 of them
+	Missed      int
+	MissedTimes []time.Time
+}
+
+// OK reports whether every run was on schedule and none was missed
+func (r VerifyResult) OK() bool {
+	return r.OffSchedule == 0 && r.Missed == 0
+}
+
+// VerifyFires checks the times a job ran at against the expression, read
+// in loc, or in the location given by WithLocation or else UTC when loc
+// is nil. Times are truncated to the minute, several runs within a minute
+// counting once, so a job started late by a few seconds is on schedule.
+// Runs at a time the expression does not fire at are off schedule, and
+// firings between the first and the last run with no run at them are
+// missed. No times give an empty result.
+func (c *CronTime) VerifyFires(times []time.Time, loc *time.Location) (VerifyResult, error) {
+	s, err := c.schedule()
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	loc = c.locationOr(loc)
+
+	runs := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		runs = append(runs, t.Truncate(time.Minute).In(loc))
+	}
+	slices.SortFunc(runs, time.Time.Compare)
+	runs = slices.CompactFunc(runs, time.Time.Equal)
+	if len(runs) == 0 {
+		return VerifyResult{}, nil
+	}
+
+	var firings []time.Time
+	end := runs[len(runs)-1].Add(time.Minute)
+	for t, ok := s.next(runs[0]); ok && t.Before(end); t, ok = s.next(t.Truncate(time.Minute).Add(time.Minute)) {
+		firings = append(firings, t.Truncate(time.Minute))
+	}
+
+	var r VerifyResult
+	for _, t := range runs {
+		if _, found := slices.BinarySearchFunc(firings, t, time.Time.Compare); found {
+			r.Matched++
+			continue
+		}
+		r.OffSchedule++
+		if len(r.OffScheduleTimes) < maxVerifyExamples {
+			r.OffScheduleTimes = append(r.OffScheduleTimes, t)
+		}
+	}
+	for _, t := range firings {
+		if _, found := slices.BinarySearchFunc(runs, t, time.Time.Compare); found {
+			continue
+		}
+		r.Missed++
+		if len(r.MissedTimes) < maxVerifyExamples {
+			r.MissedTimes = append(r.MissedTimes, t)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,82 @@
+package cronmath
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCronTime_VerifyFires(t *testing.T) {
+	at := func(day, hour, minute, sec int) time.Time {
+		return time.Date(2025, time.March, day, hour, minute, sec, 0, time.UTC)
+	}
+	tests := []struct {
+		name        string
+		expr        string
+		times       []time.Time
+		want        VerifyResult
+		wantOffTime []time.Time
+	}{
+		{"on schedule", "0 9 * * *", []time.Time{at(3, 9, 0, 4), at(4, 9, 0, 0), at(5, 9, 0, 59)}, VerifyResult{Matched: 3}, nil},
+		{"same minute twice", "0 9 * * *", []time.Time{at(3, 9, 0, 1), at(3, 9, 0, 30), at(4, 9, 0, 0)}, VerifyResult{Matched: 2}, nil},
+		{"off schedule", "0 9 * * *", []time.Time{at(3, 9, 0, 0), at(3, 9, 1, 0), at(4, 9, 0, 0)}, VerifyResult{Matched: 2, OffSchedule: 1}, []time.Time{at(3, 9, 1, 0)}},
+		{"missed", "0 9 * * *", []time.Time{at(3, 9, 0, 0), at(6, 9, 0, 0)}, VerifyResult{Matched: 2, Missed: 2}, nil},
+		{"missed and off", "*/15 9 * * *", []time.Time{at(3, 9, 0, 0), at(3, 9, 31, 0), at(3, 9, 45, 0)}, VerifyResult{Matched: 2, OffSchedule: 1, Missed: 2}, []time.Time{at(3, 9, 31, 0)}},
+		{"with seconds", "30 0 9 * * *", []time.Time{at(3, 9, 0, 5), at(4, 9, 0, 0)}, VerifyResult{Matched: 2}, nil},
+		{"no times", "0 9 * * *", nil, VerifyResult{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := mustParse(t, tt.expr).VerifyFires(tt.times, nil)
+			if err != nil {
+				t.Fatalf("VerifyFires() error = %v", err)
+			}
+			if r.Matched != tt.want.Matched || r.OffSchedule != tt.want.OffSchedule || r.Missed != tt.want.Missed {
+				t.Errorf("VerifyFires() = %+v, want %+v", r, tt.want)
+			}
+			if !slices.Equal(r.OffScheduleTimes, tt.wantOffTime) {
+				t.Errorf("OffScheduleTimes = %v, want %v", r.OffScheduleTimes, tt.wantOffTime)
+			}
+			if len(r.MissedTimes) != r.Missed {
+				t.Errorf("MissedTimes = %v for %d missed", r.MissedTimes, r.Missed)
+			}
+			if want := tt.want.OffSchedule == 0 && tt.want.Missed == 0; r.OK() != want {
+				t.Errorf("OK() = %v, want %v", r.OK(), want)
+			}
+		})
+	}
+}
+
+func TestCronTime_VerifyFiresExamples(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	r, err := mustParse(t, "* * * * *").VerifyFires([]time.Time{start, start.Add(time.Hour)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Missed != 59 || len(r.MissedTimes) != maxVerifyExamples || !r.MissedTimes[0].Equal(start.Add(time.Minute)) {
+		t.Errorf("VerifyFires() = %d missed, examples %v", r.Missed, r.MissedTimes)
+	}
+}
+
+func TestCronTime_VerifyFiresLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	runs := []time.Time{
+		time.Date(2025, time.March, 8, 14, 0, 0, 0, time.UTC),  // 9:00 EST
+		time.Date(2025, time.March, 10, 13, 0, 0, 0, time.UTC), // 9:00 EDT
+	}
+	r, err := mustParse(t, "0 9 * * *").VerifyFires(runs, ny)
+	if err != nil || r.Matched != 2 || r.Missed != 1 || !r.MissedTimes[0].Equal(time.Date(2025, time.March, 9, 9, 0, 0, 0, ny)) {
+		t.Errorf("VerifyFires() = %+v, %v", r, err)
+	}
+	if r, _ := mustParse(t, "0 9 * * *").VerifyFires(runs, nil); r.OffSchedule != 2 {
+		t.Errorf("VerifyFires() in UTC = %+v, want both off schedule", r)
+	}
+
+	if _, err := mustParse(t, "@reboot").VerifyFires(runs, nil); err == nil {
+		t.Error("VerifyFires() of @reboot succeeded")
+	}
+}
@@ -0,0 +1,273 @@
+package cronmath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Window is a span of time each day bounded by two expressions with fixed
+// times, such as a maintenance window from "0 2 * * SUN" to "0 6 * * SUN".
+//
+// Both expressions share the same day constraints, which select the days
+// the window opens on. When End is at or before Start in the day, the
+// window runs overnight and closes on the following day.
+type Window struct {
+	Start, End *CronTime
+}
+
+// NewWindow returns the window opening when start fires and closing when
+// end fires
+func NewWindow(start, end *CronTime) (*Window, error) {
+	w := &Window{Start: start, End: end}
+	if _, _, err := w.bounds(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// bounds validates the window, returning the schedule of its days and the
+// minutes of the day it opens and closes at
+func (w *Window) bounds() (*schedule, [2]int, error) {
+	var minutes [2]int
+	var days [2]*schedule
+	for i, c := range [2]*CronTime{w.Start, w.End} {
+		name := [2]string{"start", "end"}[i]
+
+		m, err := c.MinuteOfDay()
+		if err != nil {
+			return nil, minutes, fmt.Errorf("window %s %q: %v", name, c.String(), err)
+		}
+		s, err := c.schedule()
+		if err != nil {
+			return nil, minutes, fmt.Errorf("window %s %q: %v", name, c.String(), err)
+		}
+		s.minute, s.hour = 0, 0
+		minutes[i], days[i] = m, s
+	}
+
+	if *days[0] != *days[1] {
+		return nil, minutes, fmt.Errorf("window start %q and end %q must share day constraints", w.Start.String(), w.End.String())
+	}
+	if minutes[0] == minutes[1] {
+		return nil, minutes, fmt.Errorf("window start %q and end %q are at the same time", w.Start.String(), w.End.String())
+	}
+	return days[0], minutes, nil
+}
+
+// overnight reports whether a window opening and closing at the given
+// minutes of the day closes on the following day
+func overnight(minutes [2]int) bool {
+	return minutes[1] < minutes[0]
+}
+
+// Contains reports whether t, taken in its own location, falls inside the
+// window. The window includes its start and excludes its end. An invalid
+// window contains nothing.
+func (w *Window) Contains(t time.Time) bool {
+	days, minutes, err := w.bounds()
+	if err != nil {
+		return false
+	}
+
+	m := t.Hour()*60 + t.Minute()
+	if !overnight(minutes) {
+		return days.matchesDate(dateOf(t)) && m >= minutes[0] && m < minutes[1]
+	}
+	if m >= minutes[0] {
+		return days.matchesDate(dateOf(t))
+	}
+	return m < minutes[1] && days.matchesDate(dateOf(t.AddDate(0, 0, -1)))
+}
+
+// Duration returns how long the window stays open, or 0 for an invalid
+// window
+func (w *Window) Duration() time.Duration {
+	_, minutes, err := w.bounds()
+	if err != nil {
+		return 0
+	}
+	return Minutes(((minutes[1]-minutes[0])%minutesPerDay + minutesPerDay) % minutesPerDay)
+}
+
+// Shift moves both ends of the window by d. When the start crosses
+// midnight, the days the window opens on move with it, and the end keeps
+// the same day constraints so the window stays the same length.
+func (w *Window) Shift(d Duration) error {
+	shifted, err := w.Shifted(d)
+	if err != nil {
+		return err
+	}
+	*w = *shifted
+	return nil
+}
+
+// Shifted returns a copy of the window with both ends moved by d, leaving
+// w unchanged
+func (w *Window) Shifted(d Duration) (*Window, error) {
+	return w.reshape(int(d/time.Minute), 0)
+}
+
+// Extend grows the window by opening it before earlier and closing it
+// after later; negative durations shrink it. The window must stay longer
+// than zero and shorter than a day.
+func (w *Window) Extend(before, after Duration) error {
+	extended, err := w.reshape(-int(before/time.Minute), int((before+after)/time.Minute))
+	if err != nil {
+		return err
+	}
+	*w = *extended
+	return nil
+}
+
+// reshape returns the window with its start moved by the given number of
+// minutes and its length changed by grow minutes
+func (w *Window) reshape(shift, grow int) (*Window, error) {
+	_, minutes, err := w.bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	length := ((minutes[1]-minutes[0])%minutesPerDay+minutesPerDay)%minutesPerDay + grow
+	if length <= 0 || length >= minutesPerDay {
+		return nil, fmt.Errorf("window would last %v, which is not between zero and a day", Minutes(length))
+	}
+
+	start := *w.Start
+	minute, hour, dayShift, err := start.shiftClock(int64(shift))
+	if err != nil {
+		return nil, err
+	}
+	if dayShift != 0 {
+		if err := start.ShiftDays(dayShift); err != nil {
+			return nil, fmt.Errorf("cannot move the days of the window: %v", err)
+		}
+	}
+	start.Minute, start.Hour = strconv.Itoa(minute), strconv.Itoa(hour)
+
+	end := start
+	m := (hour*60 + minute + length) % minutesPerDay
+	end.Minute, end.Hour = strconv.Itoa(m%60), strconv.Itoa(m/60)
+
+	return &Window{Start: &start, End: &end}, nil
+}
+
+// ErrNoIdealSlot is matched by the *SlotGapError returned when FindFreeSlot
+// finds no slot as far as asked from the existing firings
+var ErrNoIdealSlot = errors.New("no slot keeps the minimum gap")
+
+// SlotGapError reports the best slot FindFreeSlot found, Gap away from the
+// nearest existing firing where MinGap was asked for
+type SlotGapError struct {
+	Slot        *CronTime
+	Gap, MinGap Duration
+}
+
+func (e *SlotGapError) Error() string {
+	return fmt.Sprintf("%v: best slot %q is %v from the nearest firing, %v asked for", ErrNoIdealSlot, e.Slot, e.Gap, e.MinGap)
+}
+
+// Is reports whether target is ErrNoIdealSlot
+func (e *SlotGapError) Is(target error) bool {
+	return target == ErrNoIdealSlot
+}
+
+// FindFreeSlot returns an expression firing inside the window, on its
+// days, at a minute at least minGap away from every time of day the
+// existing expressions fire at, whatever days they fire on. Round times
+// are preferred, on the hour first, then on the half and quarter hour and
+// on multiples of 5 minutes, then those furthest from the existing
+// firings, then the earliest in the window. So with "0 2 * * *" and
+// "30 2 * * *" existing, a window from 2:00 to 4:00 and a 20 minute gap
+// give "0 3 * * *".
+//
+// When no minute keeps minGap, the one furthest from the existing firings
+// is returned along with a *SlotGapError giving how far it is.
+func FindFreeSlot(existing []*CronTime, window *Window, minGap Duration) (*CronTime, error) {
+	if minGap < 0 {
+		return nil, fmt.Errorf("minimum gap %v is negative", minGap)
+	}
+	_, minutes, err := window.bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	var busy []int
+	for _, c := range existing {
+		if c.IsReboot() {
+			continue
+		}
+		s, err := c.schedule()
+		if err != nil {
+			return nil, fmt.Errorf("existing expression %q: %v", c.String(), err)
+		}
+		busy = append(busy, expandDay(s.hour, s.minute)...)
+	}
+	// gapAt returns how many minutes m is from the nearest busy minute,
+	// across midnight too
+	gapAt := func(m int) int {
+		gap := minutesPerDay
+		for _, b := range busy {
+			d := (m - b + minutesPerDay) % minutesPerDay
+			gap = min(gap, d, minutesPerDay-d)
+		}
+		return gap
+	}
+
+	length := ((minutes[1]-minutes[0])%minutesPerDay + minutesPerDay) % minutesPerDay
+	best, bestGap := -1, 0
+	better := func(i, gap int) bool {
+		switch ideal, bestIdeal := Minutes(gap) >= minGap, Minutes(bestGap) >= minGap; {
+		case best < 0:
+			return true
+		case ideal != bestIdeal:
+			return ideal
+		case !ideal:
+			return gap > bestGap || gap == bestGap && roundness(minutes[0]+i) < roundness(minutes[0]+best)
+		}
+		r, bestR := roundness(minutes[0]+i), roundness(minutes[0]+best)
+		return r < bestR || r == bestR && gap > bestGap
+	}
+	for i := 0; i < length; i++ {
+		if gap := gapAt((minutes[0] + i) % minutesPerDay); better(i, gap) {
+			best, bestGap = i, gap
+		}
+	}
+
+	slot := *window.Start
+	if slot.layout.hasSeconds() {
+		slot.Second = "0"
+	}
+	minute, hour, dayShift, err := slot.shiftClock(int64(best))
+	if err != nil {
+		return nil, err
+	}
+	if dayShift != 0 {
+		if err := slot.ShiftDays(dayShift); err != nil {
+			return nil, fmt.Errorf("cannot move the days of the slot past midnight: %v", err)
+		}
+	}
+	slot.Minute, slot.Hour = strconv.Itoa(minute), strconv.Itoa(hour)
+
+	if Minutes(bestGap) < minGap {
+		return &slot, &SlotGapError{Slot: &slot, Gap: Minutes(bestGap), MinGap: minGap}
+	}
+	return &slot, nil
+}
+
+// roundness ranks a minute of the day by how round a time it is, 0 being
+// on the hour
+func roundness(m int) int {
+	switch {
+	case m%60 == 0:
+		return 0
+	case m%30 == 0:
+		return 1
+	case m%15 == 0:
+		return 2
+	case m%5 == 0:
+		return 3
+	}
+	return 4
+}
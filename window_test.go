@@ -0,0 +1,221 @@
+package cronmath
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustWindow(t *testing.T, start, end string) *Window {
+	t.Helper()
+	s, err := ParseCron(start)
+	if err != nil {
+		t.Fatalf("ParseCron(%q) error = %v", start, err)
+	}
+	e, err := ParseCron(end)
+	if err != nil {
+		t.Fatalf("ParseCron(%q) error = %v", end, err)
+	}
+	w, err := NewWindow(s, e)
+	if err != nil {
+		t.Fatalf("NewWindow() error = %v", err)
+	}
+	return w
+}
+
+func TestNewWindow_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+	}{
+		{"different days", "0 2 * * SUN", "0 6 * * MON"},
+		{"wildcard start", "* 2 * * SUN", "0 6 * * SUN"},
+		{"same time", "0 2 * * *", "0 2 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := ParseCron(tt.start)
+			e, _ := ParseCron(tt.end)
+			if _, err := NewWindow(s, e); err == nil {
+				t.Errorf("NewWindow(%q, %q) expected error, got nil", tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestWindow_Contains(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		// March 2025 starts on a Saturday, so the 2nd is a Sunday
+		return time.Date(2025, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		start, end string
+		t          time.Time
+		want       bool
+	}{
+		{"inside", "0 2 * * SUN", "0 6 * * 0", at(2, 3, 0), true},
+		{"at start", "0 2 * * SUN", "0 6 * * 0", at(2, 2, 0), true},
+		{"at end", "0 2 * * SUN", "0 6 * * 0", at(2, 6, 0), false},
+		{"wrong day", "0 2 * * SUN", "0 6 * * 0", at(3, 3, 0), false},
+		{"overnight before midnight", "0 22 * * SUN", "0 2 * * SUN", at(2, 23, 0), true},
+		{"overnight after midnight", "0 22 * * SUN", "0 2 * * SUN", at(3, 1, 0), true},
+		{"overnight early on its own day", "0 22 * * SUN", "0 2 * * SUN", at(2, 1, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := mustWindow(t, tt.start, tt.end)
+			if got := w.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindow_Duration(t *testing.T) {
+	if got, want := mustWindow(t, "0 2 * * SUN", "30 6 * * SUN").Duration(), 4*time.Hour+30*time.Minute; got != want {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+	if got, want := mustWindow(t, "0 22 * * *", "0 2 * * *").Duration(), 4*time.Hour; got != want {
+		t.Errorf("overnight Duration() = %v, want %v", got, want)
+	}
+}
+
+func TestWindow_Shift(t *testing.T) {
+	tests := []struct {
+		name               string
+		start, end         string
+		shift              time.Duration
+		wantStart, wantEnd string
+		wantErr            bool
+	}{
+		{"same day", "0 2 * * SUN", "0 6 * * SUN", Hours(1), "0 3 * * SUN", "0 7 * * SUN", false},
+		{"end crosses midnight", "0 20 * * SUN", "0 23 * * SUN", Hours(2), "0 22 * * SUN", "0 1 * * SUN", false},
+		{"start crosses midnight", "0 22 * * SUN", "0 2 * * SUN", Hours(3), "0 1 * * 1", "0 5 * * 1", false},
+		{"back into the previous day", "0 1 * * MON", "0 5 * * MON", -Hours(2), "0 23 * * 0", "0 3 * * 0", false},
+		{"day of month follows", "0 23 1 * *", "0 1 1 * *", Hours(2), "0 1 2 * *", "0 3 2 * *", false},
+		{"both day fields", "0 23 1 * MON", "0 1 1 * MON", Hours(2), "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := mustWindow(t, tt.start, tt.end)
+			before := w.Duration()
+
+			shifted, err := w.Shifted(tt.shift)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Shifted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if w.Start.String() != tt.start {
+				t.Errorf("Shifted() modified the window start to %q", w.Start.String())
+			}
+			if got := shifted.Start.String(); got != tt.wantStart {
+				t.Errorf("Shifted() start = %q, want %q", got, tt.wantStart)
+			}
+			if got := shifted.End.String(); got != tt.wantEnd {
+				t.Errorf("Shifted() end = %q, want %q", got, tt.wantEnd)
+			}
+			if got := shifted.Duration(); got != before {
+				t.Errorf("Shifted() duration = %v, want %v", got, before)
+			}
+
+			if err := w.Shift(tt.shift); err != nil {
+				t.Fatalf("Shift() error = %v", err)
+			}
+			if w.Start.String() != tt.wantStart || w.End.String() != tt.wantEnd {
+				t.Errorf("Shift() = %q to %q, want %q to %q", w.Start.String(), w.End.String(), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestWindow_Extend(t *testing.T) {
+	tests := []struct {
+		name               string
+		start, end         string
+		before, after      time.Duration
+		wantStart, wantEnd string
+		wantErr            bool
+	}{
+		{"both sides", "0 2 * * SUN", "0 6 * * SUN", Minutes(30), Hours(1), "30 1 * * SUN", "0 7 * * SUN", false},
+		{"across midnight", "0 1 * * SUN", "0 3 * * SUN", Hours(2), 0, "0 23 * * 6", "0 3 * * 6", false},
+		{"shrink", "0 2 * * *", "0 6 * * *", -Hours(1), -Hours(1), "0 3 * * *", "0 5 * * *", false},
+		{"shrink to nothing", "0 2 * * *", "0 6 * * *", -Hours(2), -Hours(2), "", "", true},
+		{"a whole day", "0 2 * * *", "0 6 * * *", Hours(10), Hours(10), "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := mustWindow(t, tt.start, tt.end)
+			err := w.Extend(tt.before, tt.after)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Extend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (w.Start.String() != tt.wantStart || w.End.String() != tt.wantEnd) {
+				t.Errorf("Extend() = %q to %q, want %q to %q", w.Start.String(), w.End.String(), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFindFreeSlot(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   []string
+		start, end string
+		minGap     time.Duration
+		want       string
+		wantGap    time.Duration
+		wantErr    bool
+	}{
+		{"on the hour", []string{"0 2 * * *", "30 2 * * *"}, "0 2 * * *", "0 4 * * *", Minutes(20), "0 3 * * *", 0, false},
+		{"on the half hour", []string{"0 2 * * *", "30 2 * * *"}, "0 2 * * *", "0 4 * * *", Minutes(45), "30 3 * * *", 0, false},
+		{"furthest on ties", []string{"0 1 * * *"}, "0 1 * * *", "0 4 * * *", Minutes(30), "0 3 * * *", 0, false},
+		{"nothing existing", nil, "0 2 * * *", "0 4 * * *", Hours(1), "0 2 * * *", 0, false},
+		{"reboot ignored", []string{"@reboot"}, "0 2 * * *", "0 4 * * *", Hours(1), "0 2 * * *", 0, false},
+		{"across midnight", []string{"0 23 * * *"}, "0 23 * * SUN", "0 1 * * SUN", Minutes(30), "0 0 * * 1", 0, false},
+		{"gaps wrap round midnight", []string{"50 23 * * *"}, "0 0 * * *", "30 0 * * *", Minutes(30), "25 0 * * *", 0, false},
+		{"no ideal slot", []string{"0 2 * * *", "30 2 * * *"}, "0 2 * * *", "0 4 * * *", Hours(2), "59 3 * * *", Minutes(89), true},
+		{"busy every quarter", []string{"*/15 * * * *"}, "0 1 * * *", "0 2 * * *", Minutes(10), "7 1 * * *", Minutes(7), true},
+		{"negative gap", nil, "0 2 * * *", "0 4 * * *", -Minutes(1), "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := make([]*CronTime, len(tt.existing))
+			for i, expr := range tt.existing {
+				existing[i] = mustParse(t, expr)
+			}
+			got, err := FindFreeSlot(existing, mustWindow(t, tt.start, tt.end), tt.minGap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindFreeSlot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gapErr *SlotGapError
+			if errors.As(err, &gapErr) && (gapErr.Gap != tt.wantGap || gapErr.MinGap != tt.minGap || !errors.Is(err, ErrNoIdealSlot)) {
+				t.Errorf("SlotGapError = %+v, want a gap of %v", *gapErr, tt.wantGap)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("FindFreeSlot() = %q, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.String() != tt.want {
+				t.Errorf("FindFreeSlot() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindFreeSlot_InvalidExisting(t *testing.T) {
+	invalid := &CronTime{Minute: "0", Hour: "25", DayOfMonth: "*", Month: "*", DayOfWeek: "*"}
+	if _, err := FindFreeSlot([]*CronTime{invalid}, mustWindow(t, "0 2 * * *", "0 4 * * *"), Minutes(1)); err == nil {
+		t.Error("FindFreeSlot() with an invalid expression succeeded")
+	}
+}
@@ -0,0 +1,146 @@
+package cronmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bounds of the Quartz year field
+const (
+	minYear = 1970
+	maxYear = 2099
+)
+
+// yearSet is a bitmap of the years a year field matches, bit n standing
+// for minYear+n. The empty set matches every year, as a missing year
+// field or "*" does.
+type yearSet [(maxYear - minYear + 64) / 64]uint64
+
+// parseYears expands a year field into the years it matches, leaving the
+// set empty when it matches every year of the field
+func parseYears(field string) (yearSet, error) {
+	var y yearSet
+	for _, part := range strings.Split(field, ",") {
+		lo, hi, step, err := parseRange(part, yearField)
+		if err != nil {
+			return yearSet{}, err
+		}
+		for v := lo; v <= hi; v += step {
+			y[(v-minYear)/64] |= 1 << uint((v-minYear)%64)
+		}
+	}
+	var all yearSet
+	for v := minYear; v <= maxYear; v++ {
+		all[(v-minYear)/64] |= 1 << uint((v-minYear)%64)
+	}
+	if y == all {
+		return yearSet{}, nil
+	}
+	return y, nil
+}
+
+// matches reports whether the set matches year
+func (y yearSet) matches(year int) bool {
+	if y == (yearSet{}) {
+		return true
+	}
+	n := year - minYear
+	return n >= 0 && n <= maxYear-minYear && y[n/64]&(1<<uint(n%64)) != 0
+}
+
+// last returns the last year in the set, reporting false when the set
+// matches every year
+func (y yearSet) last() (int, bool) {
+	for year := maxYear; year >= minYear && y != (yearSet{}); year-- {
+		if y.matches(year) {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// format renders the set as a list, collapsing runs of three or more
+// consecutive years into ranges, or as "*" when it matches every year
+func (y yearSet) format() string {
+	if y == (yearSet{}) {
+		return "*"
+	}
+	var parts []string
+	for year := minYear; year <= maxYear; year++ {
+		if !y.matches(year) {
+			continue
+		}
+		end := year
+		for end < maxYear && y.matches(end+1) {
+			end++
+		}
+		switch {
+		case end-year >= 2:
+			parts = append(parts, fmt.Sprintf("%d-%d", year, end))
+		case end > year:
+			parts = append(parts, strconv.Itoa(year), strconv.Itoa(end))
+		default:
+			parts = append(parts, strconv.Itoa(year))
+		}
+		year = end
+	}
+	return strings.Join(parts, ",")
+}
+
+// AddYears moves the years of a seven-field Quartz expression by n,
+// shifting single years, lists and ranges alike: "0 0 9 1 1 ? 2025" plus
+// one year becomes "0 0 9 1 1 ? 2026". Expressions without a year field,
+// or with a year field of "*", fire every year already; they are left
+// unchanged and a YearUnchanged warning is returned instead of an error.
+func (c *CronTime) AddYears(n int) ([]Warning, error) {
+	if c.Year == "" || !isRestricted(c.Year) {
+		return []Warning{{
+			Kind:     YearUnchanged,
+			Severity: SeverityWarning,
+			Field:    "year",
+			Message:  fmt.Sprintf("expression %q fires every year; years left unchanged", c.String()),
+		}}, nil
+	}
+
+	parts := strings.Split(c.Year, ",")
+	for i, part := range parts {
+		shifted, err := shiftYears(part, n)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = shifted
+	}
+	c.Year = strings.Join(parts, ",")
+	return nil, nil
+}
+
+// shiftYears moves the years of one element of a year field by n
+func shiftYears(part string, n int) (string, error) {
+	base, step, hasStep := strings.Cut(part, "/")
+	loStr, hiStr, isRange := strings.Cut(base, "-")
+
+	years := []string{loStr}
+	if isRange {
+		years = append(years, hiStr)
+	}
+	for i, s := range years {
+		y, err := strconv.Atoi(s)
+		if err != nil {
+			return "", fmt.Errorf("unsupported year format: %s", part)
+		}
+		if y < minYear || y > maxYear {
+			return "", fmt.Errorf("year %d out of range [%d, %d]", y, minYear, maxYear)
+		}
+		if y += n; y < minYear || y > maxYear {
+			return "", fmt.Errorf("year %d shifted by %d leaves range [%d, %d]", y-n, n, minYear, maxYear)
+		}
+		years[i] = strconv.Itoa(y)
+	}
+
+	shifted := strings.Join(years, "-")
+	if hasStep {
+		shifted += "/" + step
+	}
+	return shifted, nil
+}
@@ -0,0 +1,137 @@
+package cronmath
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronTime_AddYears(t *testing.T) {
+	tests := []struct {
+		name        string
+		cronStr     string
+		n           int
+		want        string
+		wantWarning bool
+		wantErr     bool
+	}{
+		{"single year", "0 0 9 1 1 ? 2025", 1, "0 0 9 1 1 ? 2026", false, false},
+		{"list and range", "0 0 9 1 1 ? 2025,2027-2029", 2, "0 0 9 1 1 ? 2027,2029-2031", false, false},
+		{"range with step", "0 0 9 1 1 ? 2025-2035/2", -1, "0 0 9 1 1 ? 2024-2034/2", false, false},
+		{"every year", "0 0 9 1 1 ? *", 1, "0 0 9 1 1 ? *", true, false},
+		{"no year field", "0 9 1 1 *", 1, "0 9 1 1 *", true, false},
+		{"past the last year", "0 0 9 1 1 ? 2099", 1, "", false, true},
+		{"before the first year", "0 0 9 1 1 ? 1970", -1, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronWith(tt.cronStr, WithAutoFields())
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			warnings, err := cron.AddYears(tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddYears() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if cron.String() != tt.cronStr {
+					t.Errorf("AddYears() changed the expression to %q on error", cron.String())
+				}
+				return
+			}
+			if (len(warnings) > 0) != tt.wantWarning {
+				t.Errorf("AddYears() warnings = %v, want warning %v", warnings, tt.wantWarning)
+			}
+			if len(warnings) > 0 && warnings[0].Kind != YearUnchanged {
+				t.Errorf("AddYears() warning kind = %v, want %v", warnings[0].Kind, YearUnchanged)
+			}
+			if got := cron.String(); got != tt.want {
+				t.Errorf("AddYears() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronMath_AddYears(t *testing.T) {
+	cm := New("0 0 9 1 1 ? 2025", WithAutoFields()).AddYears(1).AddYears(1)
+	if got, want := cm.String(), "0 0 9 1 1 ? 2027"; got != want {
+		t.Errorf("AddYears() = %q, want %q", got, want)
+	}
+
+	cm = New("0 9 1 1 *").AddYears(1)
+	if cm.Error() != nil || len(cm.Warnings()) != 1 {
+		t.Errorf("AddYears() error = %v, warnings = %v, want one warning", cm.Error(), cm.Warnings())
+	}
+}
+
+func TestYearField(t *testing.T) {
+	utc := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		cronStr  string
+		after    time.Time
+		want     time.Time
+		never    bool
+		runs2026 int
+	}{
+		{"before the year", "0 0 9 1 1 ? 2025", utc(2020, time.March, 1, 0), utc(2025, time.January, 1, 9), false, 0},
+		{"after the year", "0 0 9 1 1 ? 2025", utc(2026, time.January, 1, 0), time.Time{}, false, 0},
+		{"list of years", "0 0 9 1 1 ? 2025,2027", utc(2025, time.June, 1, 0), utc(2027, time.January, 1, 9), false, 0},
+		{"stepped years", "0 0 9 1 1 ? 2024-2099/2", utc(2025, time.June, 1, 0), utc(2026, time.January, 1, 9), false, 1},
+		{"before 2000", "0 0 9 1 1 ? 1990", utc(1980, time.January, 1, 0), utc(1990, time.January, 1, 9), false, 0},
+		{"every year", "0 0 9 1 1 ? *", utc(2099, time.June, 1, 0), utc(2100, time.January, 1, 9), false, 1},
+		{"no leap day that year", "0 0 9 29 2 ? 2025", utc(2020, time.January, 1, 0), time.Time{}, true, 0},
+		{"five fields and a year", "0 9 1 1 ? 2026", utc(2025, time.June, 1, 0), utc(2026, time.January, 1, 9), false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithAutoFields()}
+			if len(strings.Fields(tt.cronStr)) == 6 {
+				opts = []Option{WithDialect(EventBridge)}
+			}
+			cron, err := ParseCronWith(tt.cronStr, opts...)
+			if err != nil {
+				t.Fatalf("ParseCronWith() error = %v", err)
+			}
+			got, err := cron.Next(tt.after)
+			if tt.want.IsZero() {
+				if !errors.Is(err, ErrNeverFires) {
+					t.Errorf("Next() = %v, %v, want ErrNeverFires", got, err)
+				}
+			} else if err != nil || !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, %v, want %v", got, err, tt.want)
+			}
+			if never, _ := cron.NeverFires(); never != tt.never {
+				t.Errorf("NeverFires() = %v, want %v", never, tt.never)
+			}
+			if n, err := cron.Cardinality(2026, time.UTC); err != nil || n != tt.runs2026 {
+				t.Errorf("Cardinality(2026) = %d, %v, want %d", n, err, tt.runs2026)
+			}
+		})
+	}
+}
+
+func TestYearField_Invalid(t *testing.T) {
+	for _, cronStr := range []string{"0 0 9 1 1 ? abc", "0 0 9 1 1 ? 1800", "0 0 9 1 1 ? 2100", "0 0 9 1 1 ? 2030-2025", "0 0 9 1 1 ? ?"} {
+		for _, opt := range []Option{WithAutoFields(), WithDialect(Quartz)} {
+			_, err := ParseCronWith(cronStr, opt)
+			var pe *ParseError
+			if !errors.As(err, &pe) || pe.Field != "year" || pe.Index != 6 || pe.Value != strings.Fields(cronStr)[6] {
+				t.Errorf("ParseCronWith(%q) error = %v, want a *ParseError for the year", cronStr, err)
+			}
+		}
+		if err := ValidateString(cronStr, WithAutoFields()); err == nil {
+			t.Errorf("ValidateString(%q) succeeded", cronStr)
+		}
+	}
+
+	cron := &CronTime{Second: "0", Minute: "0", Hour: "9", DayOfMonth: "1", Month: "1", DayOfWeek: "?", Year: "1800", layout: LayoutSecondsYear}
+	if err := cron.ValidateFor(Quartz); err == nil {
+		t.Error("ValidateFor(Quartz) accepted year 1800")
+	}
+}